@@ -0,0 +1,9 @@
+data "ignition_resource" "historian" {
+  module = "com.inductiveautomation.historian"
+  type   = "historian-provider"
+  name   = "Core Historian"
+}
+
+output "historian_settings" {
+  value = jsondecode(data.ignition_resource.historian.config)
+}
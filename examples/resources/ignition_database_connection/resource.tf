@@ -5,3 +5,32 @@ resource "ignition_database_connection" "example" {
   username    = "dbuser"
   password    = "dbpass"
 }
+
+# Connection pool, validation and failover settings. Settings left out keep
+# the gateway's defaults.
+resource "ignition_database_connection" "tuned" {
+  name        = "historian_db"
+  type        = "PostgreSQL"
+  connect_url = "jdbc:postgresql://db.example.com:5432/historian"
+  username    = "historian"
+  password    = "dbpass"
+
+  connection_properties = {
+    ssl            = "true"
+    connectTimeout = "10"
+  }
+  default_transaction_level = "READ_COMMITTED"
+
+  pool_init_size  = 2
+  pool_max_active = 16
+  pool_max_wait   = 5000
+
+  validation_query    = "SELECT 1"
+  validation_interval = 10000
+  test_on_borrow      = true
+
+  slow_query_threshold = 30000
+
+  failover_datasource = ignition_database_connection.example.name
+  failover_mode       = "OPPORTUNISTIC"
+}
@@ -0,0 +1,21 @@
+resource "ignition_database_translator" "snowflake" {
+  name                   = "SNOWFLAKE"
+  create_table           = "CREATE TABLE {tablename} ({creationdef}{primarykeydef})"
+  alter_table            = "ALTER TABLE {tablename} {alterdef}"
+  alter_table_column_def = "ADD COLUMN {columnname} {type}"
+  primary_key_def        = "PRIMARY KEY ({columnname})"
+  auto_inc_type_def      = "{type} AUTOINCREMENT"
+  column_quote_char      = "\""
+  current_time_query     = "SELECT CURRENT_TIMESTAMP"
+  limit                  = "LIMIT {limit}"
+  limit_clause_position  = "Back"
+  supports_rgk           = false
+
+  i4_type       = "INTEGER"
+  i8_type       = "BIGINT"
+  r8_type       = "DOUBLE"
+  bool_type     = "BOOLEAN"
+  string_type   = "VARCHAR(255)"
+  text_type     = "VARCHAR"
+  datetime_type = "TIMESTAMP_NTZ"
+}
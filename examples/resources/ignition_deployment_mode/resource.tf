@@ -0,0 +1,14 @@
+resource "ignition_deployment_mode" "dev" {
+  name        = "dev"
+  title       = "Development"
+  description = "Overrides for development gateways"
+}
+
+# Overrides the core "ProductionDB" connection while the gateway runs in the dev mode
+resource "ignition_database_connection" "dev" {
+  name        = "ProductionDB"
+  collection  = ignition_deployment_mode.dev.name
+  type        = "PostgreSQL"
+  translator  = "POSTGRESQL"
+  connect_url = "jdbc:postgresql://dev-db:5432/mes"
+}
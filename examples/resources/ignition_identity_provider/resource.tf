@@ -1,13 +1,69 @@
+data "http" "azure_discovery" {
+  url = "https://login.microsoftonline.com/.../v2.0/.well-known/openid-configuration"
+}
+
 resource "ignition_identity_provider" "oidc" {
-  name = "AzureAD"
-  type = "oidc"
-  config = {
-    client_id                   = "my-client-id"
-    client_secret               = "my-client-secret"
-    provider_id                 = "azure-ad"
-    authorization_endpoint      = "https://login.microsoftonline.com/.../oauth2/v2.0/authorize"
-    token_endpoint              = "https://login.microsoftonline.com/.../oauth2/v2.0/token"
-    json_web_keys_endpoint      = "https://login.microsoftonline.com/.../discovery/v2.0/keys"
-    json_web_keys_endpoint_enabled = true
+  name          = "AzureAD"
+  type          = "oidc"
+  client_id     = "my-client-id"
+  client_secret = "my-client-secret"
+  scopes        = ["openid", "profile", "email"]
+  pkce_enabled  = true
+
+  # Fills in provider_id and the endpoints
+  discovery_document = data.http.azure_discovery.response_body
+
+  user_attribute_mapping = {
+    username  = "{id-token:preferred_username}"
+    firstName = "{id-token:given_name}"
+    lastName  = "{id-token:family_name}"
+    email     = "{id-token:email}"
+  }
+
+  role_mapping = [
+    { role = "Operator", expression = "contains({id-token:groups}, 'plant-operators')" },
+    { role = "Administrator", expression = "contains({id-token:groups}, 'plant-admins')" },
+  ]
+
+  security_level_rules = [
+    { expression = "{id-token:email_verified}", roles = ["Operator"], zones = ["Plant Floor"] },
+  ]
+}
+
+variable "sp_signing_private_key" {
+  type      = string
+  sensitive = true
+}
+
+resource "ignition_identity_provider" "saml" {
+  name          = "Okta"
+  type          = "saml"
+  idp_entity_id = "http://www.okta.com/exk123"
+
+  sso_service_config = {
+    uri     = "https://example.okta.com/app/exk123/sso/saml"
+    binding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+  }
+
+  # Import the IdP's signing certificates from its metadata, or set
+  # signature_verifying_certificates to a list of PEM certificates instead
+  idp_metadata_url             = "https://example.okta.com/app/exk123/sso/saml/metadata"
+  import_metadata_certificates = true
+
+  sp_signing_key = {
+    certificate = file("${path.module}/sp-signing.crt")
+    private_key = var.sp_signing_private_key
   }
 }
+
+resource "ignition_identity_provider" "internal" {
+  name        = "plant"
+  type        = "internal"
+  user_source = "default"
+
+  # Username and password, then a one-time password as a second factor
+  auth_methods = [
+    { type = "basic" },
+    { type = "totp", config = jsonencode({ issuer = "Plant Gateway" }) },
+  ]
+}
@@ -0,0 +1,20 @@
+resource "ignition_jdbc_driver" "snowflake" {
+  name                     = "Snowflake"
+  classname                = "net.snowflake.client.jdbc.SnowflakeDriver"
+  type                     = "GENERIC"
+  url_format               = "jdbc:snowflake://<account>.snowflakecomputing.com/?db=<database>"
+  default_translator       = ignition_database_translator.snowflake.name
+  default_validation_query = "SELECT 1"
+
+  # Uploaded again whenever the file's content changes
+  jar_files = ["${path.module}/drivers/snowflake-jdbc-3.16.1.jar"]
+}
+
+resource "ignition_database_connection" "warehouse" {
+  name        = "warehouse"
+  type        = ignition_jdbc_driver.snowflake.name
+  translator  = ignition_database_translator.snowflake.name
+  connect_url = "jdbc:snowflake://acme.snowflakecomputing.com/?db=PLANT"
+  username    = "ignition"
+  password    = var.warehouse_password
+}
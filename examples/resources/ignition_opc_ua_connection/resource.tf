@@ -1,10 +1,27 @@
 resource "ignition_opc_ua_connection" "example" {
-  name = "LocalOPCUA"
-  type = "com.inductiveautomation.OpcUaServerType"
-  endpoint = {
-    discovery_url   = "opc.tcp://localhost:4096"
-    endpoint_url    = "opc.tcp://localhost:4096"
-    security_policy = "http://opcfoundation.org/UA/SecurityPolicy#None"
-    security_mode   = "None"
-  }
+  name            = "LocalOPCUA"
+  type            = "com.inductiveautomation.OpcUaServerType"
+  discovery_url   = "opc.tcp://localhost:4096"
+  endpoint_url    = "opc.tcp://localhost:4096"
+  security_policy = "None"
+  security_mode   = "None"
+}
+
+# Authenticated connection whose server certificate is trusted up front, with
+# tuned session settings. Settings left out keep the gateway's defaults.
+resource "ignition_opc_ua_connection" "plant" {
+  name            = "PlantOPCUA"
+  discovery_url   = "opc.tcp://plc.example.com:4840"
+  endpoint_url    = "opc.tcp://plc.example.com:4840"
+  security_policy = "Basic256Sha256"
+  security_mode   = "SignAndEncrypt"
+
+  username           = "opcuser"
+  password           = "opcpass"
+  server_certificate = file("${path.module}/certs/plc.pem")
+
+  request_timeout             = 30000
+  session_timeout             = 120000
+  keep_alive_failures_allowed = 3
+  browse_origin               = "OBJECTS_FOLDER"
 }
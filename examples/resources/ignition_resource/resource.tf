@@ -0,0 +1,11 @@
+# A resource type of a third-party module, managed as raw JSON
+resource "ignition_resource" "broker" {
+  module      = "com.cirrus-link.mqtt.engine"
+  type        = "server-settings"
+  name        = "broker"
+  description = "Plant MQTT broker"
+  config = jsonencode({
+    url      = "tcp://broker:1883"
+    username = "ignition"
+  })
+}
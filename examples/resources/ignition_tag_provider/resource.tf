@@ -2,4 +2,26 @@ resource "ignition_tag_provider" "example" {
   name        = "MyTags"
   type        = "STANDARD"
   description = "A standard tag provider"
+
+  standard = {
+    history_enabled          = true
+    default_history_provider = "Historian"
+    allow_backfill           = true
+
+    permissions = [
+      { role = "Operator", access_level = "READ_WRITE" },
+      { access_level = "READ_ONLY" },
+    ]
+  }
+}
+
+resource "ignition_tag_provider" "site_a" {
+  name = "SiteA"
+  type = "REMOTE"
+
+  remote = {
+    gateway    = "site-a"
+    provider   = "default"
+    alarm_mode = "QUERIED"
+  }
 }
@@ -3,3 +3,27 @@ resource "ignition_user_source" "example" {
   type        = "INTERNAL"
   description = "Managed by Terraform"
 }
+
+variable "ad_bind_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "ignition_user_source" "corp" {
+  name = "corp"
+  type = "AD_DB_HYBRID"
+
+  active_directory = {
+    domain           = "corp.example.com"
+    primary_host     = "dc1.corp.example.com"
+    primary_port     = 636
+    use_ssl          = true
+    bind_username    = "svc-ignition@corp.example.com"
+    bind_password    = var.ad_bind_password
+    user_search_base = "OU=Users,DC=corp,DC=example,DC=com"
+  }
+
+  database = {
+    datasource = "users"
+  }
+}
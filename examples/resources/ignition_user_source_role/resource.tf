@@ -0,0 +1,9 @@
+resource "ignition_user_source" "internal" {
+  name = "internal-users"
+  type = "INTERNAL"
+}
+
+resource "ignition_user_source_role" "operator" {
+  user_source = ignition_user_source.internal.name
+  name        = "Operator"
+}
@@ -0,0 +1,22 @@
+variable "operator_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "ignition_user_source_user" "jdoe" {
+  user_source = ignition_user_source.internal.name
+  username    = "jdoe"
+  first_name  = "Jane"
+  last_name   = "Doe"
+  roles       = [ignition_user_source_role.operator.name]
+
+  contact_info = [
+    { type = "email", value = "jdoe@example.com" },
+    { type = "sms", value = "+15550100" },
+  ]
+
+  # Bump password_version to send a new password
+  password         = var.operator_password
+  password_version = "1"
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/export"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const exportUsage = `Usage:
+  terraform-provider-ignition export -backup <file.gwbk> [-out <file.tf> | -dir <directory>]
+  terraform-provider-ignition export -host <url> [-token <token>] [-out <file.tf> | -dir <directory>]
+
+Generates Terraform configuration and import blocks for every resource in a
+gateway backup or on a running gateway. References between resources are
+written as expressions and secrets such as passwords are read from variables.
+The API token defaults to the IGNITION_TOKEN environment variable.
+
+`
+
+// runExport implements the export subcommand
+func runExport(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(stderr, exportUsage)
+		fs.PrintDefaults()
+	}
+
+	var backupPath, host, token, outPath, dir string
+	var allowInsecure bool
+	fs.StringVar(&backupPath, "backup", "", "path to the gateway backup (.gwbk) to export")
+	fs.StringVar(&host, "host", "", "URL of the gateway to export")
+	fs.StringVar(&token, "token", "", "API token for the gateway (default $IGNITION_TOKEN)")
+	fs.BoolVar(&allowInsecure, "allow-insecure-tls", false, "allow insecure TLS connections to the gateway")
+	fs.StringVar(&outPath, "out", "", "file to write the configuration to (default stdout)")
+	fs.StringVar(&dir, "dir", "", "directory to write one file per resource type to")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if (backupPath == "") == (host == "") || (outPath != "" && dir != "") {
+		fs.Usage()
+		return 2
+	}
+
+	var resources []export.Resource
+	var diags diag.Diagnostics
+	if backupPath != "" {
+		resources, diags = export.Backup(ctx, backupPath)
+	} else {
+		if token == "" {
+			token = os.Getenv("IGNITION_TOKEN")
+		}
+		if token == "" {
+			_, _ = fmt.Fprintln(stderr, "Error: an API token is required to export a gateway; set -token or IGNITION_TOKEN")
+			return 2
+		}
+		c, err := client.NewClient(host, token, allowInsecure)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error: %s\n", err)
+			return 1
+		}
+		resources, diags = export.Gateway(ctx, c)
+	}
+	if printDiagnostics(stderr, diags) {
+		return 1
+	}
+
+	if dir != "" {
+		return writeFiles(ctx, dir, resources, stderr)
+	}
+
+	out := stdout
+	if outPath != "" {
+		f, err := os.Create(outPath)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error: %s\n", err)
+			return 1
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if printDiagnostics(stderr, export.Write(ctx, out, resources)) {
+		return 1
+	}
+	return 0
+}
+
+// writeFiles writes one configuration file per resource type into dir
+func writeFiles(ctx context.Context, dir string, resources []export.Resource, stderr io.Writer) int {
+	files, diags := export.Files(ctx, resources)
+	if printDiagnostics(stderr, diags) {
+		return 1
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.Name), f.Content, 0o644); err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error: %s\n", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+// printDiagnostics prints diagnostics to w and reports whether any were errors
+func printDiagnostics(w io.Writer, diags diag.Diagnostics) bool {
+	for _, d := range diags {
+		severity := "Warning"
+		if d.Severity() == diag.SeverityError {
+			severity = "Error"
+		}
+		_, _ = fmt.Fprintf(w, "%s: %s\n  %s\n", severity, d.Summary(), d.Detail())
+	}
+	return diags.HasError()
+}
@@ -0,0 +1,107 @@
+// Package acctest holds the helpers of the provider's acceptance tests. Only
+// test files import it, so that none of it is linked into the provider binary.
+package acctest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/fakegateway"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider"
+	"github.com/apollogeddon/ignition-tfpl/internal/recorder"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func init() {
+	// Run acceptance tests offline against an in-process fake gateway. The
+	// gateway lives for the lifetime of the test binary.
+	if os.Getenv("IGNITION_FAKE_GATEWAY") != "" {
+		g := fakegateway.New()
+		_ = os.Setenv("IGNITION_HOST", g.URL)
+		_ = os.Setenv("IGNITION_TOKEN", g.Token)
+	}
+
+	// Set default credentials for local testing with Docker if not already set
+	if os.Getenv("IGNITION_HOST") == "" {
+		_ = os.Setenv("IGNITION_HOST", "http://localhost:8088")
+	}
+	if os.Getenv("IGNITION_TOKEN") == "" {
+		_ = os.Setenv("IGNITION_TOKEN", "terraform:bNxwTt2cyiFUwWFliYY6Fc5flj-AcdqCjfNqn_-Lw8A")
+	}
+}
+
+// ProviderFactories returns the provider factories of an acceptance test. When
+// IGNITION_FIXTURES is set to record, the provider's requests to the gateway
+// are recorded to testdata/fixtures/<test name>.json. When it is set to replay,
+// they are answered from that file without a gateway, and tests that have not
+// been recorded fail.
+func ProviderFactories(t testing.TB) map[string]func() (tfprotov6.ProviderServer, error) {
+	t.Helper()
+
+	mode, err := recorder.ModeFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode == "" {
+		return map[string]func() (tfprotov6.ProviderServer, error){
+			"ignition": providerserver.NewProtocol6WithError(provider.New("test")()),
+		}
+	}
+
+	file := filepath.Join("testdata", "fixtures", strings.ReplaceAll(t.Name(), "/", "_")+".json")
+	rec, err := recorder.New(file, mode)
+	if recorder.IsNotRecorded(err) {
+		t.Fatalf("%s has not been recorded; record it against a gateway with %s=record", file, recorder.ModeEnv)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		// Keep the last good recording when a test fails or does not run
+		if t.Failed() || t.Skipped() {
+			return
+		}
+		if err := rec.Save(); err != nil {
+			t.Errorf("Failed to save %s: %v", file, err)
+		}
+	})
+
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(provider.NewWithTransport("test", rec.Transport)()),
+	}
+}
+
+var (
+	namesMu sync.Mutex
+	names   = make(map[string]int)
+)
+
+// Name returns a random name for a resource of an acceptance test. While
+// fixtures are recorded or replayed, names are derived from the name of the
+// test instead, so that its requests are the same on every run.
+func Name(t testing.TB) string {
+	if os.Getenv(recorder.ModeEnv) == "" {
+		const alphaNum = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+		name := make([]byte, 10)
+		for i := range name {
+			name[i] = alphaNum[rand.IntN(len(alphaNum))]
+		}
+		return string(name)
+	}
+
+	namesMu.Lock()
+	defer namesMu.Unlock()
+	n := names[t.Name()]
+	names[t.Name()] = n + 1
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(t.Name()))
+	return fmt.Sprintf("tf%08x%d", h.Sum32(), n)
+}
@@ -8,12 +8,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
 
 type IgnitionClient interface {
+	generatedResources
+
 	GetResource(ctx context.Context, resourceType, name string, dest any) error
 	CreateResource(ctx context.Context, resourceType string, item any, dest any) error
 	UpdateResource(ctx context.Context, resourceType string, item any, dest any) error
@@ -22,11 +26,32 @@ type IgnitionClient interface {
 	CreateResourceWithModule(ctx context.Context, module, resourceType string, item any, dest any) error
 	UpdateResourceWithModule(ctx context.Context, module, resourceType string, item any, dest any) error
 	DeleteResourceWithModule(ctx context.Context, module, resourceType, name, signature string) error
+	ListResourcesWithModule(ctx context.Context, module, resourceType string) ([]ResourceListItem, error)
+	RenameResourceWithModule(ctx context.Context, module, resourceType, name, newName, signature string) error
+	UploadResourceFile(ctx context.Context, module, resourceType, name, filename, signature string, data []byte) error
+	DeleteResourceFile(ctx context.Context, module, resourceType, name, filename, signature string) error
 	EncryptSecret(ctx context.Context, plaintext string) (*IgnitionSecret, error)
 	GetProject(ctx context.Context, name string) (*Project, error)
 	CreateProject(ctx context.Context, p Project) (*Project, error)
 	UpdateProject(ctx context.Context, p Project) (*Project, error)
 	DeleteProject(ctx context.Context, name string) error
+	ListProjects(ctx context.Context) ([]Project, error)
+	RenameProject(ctx context.Context, name, newName string) (*Project, error)
+	GetDeploymentMode(ctx context.Context, name string) (*DeploymentMode, error)
+	CreateDeploymentMode(ctx context.Context, m DeploymentMode) (*DeploymentMode, error)
+	UpdateDeploymentMode(ctx context.Context, m DeploymentMode) (*DeploymentMode, error)
+	DeleteDeploymentMode(ctx context.Context, name string) error
+	ListDeploymentModes(ctx context.Context) ([]DeploymentMode, error)
+	GetUserSourceUser(ctx context.Context, userSource, username string) (*UserSourceUser, error)
+	CreateUserSourceUser(ctx context.Context, userSource string, u UserSourceUser) (*UserSourceUser, error)
+	UpdateUserSourceUser(ctx context.Context, userSource string, u UserSourceUser) (*UserSourceUser, error)
+	DeleteUserSourceUser(ctx context.Context, userSource, username string) error
+	GetUserSourceRole(ctx context.Context, userSource, name string) (*UserSourceRole, error)
+	CreateUserSourceRole(ctx context.Context, userSource string, role UserSourceRole) (*UserSourceRole, error)
+	DeleteUserSourceRole(ctx context.Context, userSource, name string) error
+	TrustOpcUaCertificate(ctx context.Context, cert OpcUaTrustedCertificate) error
+	DeleteOpcUaTrustedCertificate(ctx context.Context, thumbprint string) error
+	GetResourceTypeSchema(ctx context.Context, module, resourceType string) (*ConfigSchema, error)
 	GetDatabaseConnection(ctx context.Context, name string) (*ResourceResponse[DatabaseConfig], error)
 	CreateDatabaseConnection(ctx context.Context, db ResourceResponse[DatabaseConfig]) (*ResourceResponse[DatabaseConfig], error)
 	UpdateDatabaseConnection(ctx context.Context, db ResourceResponse[DatabaseConfig]) (*ResourceResponse[DatabaseConfig], error)
@@ -81,10 +106,29 @@ type IgnitionClient interface {
 	DeleteDevice(ctx context.Context, name, signature string) error
 }
 
+// resourceClient is the part of IgnitionClient that addresses resources by module and type
+type resourceClient interface {
+	GetResourceWithModule(ctx context.Context, module, resourceType, name string, dest any) error
+	CreateResourceWithModule(ctx context.Context, module, resourceType string, item any, dest any) error
+	UpdateResourceWithModule(ctx context.Context, module, resourceType string, item any, dest any) error
+	DeleteResourceWithModule(ctx context.Context, module, resourceType, name, signature string) error
+}
+
+// typedResources implements the typed resource methods of IgnitionClient on top
+// of a resourceClient, so that every backend shares them
+type typedResources struct {
+	resourceClient
+}
+
 type Client struct {
+	typedResources
+
 	HostURL    string
 	HTTPClient *retryablehttp.Client
 	Token      string
+
+	openAPIMu sync.Mutex
+	openAPI   *openAPIDocument
 }
 
 func NewClient(host, token string, allowInsecureTLS bool) (*Client, error) {
@@ -100,17 +144,23 @@ func NewClient(host, token string, allowInsecureTLS bool) (*Client, error) {
 		}
 	}
 
-	return &Client{HTTPClient: rc, HostURL: host, Token: token}, nil
+	c := &Client{HTTPClient: rc, HostURL: host, Token: token}
+	c.typedResources = typedResources{c}
+	return c, nil
 }
 
 func (c *Client) doRequest(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	return c.doRequestWithContentType(ctx, method, path, "application/json", body)
+}
+
+func (c *Client) doRequestWithContentType(ctx context.Context, method, path, contentType string, body []byte) ([]byte, error) {
 	req, err := retryablehttp.NewRequestWithContext(ctx, method, c.HostURL+path, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("X-Ignition-API-Token", c.Token)
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
 	res, err := c.HTTPClient.Do(req)
@@ -135,7 +185,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body []byte
 	return bodyBytes, nil
 }
 
-func (c *Client) GetResource(ctx context.Context, resourceType, name string, dest any) error {
+func (c typedResources) GetResource(ctx context.Context, resourceType, name string, dest any) error {
 	return c.GetResourceWithModule(ctx, "ignition", resourceType, name, dest)
 }
 
@@ -144,7 +194,7 @@ func (c *Client) GetResourceWithModule(ctx context.Context, module, resourceType
 	if name == "" {
 		path = fmt.Sprintf("/data/api/v1/resources/find/%s/%s", module, resourceType)
 	}
-	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	body, err := c.doRequest(ctx, http.MethodGet, withCollectionQuery(ctx, path), nil)
 	if err != nil {
 		return err
 	}
@@ -158,7 +208,7 @@ func (c *Client) createOrUpdate(ctx context.Context, method, module, resourceTyp
 	}
 
 	path := fmt.Sprintf("/data/api/v1/resources/%s/%s", module, resourceType)
-	body, err := c.doRequest(ctx, method, path, rb)
+	body, err := c.doRequest(ctx, method, withCollectionQuery(ctx, path), rb)
 	if err != nil {
 		return err
 	}
@@ -166,12 +216,12 @@ func (c *Client) createOrUpdate(ctx context.Context, method, module, resourceTyp
 	return c.unmarshalResourceResponse(ctx, module, resourceType, body, dest)
 }
 
-func (c *Client) CreateResource(ctx context.Context, resourceType string, item, dest any) error {
-	return c.createOrUpdate(ctx, http.MethodPost, "ignition", resourceType, item, dest)
+func (c typedResources) CreateResource(ctx context.Context, resourceType string, item, dest any) error {
+	return c.CreateResourceWithModule(ctx, "ignition", resourceType, item, dest)
 }
 
-func (c *Client) UpdateResource(ctx context.Context, resourceType string, item, dest any) error {
-	return c.createOrUpdate(ctx, http.MethodPut, "ignition", resourceType, item, dest)
+func (c typedResources) UpdateResource(ctx context.Context, resourceType string, item, dest any) error {
+	return c.UpdateResourceWithModule(ctx, "ignition", resourceType, item, dest)
 }
 
 func (c *Client) CreateResourceWithModule(ctx context.Context, module, resourceType string, item, dest any) error {
@@ -182,16 +232,92 @@ func (c *Client) UpdateResourceWithModule(ctx context.Context, module, resourceT
 	return c.createOrUpdate(ctx, http.MethodPut, module, resourceType, item, dest)
 }
 
-func (c *Client) DeleteResource(ctx context.Context, resourceType, name, signature string) error {
+func (c typedResources) DeleteResource(ctx context.Context, resourceType, name, signature string) error {
 	return c.DeleteResourceWithModule(ctx, "ignition", resourceType, name, signature)
 }
 
 func (c *Client) DeleteResourceWithModule(ctx context.Context, module, resourceType, name, signature string) error {
 	path := fmt.Sprintf("/data/api/v1/resources/%s/%s/%s/%s", module, resourceType, name, signature)
-	_, err := c.doRequest(ctx, http.MethodDelete, path, nil)
+	_, err := c.doRequest(ctx, http.MethodDelete, withCollectionQuery(ctx, path), nil)
 	return err
 }
 
+func (c *Client) RenameResourceWithModule(ctx context.Context, module, resourceType, name, newName, signature string) error {
+	rb, err := json.Marshal([]ResourceRename{{Name: name, NewName: newName, Signature: signature}})
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/data/api/v1/resources/rename/%s/%s", module, resourceType)
+	_, err = c.doRequest(ctx, http.MethodPost, withCollectionQuery(ctx, path), rb)
+	return err
+}
+
+// UploadResourceFile stores a data file (e.g., a driver JAR) alongside a
+// resource's config. The upload changes the resource's signature.
+func (c *Client) UploadResourceFile(ctx context.Context, module, resourceType, name, filename, signature string, data []byte) error {
+	path := fmt.Sprintf("/data/api/v1/resources/datafile/%s/%s/%s/%s?signature=%s",
+		module, resourceType, name, url.PathEscape(filename), url.QueryEscape(signature))
+	_, err := c.doRequestWithContentType(ctx, http.MethodPut, withCollectionQuery(ctx, path), "application/octet-stream", data)
+	return err
+}
+
+// DeleteResourceFile removes a data file of a resource. Like an upload, it
+// changes the resource's signature.
+func (c *Client) DeleteResourceFile(ctx context.Context, module, resourceType, name, filename, signature string) error {
+	path := fmt.Sprintf("/data/api/v1/resources/datafile/%s/%s/%s/%s?signature=%s",
+		module, resourceType, name, url.PathEscape(filename), url.QueryEscape(signature))
+	_, err := c.doRequest(ctx, http.MethodDelete, withCollectionQuery(ctx, path), nil)
+	return err
+}
+
+// listPageSize is the number of items requested per page when listing resources
+const listPageSize = 100
+
+func (c *Client) ListResourcesWithModule(ctx context.Context, module, resourceType string) ([]ResourceListItem, error) {
+	var items []ResourceListItem
+	for offset := 0; ; offset += listPageSize {
+		path := fmt.Sprintf("/data/api/v1/resources/list/%s/%s?limit=%d&offset=%d", module, resourceType, listPageSize, offset)
+		body, err := c.doRequest(ctx, http.MethodGet, withCollectionQuery(ctx, path), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		page, total, err := unmarshalListPage[ResourceListItem](body)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+
+		if len(page) < listPageSize || (total > 0 && len(items) >= total) {
+			return items, nil
+		}
+	}
+}
+
+// unmarshalListPage accepts either a bare JSON array or a paginated
+// {"items": [...], "metadata": {...}} envelope and returns the items and the
+// total reported by the gateway (0 when unknown).
+func unmarshalListPage[T any](body []byte) ([]T, int, error) {
+	if len(body) == 0 {
+		return nil, 0, fmt.Errorf("empty response body")
+	}
+
+	if body[0] == '[' {
+		var items []T
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, 0, fmt.Errorf("failed to unmarshal list response: %w", err)
+		}
+		return items, 0, nil
+	}
+
+	var page ResourceListResponse[T]
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal list response: %w", err)
+	}
+	return page.Items, page.Metadata.Total, nil
+}
+
 func (c *Client) unmarshalResourceResponse(ctx context.Context, module, resourceType string, body []byte, dest any) error {
 	if len(body) == 0 {
 		return fmt.Errorf("empty response body")
@@ -214,196 +340,196 @@ func (c *Client) unmarshalResourceResponse(ctx context.Context, module, resource
 	return json.Unmarshal(rawItems[0], dest)
 }
 
-func getR[T any](ctx context.Context, c *Client, m, t, n string) (*ResourceResponse[T], error) {
+func getR[T any](ctx context.Context, c resourceClient, m, t, n string) (*ResourceResponse[T], error) {
 	var r ResourceResponse[T]
 	err := c.GetResourceWithModule(ctx, m, t, n, &r)
 	return &r, err
 }
 
-func (c *Client) GetDatabaseConnection(ctx context.Context, n string) (*ResourceResponse[DatabaseConfig], error) {
+func (c typedResources) GetDatabaseConnection(ctx context.Context, n string) (*ResourceResponse[DatabaseConfig], error) {
 	return getR[DatabaseConfig](ctx, c, "ignition", "database-connection", n)
 }
-func (c *Client) CreateDatabaseConnection(ctx context.Context, i ResourceResponse[DatabaseConfig]) (*ResourceResponse[DatabaseConfig], error) {
+func (c typedResources) CreateDatabaseConnection(ctx context.Context, i ResourceResponse[DatabaseConfig]) (*ResourceResponse[DatabaseConfig], error) {
 	var r ResourceResponse[DatabaseConfig]
 	err := c.CreateResource(ctx, "database-connection", i, &r)
 	return &r, err
 }
-func (c *Client) UpdateDatabaseConnection(ctx context.Context, i ResourceResponse[DatabaseConfig]) (*ResourceResponse[DatabaseConfig], error) {
+func (c typedResources) UpdateDatabaseConnection(ctx context.Context, i ResourceResponse[DatabaseConfig]) (*ResourceResponse[DatabaseConfig], error) {
 	var r ResourceResponse[DatabaseConfig]
 	err := c.UpdateResource(ctx, "database-connection", i, &r)
 	return &r, err
 }
-func (c *Client) DeleteDatabaseConnection(ctx context.Context, n, s string) error {
+func (c typedResources) DeleteDatabaseConnection(ctx context.Context, n, s string) error {
 	return c.DeleteResource(ctx, "database-connection", n, s)
 }
 
-func (c *Client) GetUserSource(ctx context.Context, n string) (*ResourceResponse[UserSourceConfig], error) {
+func (c typedResources) GetUserSource(ctx context.Context, n string) (*ResourceResponse[UserSourceConfig], error) {
 	return getR[UserSourceConfig](ctx, c, "ignition", "user-source", n)
 }
-func (c *Client) CreateUserSource(ctx context.Context, i ResourceResponse[UserSourceConfig]) (*ResourceResponse[UserSourceConfig], error) {
+func (c typedResources) CreateUserSource(ctx context.Context, i ResourceResponse[UserSourceConfig]) (*ResourceResponse[UserSourceConfig], error) {
 	var r ResourceResponse[UserSourceConfig]
 	err := c.CreateResource(ctx, "user-source", i, &r)
 	return &r, err
 }
-func (c *Client) UpdateUserSource(ctx context.Context, i ResourceResponse[UserSourceConfig]) (*ResourceResponse[UserSourceConfig], error) {
+func (c typedResources) UpdateUserSource(ctx context.Context, i ResourceResponse[UserSourceConfig]) (*ResourceResponse[UserSourceConfig], error) {
 	var r ResourceResponse[UserSourceConfig]
 	err := c.UpdateResource(ctx, "user-source", i, &r)
 	return &r, err
 }
-func (c *Client) DeleteUserSource(ctx context.Context, n, s string) error {
+func (c typedResources) DeleteUserSource(ctx context.Context, n, s string) error {
 	return c.DeleteResource(ctx, "user-source", n, s)
 }
 
-func (c *Client) GetTagProvider(ctx context.Context, n string) (*ResourceResponse[TagProviderConfig], error) {
+func (c typedResources) GetTagProvider(ctx context.Context, n string) (*ResourceResponse[TagProviderConfig], error) {
 	return getR[TagProviderConfig](ctx, c, "ignition", "tag-provider", n)
 }
-func (c *Client) CreateTagProvider(ctx context.Context, i ResourceResponse[TagProviderConfig]) (*ResourceResponse[TagProviderConfig], error) {
+func (c typedResources) CreateTagProvider(ctx context.Context, i ResourceResponse[TagProviderConfig]) (*ResourceResponse[TagProviderConfig], error) {
 	var r ResourceResponse[TagProviderConfig]
 	err := c.CreateResource(ctx, "tag-provider", i, &r)
 	return &r, err
 }
-func (c *Client) UpdateTagProvider(ctx context.Context, i ResourceResponse[TagProviderConfig]) (*ResourceResponse[TagProviderConfig], error) {
+func (c typedResources) UpdateTagProvider(ctx context.Context, i ResourceResponse[TagProviderConfig]) (*ResourceResponse[TagProviderConfig], error) {
 	var r ResourceResponse[TagProviderConfig]
 	err := c.UpdateResource(ctx, "tag-provider", i, &r)
 	return &r, err
 }
-func (c *Client) DeleteTagProvider(ctx context.Context, n, s string) error {
+func (c typedResources) DeleteTagProvider(ctx context.Context, n, s string) error {
 	return c.DeleteResource(ctx, "tag-provider", n, s)
 }
 
-func (c *Client) GetAuditProfile(ctx context.Context, n string) (*ResourceResponse[AuditProfileConfig], error) {
+func (c typedResources) GetAuditProfile(ctx context.Context, n string) (*ResourceResponse[AuditProfileConfig], error) {
 	return getR[AuditProfileConfig](ctx, c, "ignition", "audit-profile", n)
 }
-func (c *Client) CreateAuditProfile(ctx context.Context, i ResourceResponse[AuditProfileConfig]) (*ResourceResponse[AuditProfileConfig], error) {
+func (c typedResources) CreateAuditProfile(ctx context.Context, i ResourceResponse[AuditProfileConfig]) (*ResourceResponse[AuditProfileConfig], error) {
 	var r ResourceResponse[AuditProfileConfig]
 	err := c.CreateResource(ctx, "audit-profile", i, &r)
 	return &r, err
 }
-func (c *Client) UpdateAuditProfile(ctx context.Context, i ResourceResponse[AuditProfileConfig]) (*ResourceResponse[AuditProfileConfig], error) {
+func (c typedResources) UpdateAuditProfile(ctx context.Context, i ResourceResponse[AuditProfileConfig]) (*ResourceResponse[AuditProfileConfig], error) {
 	var r ResourceResponse[AuditProfileConfig]
 	err := c.UpdateResource(ctx, "audit-profile", i, &r)
 	return &r, err
 }
-func (c *Client) DeleteAuditProfile(ctx context.Context, n, s string) error {
+func (c typedResources) DeleteAuditProfile(ctx context.Context, n, s string) error {
 	return c.DeleteResource(ctx, "audit-profile", n, s)
 }
 
-func (c *Client) GetAlarmNotificationProfile(ctx context.Context, n string) (*ResourceResponse[AlarmNotificationProfileConfig], error) {
+func (c typedResources) GetAlarmNotificationProfile(ctx context.Context, n string) (*ResourceResponse[AlarmNotificationProfileConfig], error) {
 	return getR[AlarmNotificationProfileConfig](ctx, c, "com.inductiveautomation.alarm-notification", "alarm-notification-profile", n)
 }
-func (c *Client) CreateAlarmNotificationProfile(ctx context.Context, i ResourceResponse[AlarmNotificationProfileConfig]) (*ResourceResponse[AlarmNotificationProfileConfig], error) {
+func (c typedResources) CreateAlarmNotificationProfile(ctx context.Context, i ResourceResponse[AlarmNotificationProfileConfig]) (*ResourceResponse[AlarmNotificationProfileConfig], error) {
 	var r ResourceResponse[AlarmNotificationProfileConfig]
 	err := c.CreateResourceWithModule(ctx, "com.inductiveautomation.alarm-notification", "alarm-notification-profile", i, &r)
 	return &r, err
 }
-func (c *Client) UpdateAlarmNotificationProfile(ctx context.Context, i ResourceResponse[AlarmNotificationProfileConfig]) (*ResourceResponse[AlarmNotificationProfileConfig], error) {
+func (c typedResources) UpdateAlarmNotificationProfile(ctx context.Context, i ResourceResponse[AlarmNotificationProfileConfig]) (*ResourceResponse[AlarmNotificationProfileConfig], error) {
 	var r ResourceResponse[AlarmNotificationProfileConfig]
 	err := c.UpdateResourceWithModule(ctx, "com.inductiveautomation.alarm-notification", "alarm-notification-profile", i, &r)
 	return &r, err
 }
-func (c *Client) DeleteAlarmNotificationProfile(ctx context.Context, n, s string) error {
+func (c typedResources) DeleteAlarmNotificationProfile(ctx context.Context, n, s string) error {
 	return c.DeleteResourceWithModule(ctx, "com.inductiveautomation.alarm-notification", "alarm-notification-profile", n, s)
 }
 
-func (c *Client) GetOpcUaConnection(ctx context.Context, n string) (*ResourceResponse[OpcUaConnectionConfig], error) {
+func (c typedResources) GetOpcUaConnection(ctx context.Context, n string) (*ResourceResponse[OpcUaConnectionConfig], error) {
 	return getR[OpcUaConnectionConfig](ctx, c, "ignition", "opc-connection", n)
 }
-func (c *Client) CreateOpcUaConnection(ctx context.Context, i ResourceResponse[OpcUaConnectionConfig]) (*ResourceResponse[OpcUaConnectionConfig], error) {
+func (c typedResources) CreateOpcUaConnection(ctx context.Context, i ResourceResponse[OpcUaConnectionConfig]) (*ResourceResponse[OpcUaConnectionConfig], error) {
 	var r ResourceResponse[OpcUaConnectionConfig]
 	err := c.CreateResourceWithModule(ctx, "ignition", "opc-connection", i, &r)
 	return &r, err
 }
-func (c *Client) UpdateOpcUaConnection(ctx context.Context, i ResourceResponse[OpcUaConnectionConfig]) (*ResourceResponse[OpcUaConnectionConfig], error) {
+func (c typedResources) UpdateOpcUaConnection(ctx context.Context, i ResourceResponse[OpcUaConnectionConfig]) (*ResourceResponse[OpcUaConnectionConfig], error) {
 	var r ResourceResponse[OpcUaConnectionConfig]
 	err := c.UpdateResourceWithModule(ctx, "ignition", "opc-connection", i, &r)
 	return &r, err
 }
-func (c *Client) DeleteOpcUaConnection(ctx context.Context, n, s string) error {
+func (c typedResources) DeleteOpcUaConnection(ctx context.Context, n, s string) error {
 	return c.DeleteResourceWithModule(ctx, "ignition", "opc-connection", n, s)
 }
 
-func (c *Client) GetAlarmJournal(ctx context.Context, n string) (*ResourceResponse[AlarmJournalConfig], error) {
+func (c typedResources) GetAlarmJournal(ctx context.Context, n string) (*ResourceResponse[AlarmJournalConfig], error) {
 	return getR[AlarmJournalConfig](ctx, c, "ignition", "alarm-journal", n)
 }
-func (c *Client) CreateAlarmJournal(ctx context.Context, i ResourceResponse[AlarmJournalConfig]) (*ResourceResponse[AlarmJournalConfig], error) {
+func (c typedResources) CreateAlarmJournal(ctx context.Context, i ResourceResponse[AlarmJournalConfig]) (*ResourceResponse[AlarmJournalConfig], error) {
 	var r ResourceResponse[AlarmJournalConfig]
 	err := c.CreateResourceWithModule(ctx, "ignition", "alarm-journal", i, &r)
 	return &r, err
 }
-func (c *Client) UpdateAlarmJournal(ctx context.Context, i ResourceResponse[AlarmJournalConfig]) (*ResourceResponse[AlarmJournalConfig], error) {
+func (c typedResources) UpdateAlarmJournal(ctx context.Context, i ResourceResponse[AlarmJournalConfig]) (*ResourceResponse[AlarmJournalConfig], error) {
 	var r ResourceResponse[AlarmJournalConfig]
 	err := c.UpdateResourceWithModule(ctx, "ignition", "alarm-journal", i, &r)
 	return &r, err
 }
-func (c *Client) DeleteAlarmJournal(ctx context.Context, n, s string) error {
+func (c typedResources) DeleteAlarmJournal(ctx context.Context, n, s string) error {
 	return c.DeleteResourceWithModule(ctx, "ignition", "alarm-journal", n, s)
 }
 
-func (c *Client) GetSMTPProfile(ctx context.Context, n string) (*ResourceResponse[SMTPProfileConfig], error) {
+func (c typedResources) GetSMTPProfile(ctx context.Context, n string) (*ResourceResponse[SMTPProfileConfig], error) {
 	return getR[SMTPProfileConfig](ctx, c, "ignition", "email-profile", n)
 }
-func (c *Client) CreateSMTPProfile(ctx context.Context, i ResourceResponse[SMTPProfileConfig]) (*ResourceResponse[SMTPProfileConfig], error) {
+func (c typedResources) CreateSMTPProfile(ctx context.Context, i ResourceResponse[SMTPProfileConfig]) (*ResourceResponse[SMTPProfileConfig], error) {
 	var r ResourceResponse[SMTPProfileConfig]
 	err := c.CreateResource(ctx, "email-profile", i, &r)
 	return &r, err
 }
-func (c *Client) UpdateSMTPProfile(ctx context.Context, i ResourceResponse[SMTPProfileConfig]) (*ResourceResponse[SMTPProfileConfig], error) {
+func (c typedResources) UpdateSMTPProfile(ctx context.Context, i ResourceResponse[SMTPProfileConfig]) (*ResourceResponse[SMTPProfileConfig], error) {
 	var r ResourceResponse[SMTPProfileConfig]
 	err := c.UpdateResource(ctx, "email-profile", i, &r)
 	return &r, err
 }
-func (c *Client) DeleteSMTPProfile(ctx context.Context, n, s string) error {
+func (c typedResources) DeleteSMTPProfile(ctx context.Context, n, s string) error {
 	return c.DeleteResource(ctx, "email-profile", n, s)
 }
 
-func (c *Client) GetStoreAndForward(ctx context.Context, n string) (*ResourceResponse[StoreAndForwardConfig], error) {
+func (c typedResources) GetStoreAndForward(ctx context.Context, n string) (*ResourceResponse[StoreAndForwardConfig], error) {
 	return getR[StoreAndForwardConfig](ctx, c, "ignition", "store-and-forward-engine", n)
 }
-func (c *Client) CreateStoreAndForward(ctx context.Context, i ResourceResponse[StoreAndForwardConfig]) (*ResourceResponse[StoreAndForwardConfig], error) {
+func (c typedResources) CreateStoreAndForward(ctx context.Context, i ResourceResponse[StoreAndForwardConfig]) (*ResourceResponse[StoreAndForwardConfig], error) {
 	var r ResourceResponse[StoreAndForwardConfig]
 	err := c.CreateResource(ctx, "store-and-forward-engine", i, &r)
 	return &r, err
 }
-func (c *Client) UpdateStoreAndForward(ctx context.Context, i ResourceResponse[StoreAndForwardConfig]) (*ResourceResponse[StoreAndForwardConfig], error) {
+func (c typedResources) UpdateStoreAndForward(ctx context.Context, i ResourceResponse[StoreAndForwardConfig]) (*ResourceResponse[StoreAndForwardConfig], error) {
 	var r ResourceResponse[StoreAndForwardConfig]
 	err := c.UpdateResource(ctx, "store-and-forward-engine", i, &r)
 	return &r, err
 }
-func (c *Client) DeleteStoreAndForward(ctx context.Context, n, s string) error {
+func (c typedResources) DeleteStoreAndForward(ctx context.Context, n, s string) error {
 	return c.DeleteResource(ctx, "store-and-forward-engine", n, s)
 }
 
-func (c *Client) GetIdentityProvider(ctx context.Context, n string) (*ResourceResponse[IdentityProviderConfig], error) {
+func (c typedResources) GetIdentityProvider(ctx context.Context, n string) (*ResourceResponse[IdentityProviderConfig], error) {
 	return getR[IdentityProviderConfig](ctx, c, "ignition", "identity-provider", n)
 }
-func (c *Client) CreateIdentityProvider(ctx context.Context, i ResourceResponse[IdentityProviderConfig]) (*ResourceResponse[IdentityProviderConfig], error) {
+func (c typedResources) CreateIdentityProvider(ctx context.Context, i ResourceResponse[IdentityProviderConfig]) (*ResourceResponse[IdentityProviderConfig], error) {
 	var r ResourceResponse[IdentityProviderConfig]
 	err := c.CreateResource(ctx, "identity-provider", i, &r)
 	return &r, err
 }
-func (c *Client) UpdateIdentityProvider(ctx context.Context, i ResourceResponse[IdentityProviderConfig]) (*ResourceResponse[IdentityProviderConfig], error) {
+func (c typedResources) UpdateIdentityProvider(ctx context.Context, i ResourceResponse[IdentityProviderConfig]) (*ResourceResponse[IdentityProviderConfig], error) {
 	var r ResourceResponse[IdentityProviderConfig]
 	err := c.UpdateResource(ctx, "identity-provider", i, &r)
 	return &r, err
 }
-func (c *Client) DeleteIdentityProvider(ctx context.Context, n, s string) error {
+func (c typedResources) DeleteIdentityProvider(ctx context.Context, n, s string) error {
 	return c.DeleteResource(ctx, "identity-provider", n, s)
 }
 
-func (c *Client) GetGanOutgoing(ctx context.Context, n string) (*ResourceResponse[GanOutgoingConfig], error) {
+func (c typedResources) GetGanOutgoing(ctx context.Context, n string) (*ResourceResponse[GanOutgoingConfig], error) {
 	return getR[GanOutgoingConfig](ctx, c, "ignition", "gateway-network-outgoing", n)
 }
-func (c *Client) CreateGanOutgoing(ctx context.Context, i ResourceResponse[GanOutgoingConfig]) (*ResourceResponse[GanOutgoingConfig], error) {
+func (c typedResources) CreateGanOutgoing(ctx context.Context, i ResourceResponse[GanOutgoingConfig]) (*ResourceResponse[GanOutgoingConfig], error) {
 	var r ResourceResponse[GanOutgoingConfig]
 	err := c.CreateResource(ctx, "gateway-network-outgoing", i, &r)
 	return &r, err
 }
-func (c *Client) UpdateGanOutgoing(ctx context.Context, i ResourceResponse[GanOutgoingConfig]) (*ResourceResponse[GanOutgoingConfig], error) {
+func (c typedResources) UpdateGanOutgoing(ctx context.Context, i ResourceResponse[GanOutgoingConfig]) (*ResourceResponse[GanOutgoingConfig], error) {
 	var r ResourceResponse[GanOutgoingConfig]
 	err := c.UpdateResource(ctx, "gateway-network-outgoing", i, &r)
 	return &r, err
 }
-func (c *Client) DeleteGanOutgoing(ctx context.Context, n, s string) error {
+func (c typedResources) DeleteGanOutgoing(ctx context.Context, n, s string) error {
 	return c.DeleteResource(ctx, "gateway-network-outgoing", n, s)
 }
 
@@ -424,29 +550,29 @@ func (c *Client) UpdateRedundancyConfig(ctx context.Context, config RedundancyCo
 	return err
 }
 
-func (c *Client) GetGanGeneralSettings(ctx context.Context) (*ResourceResponse[GanGeneralSettingsConfig], error) {
+func (c typedResources) GetGanGeneralSettings(ctx context.Context) (*ResourceResponse[GanGeneralSettingsConfig], error) {
 	return getR[GanGeneralSettingsConfig](ctx, c, "ignition", "gateway-network-settings", "")
 }
-func (c *Client) UpdateGanGeneralSettings(ctx context.Context, i ResourceResponse[GanGeneralSettingsConfig]) (*ResourceResponse[GanGeneralSettingsConfig], error) {
+func (c typedResources) UpdateGanGeneralSettings(ctx context.Context, i ResourceResponse[GanGeneralSettingsConfig]) (*ResourceResponse[GanGeneralSettingsConfig], error) {
 	var r ResourceResponse[GanGeneralSettingsConfig]
 	err := c.UpdateResource(ctx, "gateway-network-settings", i, &r)
 	return &r, err
 }
 
-func (c *Client) GetDevice(ctx context.Context, n string) (*ResourceResponse[DeviceConfig], error) {
+func (c typedResources) GetDevice(ctx context.Context, n string) (*ResourceResponse[DeviceConfig], error) {
 	return getR[DeviceConfig](ctx, c, "com.inductiveautomation.opcua", "device", n)
 }
-func (c *Client) CreateDevice(ctx context.Context, i ResourceResponse[DeviceConfig]) (*ResourceResponse[DeviceConfig], error) {
+func (c typedResources) CreateDevice(ctx context.Context, i ResourceResponse[DeviceConfig]) (*ResourceResponse[DeviceConfig], error) {
 	var r ResourceResponse[DeviceConfig]
 	err := c.CreateResourceWithModule(ctx, "com.inductiveautomation.opcua", "device", i, &r)
 	return &r, err
 }
-func (c *Client) UpdateDevice(ctx context.Context, i ResourceResponse[DeviceConfig]) (*ResourceResponse[DeviceConfig], error) {
+func (c typedResources) UpdateDevice(ctx context.Context, i ResourceResponse[DeviceConfig]) (*ResourceResponse[DeviceConfig], error) {
 	var r ResourceResponse[DeviceConfig]
 	err := c.UpdateResourceWithModule(ctx, "com.inductiveautomation.opcua", "device", i, &r)
 	return &r, err
 }
-func (c *Client) DeleteDevice(ctx context.Context, n, s string) error {
+func (c typedResources) DeleteDevice(ctx context.Context, n, s string) error {
 	return c.DeleteResourceWithModule(ctx, "com.inductiveautomation.opcua", "device", n, s)
 }
 
@@ -486,6 +612,159 @@ func (c *Client) DeleteProject(ctx context.Context, name string) error {
 	return err
 }
 
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/data/api/v1/projects/list", nil)
+	if err != nil {
+		return nil, err
+	}
+	projects, _, err := unmarshalListPage[Project](body)
+	return projects, err
+}
+
+func (c *Client) RenameProject(ctx context.Context, name, newName string) (*Project, error) {
+	rb, err := json.Marshal(map[string]string{"name": newName})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.doRequest(ctx, http.MethodPost, "/data/api/v1/projects/rename/"+name, rb); err != nil {
+		return nil, err
+	}
+	return c.waitForProject(ctx, newName)
+}
+
+func (c *Client) GetDeploymentMode(ctx context.Context, name string) (*DeploymentMode, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/data/api/v1/modes/find/"+name, nil)
+	if err != nil {
+		return nil, err
+	}
+	var m DeploymentMode
+	return &m, json.Unmarshal(body, &m)
+}
+
+func (c *Client) CreateDeploymentMode(ctx context.Context, m DeploymentMode) (*DeploymentMode, error) {
+	rb, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.doRequest(ctx, http.MethodPost, "/data/api/v1/modes", rb); err != nil {
+		return nil, err
+	}
+	return c.GetDeploymentMode(ctx, m.Name)
+}
+
+func (c *Client) UpdateDeploymentMode(ctx context.Context, m DeploymentMode) (*DeploymentMode, error) {
+	rb, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.doRequest(ctx, http.MethodPut, "/data/api/v1/modes/"+m.Name, rb); err != nil {
+		return nil, err
+	}
+	return c.GetDeploymentMode(ctx, m.Name)
+}
+
+func (c *Client) DeleteDeploymentMode(ctx context.Context, name string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, "/data/api/v1/modes/"+name, nil)
+	return err
+}
+
+func (c *Client) ListDeploymentModes(ctx context.Context) ([]DeploymentMode, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, "/data/api/v1/modes/list", nil)
+	if err != nil {
+		return nil, err
+	}
+	modes, _, err := unmarshalListPage[DeploymentMode](body)
+	return modes, err
+}
+
+// userSourcePath is the path of the users or roles of a user source, as managed
+// by the gateway's user management endpoints
+func userSourcePath(userSource, kind string) string {
+	return "/data/api/v1/user-sources/" + url.PathEscape(userSource) + "/" + kind
+}
+
+func (c *Client) GetUserSourceUser(ctx context.Context, userSource, username string) (*UserSourceUser, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, userSourcePath(userSource, "users")+"/find/"+url.PathEscape(username), nil)
+	if err != nil {
+		return nil, err
+	}
+	var u UserSourceUser
+	return &u, json.Unmarshal(body, &u)
+}
+
+func (c *Client) CreateUserSourceUser(ctx context.Context, userSource string, u UserSourceUser) (*UserSourceUser, error) {
+	rb, err := json.Marshal(u)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.doRequest(ctx, http.MethodPost, userSourcePath(userSource, "users"), rb); err != nil {
+		return nil, err
+	}
+	return c.GetUserSourceUser(ctx, userSource, u.Username)
+}
+
+// UpdateUserSourceUser replaces the user's details. The password is kept when
+// u has none.
+func (c *Client) UpdateUserSourceUser(ctx context.Context, userSource string, u UserSourceUser) (*UserSourceUser, error) {
+	rb, err := json.Marshal(u)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.doRequest(ctx, http.MethodPut, userSourcePath(userSource, "users")+"/"+url.PathEscape(u.Username), rb); err != nil {
+		return nil, err
+	}
+	return c.GetUserSourceUser(ctx, userSource, u.Username)
+}
+
+func (c *Client) DeleteUserSourceUser(ctx context.Context, userSource, username string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, userSourcePath(userSource, "users")+"/"+url.PathEscape(username), nil)
+	return err
+}
+
+func (c *Client) GetUserSourceRole(ctx context.Context, userSource, name string) (*UserSourceRole, error) {
+	body, err := c.doRequest(ctx, http.MethodGet, userSourcePath(userSource, "roles")+"/find/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	var role UserSourceRole
+	return &role, json.Unmarshal(body, &role)
+}
+
+func (c *Client) CreateUserSourceRole(ctx context.Context, userSource string, role UserSourceRole) (*UserSourceRole, error) {
+	rb, err := json.Marshal(role)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.doRequest(ctx, http.MethodPost, userSourcePath(userSource, "roles"), rb); err != nil {
+		return nil, err
+	}
+	return c.GetUserSourceRole(ctx, userSource, role.Name)
+}
+
+func (c *Client) DeleteUserSourceRole(ctx context.Context, userSource, name string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, userSourcePath(userSource, "roles")+"/"+url.PathEscape(name), nil)
+	return err
+}
+
+// opcUaTrustedCertificatesPath is the trust store of the gateway's OPC UA client
+const opcUaTrustedCertificatesPath = "/data/api/v1/opcua/client/trusted-certificates"
+
+// TrustOpcUaCertificate adds a certificate to the trust store of the gateway's
+// OPC UA client. Adding a certificate that is already trusted does nothing.
+func (c *Client) TrustOpcUaCertificate(ctx context.Context, cert OpcUaTrustedCertificate) error {
+	rb, err := json.Marshal(cert)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(ctx, http.MethodPut, opcUaTrustedCertificatesPath+"/"+url.PathEscape(cert.Thumbprint), rb)
+	return err
+}
+
+func (c *Client) DeleteOpcUaTrustedCertificate(ctx context.Context, thumbprint string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, opcUaTrustedCertificatesPath+"/"+url.PathEscape(thumbprint), nil)
+	return err
+}
+
 func (c *Client) waitForProject(ctx context.Context, name string) (*Project, error) {
 	ticker := time.NewTicker(200 * time.Millisecond)
 	defer ticker.Stop()
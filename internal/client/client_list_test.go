@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestClient_ListResourcesWithModule_Pagination(t *testing.T) {
+	total := listPageSize + 5
+
+	// Mock Server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/api/v1/resources/list/ignition/database-connection" {
+			t.Errorf("Expected path /data/api/v1/resources/list/ignition/database-connection, got %s", r.URL.Path)
+		}
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		var page ResourceListResponse[ResourceListItem]
+		for i := offset; i < total && i < offset+limit; i++ {
+			page.Items = append(page.Items, ResourceListItem{Name: fmt.Sprintf("db-%d", i)})
+		}
+		page.Metadata.Total = total
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	// Client
+	c, err := NewClient(server.URL, "test-token", false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	items, err := c.ListResourcesWithModule(context.Background(), "ignition", "database-connection")
+	if err != nil {
+		t.Fatalf("ListResourcesWithModule failed: %v", err)
+	}
+	if len(items) != total {
+		t.Fatalf("Expected %d items, got %d", total, len(items))
+	}
+	if items[total-1].Name != fmt.Sprintf("db-%d", total-1) {
+		t.Errorf("Expected last item db-%d, got %s", total-1, items[total-1].Name)
+	}
+}
+
+func TestClient_ListProjects(t *testing.T) {
+	// Mock Server returns a bare array rather than a paginated envelope
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/api/v1/projects/list" {
+			t.Errorf("Expected path /data/api/v1/projects/list, got %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode([]Project{{Name: "hmi"}, {Name: "mes"}})
+	}))
+	defer server.Close()
+
+	// Client
+	c, err := NewClient(server.URL, "test-token", false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	projects, err := c.ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("ListProjects failed: %v", err)
+	}
+	if len(projects) != 2 || projects[1].Name != "mes" {
+		t.Errorf("Expected projects [hmi mes], got %v", projects)
+	}
+}
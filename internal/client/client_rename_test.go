@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_RenameResourceWithModule(t *testing.T) {
+	// Mock Server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/data/api/v1/resources/rename/ignition/tag-provider" {
+			t.Errorf("Expected path /data/api/v1/resources/rename/ignition/tag-provider, got %s", r.URL.Path)
+		}
+
+		var renames []ResourceRename
+		if err := json.NewDecoder(r.Body).Decode(&renames); err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if len(renames) != 1 || renames[0] != (ResourceRename{Name: "old", NewName: "new", Signature: "sig-123"}) {
+			t.Errorf("Unexpected rename request: %+v", renames)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Client
+	c, err := NewClient(server.URL, "test-token", false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if err := c.RenameResourceWithModule(context.Background(), "ignition", "tag-provider", "old", "new", "sig-123"); err != nil {
+		t.Fatalf("RenameResourceWithModule failed: %v", err)
+	}
+}
+
+func TestClient_RenameProject(t *testing.T) {
+	// Mock Server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/data/api/v1/projects/rename/old":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["name"] != "new" {
+				t.Errorf("Expected new name 'new', got %q", body["name"])
+			}
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodGet && r.URL.Path == "/data/api/v1/projects/find/new":
+			_ = json.NewEncoder(w).Encode(Project{Name: "new", Enabled: true})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Client
+	c, err := NewClient(server.URL, "test-token", false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	p, err := c.RenameProject(context.Background(), "old", "new")
+	if err != nil {
+		t.Fatalf("RenameProject failed: %v", err)
+	}
+	if p.Name != "new" {
+		t.Errorf("Expected project 'new', got %q", p.Name)
+	}
+}
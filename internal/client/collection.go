@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+type collectionKey struct{}
+
+// WithCollection returns a copy of ctx that directs the resource requests made
+// with it to the given config collection (e.g., core, or a deployment mode).
+// An empty collection leaves the choice to the gateway.
+func WithCollection(ctx context.Context, collection string) context.Context {
+	return context.WithValue(ctx, collectionKey{}, collection)
+}
+
+// CollectionFrom returns the collection set on ctx by WithCollection, or an
+// empty string when none was set
+func CollectionFrom(ctx context.Context) string {
+	collection, _ := ctx.Value(collectionKey{}).(string)
+	return collection
+}
+
+// withCollectionQuery adds the collection set on ctx, if any, to the query of path
+func withCollectionQuery(ctx context.Context, path string) string {
+	collection := CollectionFrom(ctx)
+	if collection == "" {
+		return path
+	}
+
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + "collection=" + url.QueryEscape(collection)
+}
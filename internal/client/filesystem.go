@@ -0,0 +1,1461 @@
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCollection is the resource collection new resources are created in
+const DefaultCollection = "core"
+
+// filesystemActor is recorded as the author of every change made on disk
+const filesystemActor = "terraform"
+
+// filesystemSingletons are the resource types that exist exactly once and are
+// stored directly in their type directory. Updating one that does not exist yet
+// creates it, as a fresh data directory may not contain them.
+var filesystemSingletons = map[string]bool{
+	"ignition/gateway-network-settings": true,
+}
+
+// FilesystemClient implements IgnitionClient on the configuration files in an
+// Ignition 8.3 gateway's data directory instead of the REST API, so that
+// configuration can be written into a volume or image without a running
+// gateway. Resources are stored as
+// config/resources/<collection>/<module>/<type>[/<name>]/{resource.json,config.json}
+// and are read from the most derived collection that defines them.
+type FilesystemClient struct {
+	typedResources
+
+	// DataDir is the gateway's data directory
+	DataDir string
+	// Collection is the collection new resources are created in, unless the
+	// request's context names another with WithCollection. Existing resources
+	// are always written back to the collection they were read from.
+	Collection string
+
+	mu sync.Mutex
+}
+
+// NewFilesystemClient returns a FilesystemClient for the data directory at dataDir
+func NewFilesystemClient(dataDir string) (*FilesystemClient, error) {
+	info, err := os.Stat(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("error opening data directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("data directory %s is not a directory", dataDir)
+	}
+
+	c := &FilesystemClient{DataDir: dataDir, Collection: DefaultCollection}
+	c.typedResources = typedResources{c}
+	return c, nil
+}
+
+// storedResource is a resource located in the data directory
+type storedResource struct {
+	collection string
+	dir        string
+	name       string
+}
+
+func (c *FilesystemClient) GetResourceWithModule(ctx context.Context, module, resourceType, name string, dest any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loc, ok, err := c.locate(ctx, module, resourceType, name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return notFound(module, resourceType, name)
+	}
+
+	res, err := c.read(module, resourceType, loc)
+	if err != nil {
+		return err
+	}
+	return roundTrip(res, dest)
+}
+
+func (c *FilesystemClient) CreateResourceWithModule(ctx context.Context, module, resourceType string, item, dest any) error {
+	var res ResourceResponse[json.RawMessage]
+	if err := roundTrip(item, &res); err != nil {
+		return err
+	}
+	if err := validName(res.Name); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, exists, err := c.locate(ctx, module, resourceType, res.Name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("resource already exists: %s/%s/%s", module, resourceType, res.Name)
+	}
+
+	return c.writeAndRead(module, resourceType, c.newLocation(ctx, module, resourceType, res.Name), res, dest)
+}
+
+func (c *FilesystemClient) UpdateResourceWithModule(ctx context.Context, module, resourceType string, item, dest any) error {
+	var res ResourceResponse[json.RawMessage]
+	if err := roundTrip(item, &res); err != nil {
+		return err
+	}
+	if err := validName(res.Name); err != nil {
+		return err
+	}
+	singleton := filesystemSingletons[module+"/"+resourceType]
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loc, ok, err := c.locate(ctx, module, resourceType, res.Name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if !singleton {
+			return notFound(module, resourceType, res.Name)
+		}
+		return c.writeAndRead(module, resourceType, c.newLocation(ctx, module, resourceType, res.Name), res, dest)
+	}
+
+	if res.Signature != "" || !singleton {
+		if err := c.checkSignature(module, resourceType, loc, res.Signature); err != nil {
+			return err
+		}
+	}
+	return c.writeAndRead(module, resourceType, loc, res, dest)
+}
+
+func (c *FilesystemClient) DeleteResourceWithModule(ctx context.Context, module, resourceType, name, signature string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loc, ok, err := c.locate(ctx, module, resourceType, name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return notFound(module, resourceType, name)
+	}
+	if err := c.checkSignature(module, resourceType, loc, signature); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(loc.dir); err != nil {
+		return err
+	}
+	return pruneEmptyDirs(filepath.Dir(loc.dir), c.typeDir(loc.collection, module, resourceType))
+}
+
+func (c *FilesystemClient) RenameResourceWithModule(ctx context.Context, module, resourceType, name, newName, signature string) error {
+	if err := validName(newName); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loc, ok, err := c.locate(ctx, module, resourceType, name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return notFound(module, resourceType, name)
+	}
+	if _, exists, err := c.locate(ctx, module, resourceType, newName); err != nil {
+		return err
+	} else if exists {
+		return fmt.Errorf("resource already exists: %s/%s/%s", module, resourceType, newName)
+	}
+	if err := c.checkSignature(module, resourceType, loc, signature); err != nil {
+		return err
+	}
+
+	res, err := c.read(module, resourceType, loc)
+	if err != nil {
+		return err
+	}
+
+	renamed := storedResource{
+		collection: loc.collection,
+		dir:        c.nameDir(loc.collection, module, resourceType, newName),
+		name:       newName,
+	}
+	if err := os.MkdirAll(filepath.Dir(renamed.dir), 0o755); err != nil {
+		return err
+	}
+	if err := os.Rename(loc.dir, renamed.dir); err != nil {
+		return err
+	}
+	if err := pruneEmptyDirs(filepath.Dir(loc.dir), c.typeDir(loc.collection, module, resourceType)); err != nil {
+		return err
+	}
+
+	// Sign the resource again, as its identity has changed
+	res.Name = newName
+	return c.write(module, resourceType, renamed, *res)
+}
+
+// UploadResourceFile stores a data file in the resource's directory and lists
+// it in resource.json
+func (c *FilesystemClient) UploadResourceFile(ctx context.Context, module, resourceType, name, filename, signature string, data []byte) error {
+	if !validDataFileName(filename) {
+		return fmt.Errorf("invalid data file name %q", filename)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loc, ok, err := c.locate(ctx, module, resourceType, name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return notFound(module, resourceType, name)
+	}
+	if err := c.checkSignature(module, resourceType, loc, signature); err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(filepath.Join(loc.dir, filename), data); err != nil {
+		return err
+	}
+	meta, err := readJSONObject(filepath.Join(loc.dir, "resource.json"))
+	if err != nil {
+		return err
+	}
+	files, _ := meta["files"].([]any)
+	if !slices.Contains(files, any(filename)) {
+		meta["files"] = append(files, filename)
+		if err := writeJSONFile(filepath.Join(loc.dir, "resource.json"), meta); err != nil {
+			return err
+		}
+	}
+
+	// Sign the resource again, as its files have changed
+	res, err := c.read(module, resourceType, loc)
+	if err != nil {
+		return err
+	}
+	return c.write(module, resourceType, loc, *res)
+}
+
+// DeleteResourceFile removes a data file from the resource's directory and
+// from the files listed in resource.json
+func (c *FilesystemClient) DeleteResourceFile(ctx context.Context, module, resourceType, name, filename, signature string) error {
+	if !validDataFileName(filename) {
+		return fmt.Errorf("invalid data file name %q", filename)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loc, ok, err := c.locate(ctx, module, resourceType, name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return notFound(module, resourceType, name)
+	}
+	if err := c.checkSignature(module, resourceType, loc, signature); err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(loc.dir, filename)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	meta, err := readJSONObject(filepath.Join(loc.dir, "resource.json"))
+	if err != nil {
+		return err
+	}
+	files, _ := meta["files"].([]any)
+	if i := slices.Index(files, any(filename)); i >= 0 {
+		meta["files"] = slices.Delete(files, i, i+1)
+		if err := writeJSONFile(filepath.Join(loc.dir, "resource.json"), meta); err != nil {
+			return err
+		}
+	}
+
+	// Sign the resource again, as its files have changed
+	res, err := c.read(module, resourceType, loc)
+	if err != nil {
+		return err
+	}
+	return c.write(module, resourceType, loc, *res)
+}
+
+// validDataFileName reports whether a data file name stays within the
+// resource's directory and does not replace its config
+func validDataFileName(filename string) bool {
+	return filename != "" && filename != "." && filename != ".." && !strings.ContainsAny(filename, "/\\") &&
+		filename != "config.json" && filename != "resource.json"
+}
+
+func (c *FilesystemClient) ListResourcesWithModule(ctx context.Context, module, resourceType string) ([]ResourceListItem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	collections, err := c.collections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var items []ResourceListItem
+	for _, collection := range collections {
+		typeDir := c.typeDir(collection, module, resourceType)
+		err := filepath.WalkDir(typeDir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || d.Name() != "resource.json" {
+				return nil
+			}
+
+			dir := filepath.Dir(p)
+			name := resourceType
+			if dir != typeDir {
+				rel, err := filepath.Rel(typeDir, dir)
+				if err != nil {
+					return err
+				}
+				name = filepath.ToSlash(rel)
+			}
+			if seen[name] {
+				return nil
+			}
+			seen[name] = true
+
+			res, err := c.read(module, resourceType, storedResource{collection: collection, dir: dir, name: name})
+			if err != nil {
+				return err
+			}
+			items = append(items, ResourceListItem{
+				Name:        res.Name,
+				Collection:  collection,
+				Enabled:     res.Enabled,
+				Description: res.Description,
+				Signature:   res.Signature,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items, nil
+}
+
+// EncryptSecret always fails, as secrets are encrypted with a key that only
+// the gateway holds
+func (c *FilesystemClient) EncryptSecret(ctx context.Context, plaintext string) (*IgnitionSecret, error) {
+	return nil, fmt.Errorf("secrets cannot be encrypted in filesystem mode, as encryption requires a running gateway")
+}
+
+// resourcesDir is the directory holding every resource collection
+func (c *FilesystemClient) resourcesDir() string {
+	return filepath.Join(c.DataDir, "config", "resources")
+}
+
+func (c *FilesystemClient) typeDir(collection, module, resourceType string) string {
+	return filepath.Join(c.resourcesDir(), collection, module, resourceType)
+}
+
+func (c *FilesystemClient) nameDir(collection, module, resourceType, name string) string {
+	return filepath.Join(c.typeDir(collection, module, resourceType), filepath.FromSlash(name))
+}
+
+// newLocation returns where a new resource is stored: the collection set on ctx
+// with WithCollection, or else c.Collection. Singletons are stored directly in
+// their type directory and are named after their type.
+func (c *FilesystemClient) newLocation(ctx context.Context, module, resourceType, name string) storedResource {
+	collection := CollectionFrom(ctx)
+	if collection == "" {
+		collection = c.Collection
+	}
+	if filesystemSingletons[module+"/"+resourceType] {
+		return storedResource{collection: collection, dir: c.typeDir(collection, module, resourceType), name: resourceType}
+	}
+	return storedResource{collection: collection, dir: c.nameDir(collection, module, resourceType, name), name: name}
+}
+
+// collections returns the names of the resource collections to search, the
+// most derived first. A collection set on ctx with WithCollection is the only
+// one searched.
+func (c *FilesystemClient) collections(ctx context.Context) ([]string, error) {
+	if collection := CollectionFrom(ctx); collection != "" {
+		if err := validCollectionName(collection); err != nil {
+			return nil, err
+		}
+		return []string{collection}, nil
+	}
+
+	entries, err := os.ReadDir(c.resourcesDir())
+	if errors.Is(err, fs.ErrNotExist) {
+		return []string{c.Collection}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	parents := make(map[string]string)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		var mode struct {
+			Parent string `json:"parent"`
+		}
+		data, err := os.ReadFile(filepath.Join(c.resourcesDir(), e.Name(), "config-mode.json"))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &mode); err != nil {
+				return nil, fmt.Errorf("error parsing config-mode.json of collection %s: %w", e.Name(), err)
+			}
+		}
+		parents[e.Name()] = mode.Parent
+	}
+
+	depth := func(name string) int {
+		d := 0
+		seen := make(map[string]bool)
+		for name != "" && !seen[name] {
+			seen[name] = true
+			name = parents[name]
+			d++
+		}
+		return d
+	}
+
+	names := make([]string, 0, len(parents))
+	for name := range parents {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if di, dj := depth(names[i]), depth(names[j]); di != dj {
+			return di > dj
+		}
+		return names[i] < names[j]
+	})
+	return names, nil
+}
+
+// locate finds the named resource in the most derived collection that defines
+// it. An empty name, or the type itself, finds a singleton.
+func (c *FilesystemClient) locate(ctx context.Context, module, resourceType, name string) (storedResource, bool, error) {
+	if name != "" {
+		if err := validName(name); err != nil {
+			return storedResource{}, false, err
+		}
+	}
+
+	collections, err := c.collections(ctx)
+	if err != nil {
+		return storedResource{}, false, err
+	}
+
+	for _, collection := range collections {
+		var candidates []storedResource
+		if name == "" || name == resourceType {
+			candidates = append(candidates, storedResource{collection: collection, dir: c.typeDir(collection, module, resourceType), name: resourceType})
+		}
+		if name != "" {
+			candidates = append(candidates, storedResource{collection: collection, dir: c.nameDir(collection, module, resourceType, name), name: name})
+		}
+
+		for _, loc := range candidates {
+			if _, err := os.Stat(filepath.Join(loc.dir, "resource.json")); err == nil {
+				return loc, true, nil
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return storedResource{}, false, err
+			}
+		}
+	}
+	return storedResource{}, false, nil
+}
+
+// read reads a located resource in its REST API shape
+func (c *FilesystemClient) read(module, resourceType string, loc storedResource) (*ResourceResponse[json.RawMessage], error) {
+	meta, err := readJSONObject(filepath.Join(loc.dir, "resource.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := os.ReadFile(filepath.Join(loc.dir, "config.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		stored = []byte(`{}`)
+	} else if err != nil {
+		return nil, err
+	}
+
+	config, err := APIConfig(module, resourceType, stored)
+	if err != nil {
+		return nil, fmt.Errorf("error converting %s/%s %q: %w", module, resourceType, loc.name, err)
+	}
+
+	res := &ResourceResponse[json.RawMessage]{
+		Module:     module,
+		Type:       APIResourceType(module, resourceType, stored),
+		Name:       loc.name,
+		Collection: loc.collection,
+		Config:     config,
+	}
+	res.Description, _ = meta["description"].(string)
+
+	attributes, _ := meta["attributes"].(map[string]any)
+	if enabled, ok := attributes["enabled"].(bool); ok {
+		res.Enabled = &enabled
+	}
+	res.Signature, _ = attributes["lastModificationSignature"].(string)
+	if res.Signature == "" {
+		// Resources written by hand carry no signature
+		if res.Signature, err = sign(module, resourceType, loc.name, meta, stored); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// checkSignature fails when signature is not the resource's current signature
+func (c *FilesystemClient) checkSignature(module, resourceType string, loc storedResource, signature string) error {
+	if signature == "" {
+		return fmt.Errorf("a signature is required to modify %s/%s/%s", module, resourceType, loc.name)
+	}
+	current, err := c.read(module, resourceType, loc)
+	if err != nil {
+		return err
+	}
+	if current.Signature != signature {
+		return fmt.Errorf("signature mismatch: %s/%s/%s was modified by another user", module, resourceType, loc.name)
+	}
+	return nil
+}
+
+// writeAndRead writes the resource and reads it back into dest
+func (c *FilesystemClient) writeAndRead(module, resourceType string, loc storedResource, res ResourceResponse[json.RawMessage], dest any) error {
+	if err := c.write(module, resourceType, loc, res); err != nil {
+		return err
+	}
+	stored, err := c.read(module, resourceType, loc)
+	if err != nil {
+		return err
+	}
+	return roundTrip(stored, dest)
+}
+
+// write stores the resource's config.json and resource.json, keeping any
+// metadata in an existing resource.json that the API does not expose
+func (c *FilesystemClient) write(module, resourceType string, loc storedResource, res ResourceResponse[json.RawMessage]) error {
+	config := res.Config
+	if len(config) == 0 || string(config) == "null" {
+		config = json.RawMessage(`{}`)
+	}
+	config, err := StoredConfig(module, resourceType, config)
+	if err != nil {
+		return fmt.Errorf("error converting %s/%s %q: %w", module, resourceType, loc.name, err)
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, config, "", "  "); err != nil {
+		return err
+	}
+
+	meta, err := readJSONObject(filepath.Join(loc.dir, "resource.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		meta = map[string]any{
+			"scope":       "A",
+			"version":     1,
+			"restricted":  false,
+			"overridable": true,
+			"files":       []string{"config.json"},
+		}
+	} else if err != nil {
+		return err
+	}
+
+	if res.Description != "" {
+		meta["description"] = res.Description
+	} else {
+		delete(meta, "description")
+	}
+
+	attributes, _ := meta["attributes"].(map[string]any)
+	if attributes == nil {
+		attributes = make(map[string]any)
+	}
+	if res.Enabled != nil {
+		attributes["enabled"] = *res.Enabled
+	}
+	attributes["lastModification"] = map[string]any{
+		"actor":     filesystemActor,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+	delete(attributes, "lastModificationSignature")
+	meta["attributes"] = attributes
+
+	signature, err := sign(module, resourceType, loc.name, meta, indented.Bytes())
+	if err != nil {
+		return err
+	}
+	attributes["lastModificationSignature"] = signature
+
+	if err := os.MkdirAll(loc.dir, 0o755); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(loc.dir, "config.json"), indented.Bytes()); err != nil {
+		return err
+	}
+	return writeJSONFile(filepath.Join(loc.dir, "resource.json"), meta)
+}
+
+// sign returns the signature of a resource's metadata and config. The gateway
+// computes its own signatures when it saves a resource; these only need to
+// change whenever the resource changes, so that concurrent changes are detected.
+func sign(module, resourceType, name string, meta map[string]any, config []byte) (string, error) {
+	metaJSON, err := json.Marshal(meta)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s/%s/%s/", module, resourceType, name)
+	_, _ = h.Write(metaJSON)
+	_, _ = h.Write(config)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (c *FilesystemClient) GetProject(ctx context.Context, name string) (*Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.readProject(name)
+}
+
+func (c *FilesystemClient) CreateProject(ctx context.Context, p Project) (*Project, error) {
+	if err := validProjectName(p.Name); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.readProject(p.Name); err == nil {
+		return nil, fmt.Errorf("project already exists: %s", p.Name)
+	}
+	if p.Parent != "" {
+		if _, err := c.readProject(p.Parent); err != nil {
+			return nil, fmt.Errorf("parent project does not exist: %s", p.Parent)
+		}
+	}
+
+	if err := c.writeProject(p); err != nil {
+		return nil, err
+	}
+	return c.readProject(p.Name)
+}
+
+func (c *FilesystemClient) UpdateProject(ctx context.Context, p Project) (*Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.readProject(p.Name); err != nil {
+		return nil, err
+	}
+	if err := c.writeProject(p); err != nil {
+		return nil, err
+	}
+	return c.readProject(p.Name)
+}
+
+func (c *FilesystemClient) DeleteProject(ctx context.Context, name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.readProject(name); err != nil {
+		return err
+	}
+	return os.RemoveAll(c.projectDir(name))
+}
+
+func (c *FilesystemClient) ListProjects(ctx context.Context) ([]Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.listProjects()
+}
+
+func (c *FilesystemClient) RenameProject(ctx context.Context, name, newName string) (*Project, error) {
+	if err := validProjectName(newName); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.readProject(name); err != nil {
+		return nil, err
+	}
+	if _, err := c.readProject(newName); err == nil {
+		return nil, fmt.Errorf("project already exists: %s", newName)
+	}
+	if err := os.Rename(c.projectDir(name), c.projectDir(newName)); err != nil {
+		return nil, err
+	}
+
+	// Child projects follow their renamed parent
+	projects, err := c.listProjects()
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range projects {
+		if child.Parent == name {
+			child.Parent = newName
+			if err := c.writeProject(child); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return c.readProject(newName)
+}
+
+func (c *FilesystemClient) projectDir(name string) string {
+	return filepath.Join(c.DataDir, "projects", name)
+}
+
+func (c *FilesystemClient) readProject(name string) (*Project, error) {
+	if err := validProjectName(name); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.projectDir(name), "project.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("project not found: %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var p Project
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("error parsing project.json of project %s: %w", name, err)
+	}
+	p.Name = name
+	return &p, nil
+}
+
+func (c *FilesystemClient) listProjects() ([]Project, error) {
+	entries, err := os.ReadDir(filepath.Join(c.DataDir, "projects"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []Project
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(c.projectDir(e.Name()), "project.json")); err != nil {
+			continue
+		}
+		p, err := c.readProject(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, *p)
+	}
+	return projects, nil
+}
+
+// projectKeys are the project.json fields managed through Project
+var projectKeys = []string{"title", "description", "enabled", "parent", "inheritable", "defaultDb", "tagProvider", "userSource", "identityProvider"}
+
+// writeProject stores the project's project.json, keeping any fields of an
+// existing one that Project does not cover
+func (c *FilesystemClient) writeProject(p Project) error {
+	path := filepath.Join(c.projectDir(p.Name), "project.json")
+	existing, err := readJSONObject(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		existing = make(map[string]any)
+	} else if err != nil {
+		return err
+	}
+
+	var fields map[string]any
+	if err := roundTrip(p, &fields); err != nil {
+		return err
+	}
+	for _, k := range projectKeys {
+		delete(existing, k)
+	}
+	for k, v := range fields {
+		if k != "name" {
+			existing[k] = v
+		}
+	}
+	// Booleans are omitted from Project's JSON when false
+	existing["enabled"] = p.Enabled
+	existing["inheritable"] = p.Inheritable
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return writeJSONFile(path, existing)
+}
+
+// builtinCollections are the collections every gateway has. Any other
+// collection is a deployment mode.
+var builtinCollections = map[string]bool{
+	"external":        true,
+	DefaultCollection: true,
+	"local":           true,
+}
+
+func (c *FilesystemClient) GetDeploymentMode(ctx context.Context, name string) (*DeploymentMode, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.readDeploymentMode(name)
+}
+
+func (c *FilesystemClient) CreateDeploymentMode(ctx context.Context, m DeploymentMode) (*DeploymentMode, error) {
+	if err := validCollectionName(m.Name); err != nil {
+		return nil, err
+	}
+	if builtinCollections[m.Name] {
+		return nil, fmt.Errorf("%s is a built-in collection and cannot be used as a deployment mode", m.Name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.readDeploymentMode(m.Name); err == nil {
+		return nil, fmt.Errorf("deployment mode already exists: %s", m.Name)
+	}
+	if err := c.writeDeploymentMode(m); err != nil {
+		return nil, err
+	}
+	return c.readDeploymentMode(m.Name)
+}
+
+func (c *FilesystemClient) UpdateDeploymentMode(ctx context.Context, m DeploymentMode) (*DeploymentMode, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.readDeploymentMode(m.Name); err != nil {
+		return nil, err
+	}
+	if err := c.writeDeploymentMode(m); err != nil {
+		return nil, err
+	}
+	return c.readDeploymentMode(m.Name)
+}
+
+// DeleteDeploymentMode removes the mode's collection together with every
+// resource it overrides
+func (c *FilesystemClient) DeleteDeploymentMode(ctx context.Context, name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.readDeploymentMode(name); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(c.resourcesDir(), name))
+}
+
+func (c *FilesystemClient) ListDeploymentModes(ctx context.Context) ([]DeploymentMode, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := os.ReadDir(c.resourcesDir())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var modes []DeploymentMode
+	for _, e := range entries {
+		if !e.IsDir() || builtinCollections[e.Name()] {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(c.resourcesDir(), e.Name(), "config-mode.json")); err != nil {
+			continue
+		}
+		m, err := c.readDeploymentMode(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		modes = append(modes, *m)
+	}
+	return modes, nil
+}
+
+// usersFile is the data file of an internal user source that holds its users
+// and roles. Users refer to their roles by UUID, and passwords are stored as
+// [SALT] followed by the hex SHA-256 of the password and the salt.
+const usersFile = "users.json"
+
+// storedUsers is the content of a user source's users.json. Users and roles are
+// kept as JSON objects, so that the keys the API does not expose (password
+// history, schedule adjustments, ...) survive updates.
+type storedUsers struct {
+	Users []map[string]any `json:"users"`
+	Roles []map[string]any `json:"roles"`
+}
+
+func (c *FilesystemClient) GetUserSourceUser(ctx context.Context, userSource, username string) (*UserSourceUser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, users, err := c.readUsers(ctx, userSource)
+	if err != nil {
+		return nil, err
+	}
+	stored := users.user(username)
+	if stored == nil {
+		return nil, userNotFound(userSource, "user", username)
+	}
+	return users.apiUser(stored), nil
+}
+
+func (c *FilesystemClient) CreateUserSourceUser(ctx context.Context, userSource string, u UserSourceUser) (*UserSourceUser, error) {
+	err := c.updateUsers(ctx, userSource, func(users *storedUsers, now string) error {
+		if users.user(u.Username) != nil {
+			return fmt.Errorf("user %q already exists in user source %s", u.Username, userSource)
+		}
+		stored := map[string]any{
+			"uuid":                newUUID(),
+			"createdDate":         now,
+			"passwordHistory":     []any{},
+			"extraProps":          []any{},
+			"scheduleAdjustments": []any{},
+		}
+		if err := users.setUser(stored, u, now); err != nil {
+			return err
+		}
+		users.Users = append(users.Users, stored)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.GetUserSourceUser(ctx, userSource, u.Username)
+}
+
+// UpdateUserSourceUser replaces the user's details. The password is kept when
+// u has none.
+func (c *FilesystemClient) UpdateUserSourceUser(ctx context.Context, userSource string, u UserSourceUser) (*UserSourceUser, error) {
+	err := c.updateUsers(ctx, userSource, func(users *storedUsers, now string) error {
+		stored := users.user(u.Username)
+		if stored == nil {
+			return userNotFound(userSource, "user", u.Username)
+		}
+		return users.setUser(stored, u, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.GetUserSourceUser(ctx, userSource, u.Username)
+}
+
+func (c *FilesystemClient) DeleteUserSourceUser(ctx context.Context, userSource, username string) error {
+	return c.updateUsers(ctx, userSource, func(users *storedUsers, now string) error {
+		n := len(users.Users)
+		users.Users = slices.DeleteFunc(users.Users, func(u map[string]any) bool { return u["username"] == username })
+		if len(users.Users) == n {
+			return userNotFound(userSource, "user", username)
+		}
+		return nil
+	})
+}
+
+func (c *FilesystemClient) GetUserSourceRole(ctx context.Context, userSource, name string) (*UserSourceRole, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, users, err := c.readUsers(ctx, userSource)
+	if err != nil {
+		return nil, err
+	}
+	if users.role("rolename", name) == nil {
+		return nil, userNotFound(userSource, "role", name)
+	}
+	return &UserSourceRole{Name: name}, nil
+}
+
+func (c *FilesystemClient) CreateUserSourceRole(ctx context.Context, userSource string, role UserSourceRole) (*UserSourceRole, error) {
+	err := c.updateUsers(ctx, userSource, func(users *storedUsers, now string) error {
+		if users.role("rolename", role.Name) != nil {
+			return fmt.Errorf("role %q already exists in user source %s", role.Name, userSource)
+		}
+		users.Roles = append(users.Roles, map[string]any{
+			"uuid":             newUUID(),
+			"rolename":         role.Name,
+			"createdDate":      now,
+			"lastModifiedDate": now,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.GetUserSourceRole(ctx, userSource, role.Name)
+}
+
+// DeleteUserSourceRole removes the role and takes it away from every user that has it
+func (c *FilesystemClient) DeleteUserSourceRole(ctx context.Context, userSource, name string) error {
+	return c.updateUsers(ctx, userSource, func(users *storedUsers, now string) error {
+		role := users.role("rolename", name)
+		if role == nil {
+			return userNotFound(userSource, "role", name)
+		}
+		users.Roles = slices.DeleteFunc(users.Roles, func(r map[string]any) bool { return r["uuid"] == role["uuid"] })
+		for _, u := range users.Users {
+			roles, _ := u["roles"].([]any)
+			if i := slices.Index(roles, role["uuid"]); i >= 0 {
+				u["roles"] = slices.Delete(roles, i, i+1)
+				u["lastModifiedDate"] = now
+			}
+		}
+		return nil
+	})
+}
+
+// readUsers reads the users and roles of the named user source. A user source
+// without a users.json has neither.
+func (c *FilesystemClient) readUsers(ctx context.Context, userSource string) (storedResource, *storedUsers, error) {
+	loc, ok, err := c.locate(ctx, "ignition", "user-source", userSource)
+	if err != nil {
+		return storedResource{}, nil, err
+	}
+	if !ok {
+		return storedResource{}, nil, notFound("ignition", "user-source", userSource)
+	}
+
+	users := &storedUsers{}
+	data, err := os.ReadFile(filepath.Join(loc.dir, usersFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return loc, users, nil
+	} else if err != nil {
+		return storedResource{}, nil, err
+	}
+	if err := json.Unmarshal(data, users); err != nil {
+		return storedResource{}, nil, fmt.Errorf("error parsing %s of user source %s: %w", usersFile, userSource, err)
+	}
+	return loc, users, nil
+}
+
+// updateUsers applies modify to the users and roles of the named user source
+// and writes them back. The user source is signed again, as its files change.
+func (c *FilesystemClient) updateUsers(ctx context.Context, userSource string, modify func(users *storedUsers, now string) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loc, users, err := c.readUsers(ctx, userSource)
+	if err != nil {
+		return err
+	}
+	if err := modify(users, storedUserDate(time.Now())); err != nil {
+		return err
+	}
+	if users.Users == nil {
+		users.Users = []map[string]any{}
+	}
+	if users.Roles == nil {
+		users.Roles = []map[string]any{}
+	}
+	if err := writeJSONFile(filepath.Join(loc.dir, usersFile), users); err != nil {
+		return err
+	}
+
+	meta, err := readJSONObject(filepath.Join(loc.dir, "resource.json"))
+	if err != nil {
+		return err
+	}
+	files, _ := meta["files"].([]any)
+	if !slices.Contains(files, any(usersFile)) {
+		meta["files"] = append(files, usersFile)
+		if err := writeJSONFile(filepath.Join(loc.dir, "resource.json"), meta); err != nil {
+			return err
+		}
+	}
+
+	res, err := c.read("ignition", "user-source", loc)
+	if err != nil {
+		return err
+	}
+	return c.write("ignition", "user-source", loc, *res)
+}
+
+func (s *storedUsers) user(username string) map[string]any {
+	for _, u := range s.Users {
+		if u["username"] == username {
+			return u
+		}
+	}
+	return nil
+}
+
+// role finds the role whose key (uuid or rolename) has the given value
+func (s *storedUsers) role(key, value string) map[string]any {
+	for _, r := range s.Roles {
+		if r[key] == value {
+			return r
+		}
+	}
+	return nil
+}
+
+// apiUser converts a stored user to the API shape, replacing role UUIDs with names
+func (s *storedUsers) apiUser(stored map[string]any) *UserSourceUser {
+	str := func(key string) string {
+		v, _ := stored[key].(string)
+		return v
+	}
+
+	u := &UserSourceUser{
+		Username:    str("username"),
+		FirstName:   str("firstName"),
+		LastName:    str("lastName"),
+		Schedule:    str("schedule"),
+		Language:    str("language"),
+		Badge:       str("badge"),
+		Roles:       []string{},
+		ContactInfo: []UserSourceContactInfo{},
+	}
+	roles, _ := stored["roles"].([]any)
+	for _, id := range roles {
+		id, _ := id.(string)
+		if role := s.role("uuid", id); role != nil {
+			name, _ := role["rolename"].(string)
+			u.Roles = append(u.Roles, name)
+		}
+	}
+	contacts, _ := stored["contactInfos"].([]any)
+	for _, contact := range contacts {
+		contact, _ := contact.(map[string]any)
+		contactType, _ := contact["contactType"].(string)
+		value, _ := contact["value"].(string)
+		u.ContactInfo = append(u.ContactInfo, UserSourceContactInfo{Type: contactType, Value: value})
+	}
+	return u
+}
+
+// setUser copies the API user u into stored, replacing role names with UUIDs
+// and hashing the password, if any
+func (s *storedUsers) setUser(stored map[string]any, u UserSourceUser, now string) error {
+	roles := make([]any, 0, len(u.Roles))
+	for _, name := range u.Roles {
+		role := s.role("rolename", name)
+		if role == nil {
+			return fmt.Errorf("role %q does not exist in the user source", name)
+		}
+		roles = append(roles, role["uuid"])
+	}
+	contacts := make([]any, 0, len(u.ContactInfo))
+	for _, contact := range u.ContactInfo {
+		contacts = append(contacts, map[string]any{"contactType": contact.Type, "value": contact.Value})
+	}
+
+	stored["username"] = u.Username
+	stored["schedule"] = cmp.Or(u.Schedule, "Always")
+	for key, value := range map[string]string{
+		"firstName": u.FirstName,
+		"lastName":  u.LastName,
+		"language":  u.Language,
+		"badge":     u.Badge,
+	} {
+		if value != "" {
+			stored[key] = value
+		} else {
+			delete(stored, key)
+		}
+	}
+	stored["roles"] = roles
+	stored["contactInfos"] = contacts
+	stored["lastModifiedDate"] = now
+	if u.Password != "" {
+		password, err := hashPassword(u.Password)
+		if err != nil {
+			return err
+		}
+		stored["password"] = password
+		stored["passwordDate"] = now
+	}
+	return nil
+}
+
+// hashPassword hashes a password the way internal user sources store it
+func hashPassword(password string) (string, error) {
+	salt := make([]byte, 4)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	s := strings.ToUpper(hex.EncodeToString(salt))
+	sum := sha256.Sum256([]byte(password + s))
+	return "[" + s + "]" + hex.EncodeToString(sum[:]), nil
+}
+
+// storedUserDate formats t as users.json does (e.g., 20260113.081456043+0000)
+func storedUserDate(t time.Time) string {
+	t = t.UTC()
+	return fmt.Sprintf("%s%03d%s", t.Format("20060102.150405"), t.Nanosecond()/int(time.Millisecond), t.Format("-0700"))
+}
+
+// newUUID returns a random (version 4) UUID
+func newUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func userNotFound(userSource, kind, name string) error {
+	return fmt.Errorf("%s not found: %s in user source %s", kind, name, userSource)
+}
+
+// errTrustStore is returned by the trust store methods, as the gateway manages
+// its certificate stores itself rather than through its config files
+var errTrustStore = errors.New("the OPC UA client trust store cannot be managed in filesystem mode")
+
+func (c *FilesystemClient) TrustOpcUaCertificate(ctx context.Context, cert OpcUaTrustedCertificate) error {
+	return errTrustStore
+}
+
+func (c *FilesystemClient) DeleteOpcUaTrustedCertificate(ctx context.Context, thumbprint string) error {
+	return errTrustStore
+}
+
+// GetResourceTypeSchema returns nil, as resource type schemas are only
+// published by a running gateway
+func (c *FilesystemClient) GetResourceTypeSchema(ctx context.Context, module, resourceType string) (*ConfigSchema, error) {
+	return nil, nil
+}
+
+func (c *FilesystemClient) readDeploymentMode(name string) (*DeploymentMode, error) {
+	if err := validCollectionName(name); err != nil {
+		return nil, err
+	}
+	if builtinCollections[name] {
+		return nil, fmt.Errorf("deployment mode not found: %s", name)
+	}
+
+	meta, err := readJSONObject(filepath.Join(c.resourcesDir(), name, "config-mode.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("deployment mode not found: %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m := &DeploymentMode{Name: name, Enabled: true}
+	m.Title, _ = meta["title"].(string)
+	m.Description, _ = meta["description"].(string)
+	if enabled, ok := meta["enabled"].(bool); ok {
+		m.Enabled = enabled
+	}
+	return m, nil
+}
+
+// writeDeploymentMode stores the mode's config-mode.json, keeping any fields of
+// an existing one that DeploymentMode does not cover. New modes inherit from
+// the core collection.
+func (c *FilesystemClient) writeDeploymentMode(m DeploymentMode) error {
+	path := filepath.Join(c.resourcesDir(), m.Name, "config-mode.json")
+	meta, err := readJSONObject(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		meta = map[string]any{
+			"inheritable": true,
+			"parent":      DefaultCollection,
+		}
+	} else if err != nil {
+		return err
+	}
+
+	for key, value := range map[string]string{"title": m.Title, "description": m.Description} {
+		if value != "" {
+			meta[key] = value
+		} else {
+			delete(meta, key)
+		}
+	}
+	meta["enabled"] = m.Enabled
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return writeJSONFile(path, meta)
+}
+
+// defaultRedundancy is the redundancy configuration of a gateway without a redundancy.xml
+var defaultRedundancy = RedundancyConfig{
+	Role:               "Independent",
+	ActiveHistoryLevel: "Full",
+	JoinWaitTime:       30000,
+	RecoveryMode:       "Automatic",
+}
+
+func (c *FilesystemClient) GetRedundancyConfig(ctx context.Context) (*RedundancyConfig, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := os.ReadFile(filepath.Join(c.DataDir, "redundancy.xml"))
+	if errors.Is(err, fs.ErrNotExist) {
+		config := defaultRedundancy
+		return &config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return DecodeRedundancyProperties(data)
+}
+
+func (c *FilesystemClient) UpdateRedundancyConfig(ctx context.Context, config RedundancyConfig) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := filepath.Join(c.DataDir, "redundancy.xml")
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	out, err := EncodeRedundancyProperties(data, config)
+	if err != nil {
+		return fmt.Errorf("error updating redundancy.xml: %w", err)
+	}
+	return writeFileAtomic(path, out)
+}
+
+func notFound(module, resourceType, name string) error {
+	return fmt.Errorf("resource not found: %s/%s/%s", module, resourceType, name)
+}
+
+// validName rejects resource names that would escape their type directory
+func validName(name string) error {
+	if name == "" {
+		return fmt.Errorf("a resource name is required")
+	}
+	for _, segment := range strings.Split(name, "/") {
+		if segment == "" || segment == "." || segment == ".." || strings.ContainsRune(segment, '\\') {
+			return fmt.Errorf("invalid resource name %q", name)
+		}
+	}
+	return nil
+}
+
+// validCollectionName rejects collection names that would escape the resources directory
+func validCollectionName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid collection name %q", name)
+	}
+	return nil
+}
+
+// validProjectName rejects project names that would escape the projects directory
+func validProjectName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid project name %q", name)
+	}
+	return nil
+}
+
+// pruneEmptyDirs removes dir and its parents while they are empty, stopping at root
+func pruneEmptyDirs(dir, root string) error {
+	for dir != root && strings.HasPrefix(dir, root) {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			dir = filepath.Dir(dir)
+			continue
+		}
+		if err != nil || len(entries) > 0 {
+			return err
+		}
+		if err := os.Remove(dir); err != nil {
+			return err
+		}
+		dir = filepath.Dir(dir)
+	}
+	return nil
+}
+
+func readJSONObject(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	if obj == nil {
+		obj = make(map[string]any)
+	}
+	return obj, nil
+}
+
+func writeJSONFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic replaces the file at path, so that a gateway scanning the
+// data directory never sees a partially written file
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Chmod(0o644); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+var _ IgnitionClient = &FilesystemClient{}
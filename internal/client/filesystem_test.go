@@ -0,0 +1,555 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newTestFilesystemClient(t *testing.T) *FilesystemClient {
+	t.Helper()
+	c, err := NewFilesystemClient(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return c
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFilesystemClient_ResourceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFilesystemClient(t)
+
+	created, err := c.CreateTagProvider(ctx, ResourceResponse[TagProviderConfig]{
+		Name:        "plant",
+		Enabled:     boolPtr(true),
+		Description: "Plant tags",
+		Config:      TagProviderConfig{Profile: TagProviderProfile{Type: "STANDARD"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateTagProvider failed: %v", err)
+	}
+	if created.Signature == "" || created.Config.Profile.Type != "STANDARD" || created.Description != "Plant tags" {
+		t.Errorf("Unexpected created resource: %+v", created)
+	}
+
+	dir := filepath.Join(c.DataDir, "config", "resources", "core", "ignition", "tag-provider", "plant")
+	for _, name := range []string{"resource.json", "config.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected %s to be written: %v", name, err)
+		}
+	}
+
+	// A stale signature is rejected
+	created.Description = "Updated"
+	stale := *created
+	stale.Signature = "stale"
+	if _, err := c.UpdateTagProvider(ctx, stale); err == nil || !strings.Contains(err.Error(), "signature mismatch") {
+		t.Errorf("Expected a signature mismatch, got %v", err)
+	}
+
+	updated, err := c.UpdateTagProvider(ctx, *created)
+	if err != nil {
+		t.Fatalf("UpdateTagProvider failed: %v", err)
+	}
+	if updated.Description != "Updated" || updated.Signature == created.Signature {
+		t.Errorf("Expected an updated description and a new signature, got %+v", updated)
+	}
+
+	if err := c.RenameResourceWithModule(ctx, "ignition", "tag-provider", "plant", "site/plant", updated.Signature); err != nil {
+		t.Fatalf("RenameResourceWithModule failed: %v", err)
+	}
+	items, err := c.ListResourcesWithModule(ctx, "ignition", "tag-provider")
+	if err != nil {
+		t.Fatalf("ListResourcesWithModule failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Name != "site/plant" || items[0].Collection != "core" {
+		t.Fatalf("Unexpected items after rename: %+v", items)
+	}
+
+	if err := c.DeleteTagProvider(ctx, "site/plant", items[0].Signature); err != nil {
+		t.Fatalf("DeleteTagProvider failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(c.DataDir, "config", "resources", "core", "ignition", "tag-provider", "site")); !os.IsNotExist(err) {
+		t.Errorf("Expected empty directories to be removed, got %v", err)
+	}
+	if _, err := c.GetTagProvider(ctx, "site/plant"); err == nil {
+		t.Error("Expected deleted resource to be gone")
+	}
+}
+
+func TestFilesystemClient_Collections(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFilesystemClient(t)
+	resources := filepath.Join(c.DataDir, "config", "resources")
+
+	writeTestFile(t, filepath.Join(resources, "core", "config-mode.json"), `{"parent": "external"}`)
+	writeTestFile(t, filepath.Join(resources, "local", "config-mode.json"), `{"parent": "core"}`)
+	writeTestFile(t, filepath.Join(resources, "core", "ignition", "database-connection", "mes", "resource.json"), `{"scope": "A"}`)
+	writeTestFile(t, filepath.Join(resources, "core", "ignition", "database-connection", "mes", "config.json"), `{"connectURL": "jdbc:core"}`)
+	writeTestFile(t, filepath.Join(resources, "local", "ignition", "database-connection", "mes", "resource.json"), `{"scope": "A"}`)
+	writeTestFile(t, filepath.Join(resources, "local", "ignition", "database-connection", "mes", "config.json"), `{"connectURL": "jdbc:local"}`)
+
+	db, err := c.GetDatabaseConnection(ctx, "mes")
+	if err != nil {
+		t.Fatalf("GetDatabaseConnection failed: %v", err)
+	}
+	if db.Config.ConnectURL != "jdbc:local" {
+		t.Errorf("Expected the local override, got %q", db.Config.ConnectURL)
+	}
+	if db.Signature == "" {
+		t.Error("Expected a signature for a resource written without one")
+	}
+
+	// Updates are written back to the collection the resource was read from
+	db.Config.ConnectURL = "jdbc:updated"
+	if _, err := c.UpdateDatabaseConnection(ctx, *db); err != nil {
+		t.Fatalf("UpdateDatabaseConnection failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(resources, "local", "ignition", "database-connection", "mes", "config.json"))
+	if err != nil || !strings.Contains(string(data), "jdbc:updated") {
+		t.Errorf("Expected the local config to be updated, got %s (%v)", data, err)
+	}
+}
+
+func TestFilesystemClient_DeploymentModes(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFilesystemClient(t)
+	resources := filepath.Join(c.DataDir, "config", "resources")
+
+	if _, err := c.CreateDeploymentMode(ctx, DeploymentMode{Name: "local"}); err == nil {
+		t.Error("Expected a built-in collection to be rejected as a deployment mode")
+	}
+	mode, err := c.CreateDeploymentMode(ctx, DeploymentMode{Name: "dev", Title: "Development", Enabled: true})
+	if err != nil {
+		t.Fatalf("CreateDeploymentMode failed: %v", err)
+	}
+	if mode.Title != "Development" || !mode.Enabled {
+		t.Errorf("Unexpected deployment mode: %+v", mode)
+	}
+	meta, err := readJSONObject(filepath.Join(resources, "dev", "config-mode.json"))
+	if err != nil || meta["parent"] != "core" {
+		t.Errorf("Expected the mode to inherit from core, got %v (%v)", meta, err)
+	}
+
+	item := ResourceResponse[DatabaseConfig]{Name: "mes", Config: DatabaseConfig{ConnectURL: "jdbc:core"}}
+	if _, err := c.CreateDatabaseConnection(ctx, item); err != nil {
+		t.Fatalf("CreateDatabaseConnection in core failed: %v", err)
+	}
+	dev := WithCollection(ctx, "dev")
+	item.Config.ConnectURL = "jdbc:dev"
+	override, err := c.CreateDatabaseConnection(dev, item)
+	if err != nil {
+		t.Fatalf("CreateDatabaseConnection in dev failed: %v", err)
+	}
+	if override.Collection != "dev" {
+		t.Errorf("Expected the override to be stored in dev, got %q", override.Collection)
+	}
+
+	// A collection on the context limits lookups to that collection
+	core, err := c.GetDatabaseConnection(WithCollection(ctx, "core"), "mes")
+	if err != nil || core.Config.ConnectURL != "jdbc:core" {
+		t.Errorf("Expected the core resource, got %+v (%v)", core, err)
+	}
+	if _, err := c.GetDatabaseConnection(WithCollection(ctx, "local"), "mes"); err == nil {
+		t.Error("Expected no resource in the local collection")
+	}
+	if _, err := c.GetDatabaseConnection(WithCollection(ctx, "../core"), "mes"); err == nil {
+		t.Error("Expected an escaping collection name to be rejected")
+	}
+
+	if err := c.DeleteDatabaseConnection(dev, "mes", override.Signature); err != nil {
+		t.Fatalf("DeleteDatabaseConnection in dev failed: %v", err)
+	}
+	if _, err := c.GetDatabaseConnection(ctx, "mes"); err != nil {
+		t.Errorf("Expected the core resource to remain: %v", err)
+	}
+
+	modes, err := c.ListDeploymentModes(ctx)
+	if err != nil || len(modes) != 1 || modes[0].Name != "dev" {
+		t.Fatalf("Unexpected deployment modes: %+v (%v)", modes, err)
+	}
+	if err := c.DeleteDeploymentMode(ctx, "dev"); err != nil {
+		t.Fatalf("DeleteDeploymentMode failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(resources, "dev")); !os.IsNotExist(err) {
+		t.Errorf("Expected the mode's collection to be removed, got %v", err)
+	}
+}
+
+func TestFilesystemClient_StoredShapes(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFilesystemClient(t)
+
+	_, err := c.CreateIdentityProvider(ctx, ResourceResponse[IdentityProviderConfig]{
+		Name: "corp",
+		Config: IdentityProviderConfig{
+			Type:   "internal",
+			Config: map[string]any{"userSource": "corp", "userGrants": map[string]any{}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateIdentityProvider failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.DataDir, "config", "resources", "core", "ignition", "identity-provider", "corp", "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored struct {
+		Profile  map[string]any `json:"profile"`
+		Settings map[string]any `json:"settings"`
+	}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Profile["type"] != "internal" || stored.Profile["userGrants"] == nil || stored.Settings["userSource"] != "corp" {
+		t.Errorf("Unexpected stored identity provider: %s", data)
+	}
+
+	idp, err := c.GetIdentityProvider(ctx, "corp")
+	if err != nil {
+		t.Fatalf("GetIdentityProvider failed: %v", err)
+	}
+	if idp.Config.Type != "internal" {
+		t.Errorf("Expected type internal, got %q", idp.Config.Type)
+	}
+
+	// Devices report their driver type as their resource type
+	device, err := c.CreateDevice(ctx, ResourceResponse[DeviceConfig]{
+		Name:   "press",
+		Config: DeviceConfig{"profile": map[string]any{"type": "ModbusTcp"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateDevice failed: %v", err)
+	}
+	if device.Type != "ModbusTcp" {
+		t.Errorf("Expected type ModbusTcp, got %q", device.Type)
+	}
+}
+
+func TestFilesystemClient_Singletons(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFilesystemClient(t)
+
+	// Singletons are created on first update in an empty data directory
+	settings, err := c.UpdateGanGeneralSettings(ctx, ResourceResponse[GanGeneralSettingsConfig]{
+		Name:   "gateway-network-settings",
+		Config: GanGeneralSettingsConfig{RequireSSL: true},
+	})
+	if err != nil {
+		t.Fatalf("UpdateGanGeneralSettings failed: %v", err)
+	}
+	if !settings.Config.RequireSSL {
+		t.Errorf("Unexpected settings: %+v", settings)
+	}
+	if _, err := os.Stat(filepath.Join(c.DataDir, "config", "resources", "core", "ignition", "gateway-network-settings", "config.json")); err != nil {
+		t.Errorf("Expected the singleton in its type directory: %v", err)
+	}
+
+	got, err := c.GetGanGeneralSettings(ctx)
+	if err != nil {
+		t.Fatalf("GetGanGeneralSettings failed: %v", err)
+	}
+	if got.Name != "gateway-network-settings" || got.Signature != settings.Signature {
+		t.Errorf("Unexpected singleton: %+v", got)
+	}
+}
+
+func TestFilesystemClient_Projects(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFilesystemClient(t)
+
+	if _, err := c.CreateProject(ctx, Project{Name: "child", Parent: "missing"}); err == nil {
+		t.Error("Expected an error for a missing parent")
+	}
+	if _, err := c.CreateProject(ctx, Project{Name: "global", Inheritable: true}); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if _, err := c.CreateProject(ctx, Project{Name: "hmi", Parent: "global", Enabled: true, DefaultDB: "mes"}); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+
+	if _, err := c.RenameProject(ctx, "global", "base"); err != nil {
+		t.Fatalf("RenameProject failed: %v", err)
+	}
+	hmi, err := c.GetProject(ctx, "hmi")
+	if err != nil {
+		t.Fatalf("GetProject failed: %v", err)
+	}
+	if hmi.Parent != "base" || hmi.DefaultDB != "mes" || !hmi.Enabled {
+		t.Errorf("Unexpected project after parent rename: %+v", hmi)
+	}
+
+	projects, err := c.ListProjects(ctx)
+	if err != nil {
+		t.Fatalf("ListProjects failed: %v", err)
+	}
+	if len(projects) != 2 || projects[0].Name != "base" || projects[1].Name != "hmi" {
+		t.Errorf("Unexpected projects: %+v", projects)
+	}
+
+	if err := c.DeleteProject(ctx, "hmi"); err != nil {
+		t.Fatalf("DeleteProject failed: %v", err)
+	}
+	if _, err := c.GetProject(ctx, "hmi"); err == nil {
+		t.Error("Expected deleted project to be gone")
+	}
+}
+
+func TestFilesystemClient_Redundancy(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFilesystemClient(t)
+
+	cfg, err := c.GetRedundancyConfig(ctx)
+	if err != nil {
+		t.Fatalf("GetRedundancyConfig failed: %v", err)
+	}
+	if cfg.Role != "Independent" {
+		t.Errorf("Expected the default role, got %q", cfg.Role)
+	}
+
+	writeTestFile(t, filepath.Join(c.DataDir, "redundancy.xml"), `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE properties SYSTEM "http://java.sun.com/dtd/properties.dtd">
+<properties>
+<entry key="redundancy.noderole">Independent</entry>
+<entry key="redundancy.sync.timeoutSecs">60</entry>
+</properties>`)
+
+	cfg.Role = "Master"
+	cfg.JoinWaitTime = 15000
+	if err := c.UpdateRedundancyConfig(ctx, *cfg); err != nil {
+		t.Fatalf("UpdateRedundancyConfig failed: %v", err)
+	}
+
+	got, err := c.GetRedundancyConfig(ctx)
+	if err != nil {
+		t.Fatalf("GetRedundancyConfig failed: %v", err)
+	}
+	if got.Role != "Master" || got.JoinWaitTime != 15000 || got.RecoveryMode != "Automatic" {
+		t.Errorf("Unexpected redundancy config: %+v", got)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(c.DataDir, "redundancy.xml"))
+	if !strings.Contains(string(data), `<entry key="redundancy.sync.timeoutSecs">60</entry>`) {
+		t.Errorf("Expected unrelated properties to be kept:\n%s", data)
+	}
+}
+
+func TestFilesystemClient_RejectsEscapingNames(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFilesystemClient(t)
+
+	for _, name := range []string{"../escape", "a/../../b", "/abs", ""} {
+		_, err := c.CreateTagProvider(ctx, ResourceResponse[TagProviderConfig]{Name: name})
+		if err == nil {
+			t.Errorf("Expected name %q to be rejected", name)
+		}
+	}
+	if _, err := c.CreateProject(ctx, Project{Name: "../escape"}); err == nil {
+		t.Error("Expected project name to be rejected")
+	}
+}
+
+func TestFilesystemClient_EncryptSecret(t *testing.T) {
+	c := newTestFilesystemClient(t)
+	if _, err := c.EncryptSecret(context.Background(), "secret"); err == nil {
+		t.Error("Expected EncryptSecret to fail without a gateway")
+	}
+}
+
+func TestFilesystemClient_UploadResourceFile(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFilesystemClient(t)
+
+	created, err := c.CreateJDBCDriver(ctx, ResourceResponse[JDBCDriverConfig]{
+		Name:   "Snowflake",
+		Config: JDBCDriverConfig{Classname: "net.snowflake.client.jdbc.SnowflakeDriver", Type: "GENERIC"},
+	})
+	if err != nil {
+		t.Fatalf("CreateJDBCDriver failed: %v", err)
+	}
+
+	if err := c.UploadResourceFile(ctx, "ignition", "database-driver", "Snowflake", "snowflake.jar", "stale", []byte("jar")); err == nil {
+		t.Error("Expected a stale signature to be rejected")
+	}
+	if err := c.UploadResourceFile(ctx, "ignition", "database-driver", "Snowflake", "../escape.jar", created.Signature, []byte("jar")); err == nil {
+		t.Error("Expected an escaping file name to be rejected")
+	}
+	if err := c.UploadResourceFile(ctx, "ignition", "database-driver", "Snowflake", "snowflake.jar", created.Signature, []byte("jar")); err != nil {
+		t.Fatalf("UploadResourceFile failed: %v", err)
+	}
+
+	dir := filepath.Join(c.DataDir, "config", "resources", "core", "ignition", "database-driver", "Snowflake")
+	if data, err := os.ReadFile(filepath.Join(dir, "snowflake.jar")); err != nil || string(data) != "jar" {
+		t.Errorf("Expected the JAR to be written, got %q, %v", data, err)
+	}
+	meta, err := readJSONObject(filepath.Join(dir, "resource.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := meta["files"].([]any); len(files) != 2 || files[1] != "snowflake.jar" {
+		t.Errorf("Expected the JAR to be listed in resource.json, got %v", meta["files"])
+	}
+
+	// The upload changes the signature, like any other change
+	fresh, err := c.GetJDBCDriver(ctx, "Snowflake")
+	if err != nil {
+		t.Fatalf("GetJDBCDriver failed: %v", err)
+	}
+	if fresh.Signature == created.Signature {
+		t.Error("Expected a new signature after the upload")
+	}
+
+	if err := c.DeleteResourceFile(ctx, "ignition", "database-driver", "Snowflake", "snowflake.jar", created.Signature); err == nil {
+		t.Error("Expected a stale signature to be rejected")
+	}
+	if err := c.DeleteResourceFile(ctx, "ignition", "database-driver", "Snowflake", "snowflake.jar", fresh.Signature); err != nil {
+		t.Fatalf("DeleteResourceFile failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "snowflake.jar")); !os.IsNotExist(err) {
+		t.Errorf("Expected the JAR to be removed, got %v", err)
+	}
+	meta, err = readJSONObject(filepath.Join(dir, "resource.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := meta["files"].([]any); slices.Contains(files, any("snowflake.jar")) {
+		t.Errorf("Expected the JAR to be unlisted from resource.json, got %v", meta["files"])
+	}
+	if deleted, err := c.GetJDBCDriver(ctx, "Snowflake"); err != nil || deleted.Signature == fresh.Signature {
+		t.Errorf("Expected a new signature after the deletion, got %v", err)
+	}
+}
+
+func TestFilesystemClient_UsersAndRoles(t *testing.T) {
+	ctx := context.Background()
+	c := newTestFilesystemClient(t)
+
+	// The default user source of a fresh gateway, as stored in a backup
+	dir := filepath.Join(c.DataDir, "config", "resources", "core", "ignition", "user-source", "default")
+	writeTestFile(t, filepath.Join(dir, "resource.json"), `{"scope": "A", "version": 1, "files": ["config.json", "users.json"], "attributes": {}}`)
+	writeTestFile(t, filepath.Join(dir, "config.json"), `{"profile": {"type": "INTERNAL"}}`)
+	writeTestFile(t, filepath.Join(dir, "users.json"), `{
+  "users": [{
+    "uuid": "c6d87ac2-3be4-4b85-8ae4-f869dad1f66d",
+    "username": "admin",
+    "schedule": "Always",
+    "password": "[F7F7BB92]52fb003588f6b9b06cdc76d6f36398eae06051a360eee074960000822018f4b9",
+    "passwordHistory": [],
+    "roles": ["decffe8e-de86-4ceb-8ade-f233d44cd648"],
+    "extraProps": [],
+    "scheduleAdjustments": [],
+    "contactInfos": []
+  }],
+  "roles": [{"uuid": "decffe8e-de86-4ceb-8ade-f233d44cd648", "rolename": "Administrator"}]
+}`)
+
+	admin, err := c.GetUserSourceUser(ctx, "default", "admin")
+	if err != nil {
+		t.Fatalf("GetUserSourceUser failed: %v", err)
+	}
+	if len(admin.Roles) != 1 || admin.Roles[0] != "Administrator" || admin.Schedule != "Always" {
+		t.Errorf("Expected role UUIDs to be read as names, got %+v", admin)
+	}
+	if _, err := c.GetUserSourceRole(ctx, "default", "Administrator"); err != nil {
+		t.Errorf("GetUserSourceRole failed: %v", err)
+	}
+
+	if _, err := c.CreateUserSourceUser(ctx, "default", UserSourceUser{Username: "op", Roles: []string{"Operator"}}); err == nil {
+		t.Error("Expected a user with an unknown role to be rejected")
+	}
+	if _, err := c.CreateUserSourceRole(ctx, "default", UserSourceRole{Name: "Operator"}); err != nil {
+		t.Fatalf("CreateUserSourceRole failed: %v", err)
+	}
+	op, err := c.CreateUserSourceUser(ctx, "default", UserSourceUser{
+		Username:    "op",
+		FirstName:   "Ops",
+		Roles:       []string{"Operator"},
+		ContactInfo: []UserSourceContactInfo{{Type: "email", Value: "op@example.com"}},
+		Password:    "secret",
+	})
+	if err != nil {
+		t.Fatalf("CreateUserSourceUser failed: %v", err)
+	}
+	if op.FirstName != "Ops" || len(op.Roles) != 1 || op.Roles[0] != "Operator" || len(op.ContactInfo) != 1 || op.Password != "" {
+		t.Errorf("Unexpected created user: %+v", op)
+	}
+
+	var stored storedUsers
+	data, err := os.ReadFile(filepath.Join(dir, "users.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatal(err)
+	}
+	role := stored.role("rolename", "Operator")
+	user := stored.user("op")
+	if role == nil || user == nil {
+		t.Fatalf("Expected the role and user to be stored, got %s", data)
+	}
+	if roles, _ := user["roles"].([]any); len(roles) != 1 || roles[0] != role["uuid"] {
+		t.Errorf("Expected the user to refer to the role by UUID, got %v", user["roles"])
+	}
+	password, _ := user["password"].(string)
+	salt, hash, _ := strings.Cut(strings.TrimPrefix(password, "["), "]")
+	sum := sha256.Sum256([]byte("secret" + salt))
+	if hash != hex.EncodeToString(sum[:]) {
+		t.Errorf("Expected the password to be salted and hashed, got %q", password)
+	}
+
+	// Updates keep the password when none is given, and keys the API does not expose
+	op.FirstName = ""
+	if _, err := c.UpdateUserSourceUser(ctx, "default", *op); err != nil {
+		t.Fatalf("UpdateUserSourceUser failed: %v", err)
+	}
+	var updated storedUsers
+	if err := json.Unmarshal(mustReadFile(t, filepath.Join(dir, "users.json")), &updated); err != nil {
+		t.Fatal(err)
+	}
+	if user := updated.user("op"); user["password"] != password || user["firstName"] != nil || user["extraProps"] == nil {
+		t.Errorf("Unexpected updated user: %v", user)
+	}
+
+	// Deleting a role takes it away from its users
+	if err := c.DeleteUserSourceRole(ctx, "default", "Operator"); err != nil {
+		t.Fatalf("DeleteUserSourceRole failed: %v", err)
+	}
+	if op, err := c.GetUserSourceUser(ctx, "default", "op"); err != nil || len(op.Roles) != 0 {
+		t.Errorf("Expected the user to lose the deleted role, got %+v, %v", op, err)
+	}
+	if err := c.DeleteUserSourceUser(ctx, "default", "op"); err != nil {
+		t.Fatalf("DeleteUserSourceUser failed: %v", err)
+	}
+	if _, err := c.GetUserSourceUser(ctx, "default", "op"); err == nil {
+		t.Error("Expected the deleted user to be gone")
+	}
+	if _, err := c.GetUserSourceUser(ctx, "missing", "admin"); err == nil {
+		t.Error("Expected an unknown user source to be reported")
+	}
+}
+
+func mustReadFile(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
@@ -0,0 +1,9 @@
+package client
+
+// The typed models and methods of the resource types listed in
+// openapi/resources.json are generated from openapi/openapi.json. That file is
+// a hand-maintained fixture in the shape of the gateway's OpenAPI document, not
+// a capture of its /openapi endpoint: its config schemas are transcribed from
+// the configs a gateway stores. Describe new resource types there, list them in
+// resources.json, then run go generate.
+//go:generate go run ../codegen -spec openapi/openapi.json -config openapi/resources.json
@@ -3,6 +3,8 @@ package client
 import "context"
 
 type MockClient struct {
+	GeneratedMockFuncs
+
 	GetResourceFunc                    func(ctx context.Context, rt, n string, d any) error
 	CreateResourceFunc                 func(ctx context.Context, rt string, i, d any) error
 	UpdateResourceFunc                 func(ctx context.Context, rt string, i, d any) error
@@ -11,11 +13,32 @@ type MockClient struct {
 	CreateResourceWithModuleFunc       func(ctx context.Context, m, rt string, i, d any) error
 	UpdateResourceWithModuleFunc       func(ctx context.Context, m, rt string, i, d any) error
 	DeleteResourceWithModuleFunc       func(ctx context.Context, m, rt, n, s string) error
+	ListResourcesWithModuleFunc        func(ctx context.Context, m, rt string) ([]ResourceListItem, error)
+	RenameResourceWithModuleFunc       func(ctx context.Context, m, rt, n, nn, s string) error
+	UploadResourceFileFunc             func(ctx context.Context, m, rt, n, f, s string, d []byte) error
+	DeleteResourceFileFunc             func(ctx context.Context, m, rt, n, f, s string) error
 	EncryptSecretFunc                  func(ctx context.Context, p string) (*IgnitionSecret, error)
 	GetProjectFunc                     func(ctx context.Context, n string) (*Project, error)
 	CreateProjectFunc                  func(ctx context.Context, p Project) (*Project, error)
 	UpdateProjectFunc                  func(ctx context.Context, p Project) (*Project, error)
 	DeleteProjectFunc                  func(ctx context.Context, n string) error
+	ListProjectsFunc                   func(ctx context.Context) ([]Project, error)
+	RenameProjectFunc                  func(ctx context.Context, n, nn string) (*Project, error)
+	GetDeploymentModeFunc              func(ctx context.Context, n string) (*DeploymentMode, error)
+	CreateDeploymentModeFunc           func(ctx context.Context, d DeploymentMode) (*DeploymentMode, error)
+	UpdateDeploymentModeFunc           func(ctx context.Context, d DeploymentMode) (*DeploymentMode, error)
+	DeleteDeploymentModeFunc           func(ctx context.Context, n string) error
+	ListDeploymentModesFunc            func(ctx context.Context) ([]DeploymentMode, error)
+	GetUserSourceUserFunc              func(ctx context.Context, us, n string) (*UserSourceUser, error)
+	CreateUserSourceUserFunc           func(ctx context.Context, us string, u UserSourceUser) (*UserSourceUser, error)
+	UpdateUserSourceUserFunc           func(ctx context.Context, us string, u UserSourceUser) (*UserSourceUser, error)
+	DeleteUserSourceUserFunc           func(ctx context.Context, us, n string) error
+	GetUserSourceRoleFunc              func(ctx context.Context, us, n string) (*UserSourceRole, error)
+	CreateUserSourceRoleFunc           func(ctx context.Context, us string, r UserSourceRole) (*UserSourceRole, error)
+	DeleteUserSourceRoleFunc           func(ctx context.Context, us, n string) error
+	TrustOpcUaCertificateFunc          func(ctx context.Context, c OpcUaTrustedCertificate) error
+	DeleteOpcUaTrustedCertificateFunc  func(ctx context.Context, t string) error
+	GetResourceTypeSchemaFunc          func(ctx context.Context, m, t string) (*ConfigSchema, error)
 	GetDatabaseConnectionFunc          func(ctx context.Context, n string) (*ResourceResponse[DatabaseConfig], error)
 	CreateDatabaseConnectionFunc       func(ctx context.Context, i ResourceResponse[DatabaseConfig]) (*ResourceResponse[DatabaseConfig], error)
 	UpdateDatabaseConnectionFunc       func(ctx context.Context, i ResourceResponse[DatabaseConfig]) (*ResourceResponse[DatabaseConfig], error)
@@ -118,6 +141,30 @@ func (m *MockClient) DeleteResourceWithModule(ctx context.Context, mod, rt, n, s
 	}
 	return nil
 }
+func (m *MockClient) ListResourcesWithModule(ctx context.Context, mod, rt string) ([]ResourceListItem, error) {
+	if m.ListResourcesWithModuleFunc != nil {
+		return m.ListResourcesWithModuleFunc(ctx, mod, rt)
+	}
+	return nil, nil
+}
+func (m *MockClient) RenameResourceWithModule(ctx context.Context, mod, rt, n, nn, s string) error {
+	if m.RenameResourceWithModuleFunc != nil {
+		return m.RenameResourceWithModuleFunc(ctx, mod, rt, n, nn, s)
+	}
+	return nil
+}
+func (m *MockClient) UploadResourceFile(ctx context.Context, mod, rt, n, f, s string, d []byte) error {
+	if m.UploadResourceFileFunc != nil {
+		return m.UploadResourceFileFunc(ctx, mod, rt, n, f, s, d)
+	}
+	return nil
+}
+func (m *MockClient) DeleteResourceFile(ctx context.Context, mod, rt, n, f, s string) error {
+	if m.DeleteResourceFileFunc != nil {
+		return m.DeleteResourceFileFunc(ctx, mod, rt, n, f, s)
+	}
+	return nil
+}
 func (m *MockClient) EncryptSecret(ctx context.Context, p string) (*IgnitionSecret, error) {
 	if m.EncryptSecretFunc != nil {
 		return m.EncryptSecretFunc(ctx, p)
@@ -148,6 +195,108 @@ func (m *MockClient) DeleteProject(ctx context.Context, n string) error {
 	}
 	return nil
 }
+func (m *MockClient) ListProjects(ctx context.Context) ([]Project, error) {
+	if m.ListProjectsFunc != nil {
+		return m.ListProjectsFunc(ctx)
+	}
+	return nil, nil
+}
+func (m *MockClient) RenameProject(ctx context.Context, n, nn string) (*Project, error) {
+	if m.RenameProjectFunc != nil {
+		return m.RenameProjectFunc(ctx, n, nn)
+	}
+	return &Project{Name: nn}, nil
+}
+func (m *MockClient) GetDeploymentMode(ctx context.Context, n string) (*DeploymentMode, error) {
+	if m.GetDeploymentModeFunc != nil {
+		return m.GetDeploymentModeFunc(ctx, n)
+	}
+	return &DeploymentMode{Name: n}, nil
+}
+func (m *MockClient) CreateDeploymentMode(ctx context.Context, d DeploymentMode) (*DeploymentMode, error) {
+	if m.CreateDeploymentModeFunc != nil {
+		return m.CreateDeploymentModeFunc(ctx, d)
+	}
+	return &d, nil
+}
+func (m *MockClient) UpdateDeploymentMode(ctx context.Context, d DeploymentMode) (*DeploymentMode, error) {
+	if m.UpdateDeploymentModeFunc != nil {
+		return m.UpdateDeploymentModeFunc(ctx, d)
+	}
+	return &d, nil
+}
+func (m *MockClient) DeleteDeploymentMode(ctx context.Context, n string) error {
+	if m.DeleteDeploymentModeFunc != nil {
+		return m.DeleteDeploymentModeFunc(ctx, n)
+	}
+	return nil
+}
+func (m *MockClient) ListDeploymentModes(ctx context.Context) ([]DeploymentMode, error) {
+	if m.ListDeploymentModesFunc != nil {
+		return m.ListDeploymentModesFunc(ctx)
+	}
+	return nil, nil
+}
+func (m *MockClient) GetUserSourceUser(ctx context.Context, us, n string) (*UserSourceUser, error) {
+	if m.GetUserSourceUserFunc != nil {
+		return m.GetUserSourceUserFunc(ctx, us, n)
+	}
+	return &UserSourceUser{Username: n}, nil
+}
+func (m *MockClient) CreateUserSourceUser(ctx context.Context, us string, u UserSourceUser) (*UserSourceUser, error) {
+	if m.CreateUserSourceUserFunc != nil {
+		return m.CreateUserSourceUserFunc(ctx, us, u)
+	}
+	return &u, nil
+}
+func (m *MockClient) UpdateUserSourceUser(ctx context.Context, us string, u UserSourceUser) (*UserSourceUser, error) {
+	if m.UpdateUserSourceUserFunc != nil {
+		return m.UpdateUserSourceUserFunc(ctx, us, u)
+	}
+	return &u, nil
+}
+func (m *MockClient) DeleteUserSourceUser(ctx context.Context, us, n string) error {
+	if m.DeleteUserSourceUserFunc != nil {
+		return m.DeleteUserSourceUserFunc(ctx, us, n)
+	}
+	return nil
+}
+func (m *MockClient) GetUserSourceRole(ctx context.Context, us, n string) (*UserSourceRole, error) {
+	if m.GetUserSourceRoleFunc != nil {
+		return m.GetUserSourceRoleFunc(ctx, us, n)
+	}
+	return &UserSourceRole{Name: n}, nil
+}
+func (m *MockClient) CreateUserSourceRole(ctx context.Context, us string, r UserSourceRole) (*UserSourceRole, error) {
+	if m.CreateUserSourceRoleFunc != nil {
+		return m.CreateUserSourceRoleFunc(ctx, us, r)
+	}
+	return &r, nil
+}
+func (m *MockClient) DeleteUserSourceRole(ctx context.Context, us, n string) error {
+	if m.DeleteUserSourceRoleFunc != nil {
+		return m.DeleteUserSourceRoleFunc(ctx, us, n)
+	}
+	return nil
+}
+func (m *MockClient) TrustOpcUaCertificate(ctx context.Context, c OpcUaTrustedCertificate) error {
+	if m.TrustOpcUaCertificateFunc != nil {
+		return m.TrustOpcUaCertificateFunc(ctx, c)
+	}
+	return nil
+}
+func (m *MockClient) DeleteOpcUaTrustedCertificate(ctx context.Context, t string) error {
+	if m.DeleteOpcUaTrustedCertificateFunc != nil {
+		return m.DeleteOpcUaTrustedCertificateFunc(ctx, t)
+	}
+	return nil
+}
+func (m *MockClient) GetResourceTypeSchema(ctx context.Context, mod, t string) (*ConfigSchema, error) {
+	if m.GetResourceTypeSchemaFunc != nil {
+		return m.GetResourceTypeSchemaFunc(ctx, mod, t)
+	}
+	return nil, nil
+}
 func (m *MockClient) GetDatabaseConnection(ctx context.Context, n string) (*ResourceResponse[DatabaseConfig], error) {
 	if m.GetDatabaseConnectionFunc != nil {
 		return m.GetDatabaseConnectionFunc(ctx, n)
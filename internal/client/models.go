@@ -35,12 +35,39 @@ type ResourceResponse[T any] struct {
 	Module      string `json:"module,omitempty"`
 	Type        string `json:"type,omitempty"`
 	Name        string `json:"name"`
+	Collection  string `json:"collection,omitempty"`
 	Enabled     *bool  `json:"enabled,omitempty"`
 	Description string `json:"description,omitempty"`
 	Signature   string `json:"signature,omitempty"`
 	Config      T      `json:"config"`
 }
 
+// ResourceRename is a request to rename a resource in place
+type ResourceRename struct {
+	Name      string `json:"name"`
+	NewName   string `json:"newName"`
+	Signature string `json:"signature"`
+}
+
+// ResourceListItem is the summary of a resource returned by the list endpoint
+type ResourceListItem struct {
+	Name        string `json:"name"`
+	Collection  string `json:"collection,omitempty"`
+	Enabled     *bool  `json:"enabled,omitempty"`
+	Description string `json:"description,omitempty"`
+	Signature   string `json:"signature,omitempty"`
+}
+
+type ResourceListResponse[T any] struct {
+	Items    []T `json:"items"`
+	Metadata struct {
+		Total    int `json:"total"`
+		Matching int `json:"matching"`
+		Limit    int `json:"limit"`
+		Offset   int `json:"offset"`
+	} `json:"metadata"`
+}
+
 type ResourceChangesResponse struct {
 	Success bool `json:"success"`
 	Changes []struct {
@@ -51,33 +78,151 @@ type ResourceChangesResponse struct {
 	} `json:"changes"`
 }
 
+// DatabaseConfig is the config of a database connection. The advanced
+// settings are pointers, so that those left out keep the gateway's defaults.
 type DatabaseConfig struct {
 	Driver     string `json:"driver"`
 	Translator string `json:"translator,omitempty"`
 	ConnectURL string `json:"connectURL"`
 	Username   string `json:"username,omitempty"`
 	Password   any    `json:"password,omitempty"`
+
+	// ConnectionProps are extra JDBC connection properties, as key=value pairs
+	// separated by semicolons
+	ConnectionProps         *string `json:"connectionProps,omitempty"`
+	DefaultTransactionLevel *string `json:"defaultTransactionLevel,omitempty"`
+	PoolInitSize            *int64  `json:"poolInitSize,omitempty"`
+	PoolMaxActive           *int64  `json:"poolMaxActive,omitempty"`
+	PoolMaxIdle             *int64  `json:"poolMaxIdle,omitempty"`
+	PoolMinIdle             *int64  `json:"poolMinIdle,omitempty"`
+	PoolMaxWait             *int64  `json:"poolMaxWait,omitempty"`
+	ValidationQuery         *string `json:"validationQuery,omitempty"`
+	ValidationSleepTime     *int64  `json:"validationSleepTime,omitempty"`
+	TestOnBorrow            *bool   `json:"testOnBorrow,omitempty"`
+	TestOnReturn            *bool   `json:"testOnReturn,omitempty"`
+	TestWhileIdle           *bool   `json:"testWhileIdle,omitempty"`
+	EvictionRate            *int64  `json:"evictionRate,omitempty"`
+	EvictionTests           *int64  `json:"evictionTests,omitempty"`
+	EvictionTime            *int64  `json:"evictionTime,omitempty"`
+	SlowQueryLogThreshold   *int64  `json:"slowQueryLogThreshold,omitempty"`
+	FailoverProfile         string  `json:"failoverProfile"`
+	FailoverMode            *string `json:"failoverMode,omitempty"`
 }
 
 type TagProviderProfile struct {
 	Type string `json:"type"`
 }
 
+// TagProviderConfig is the config of a tag provider. The keys of Settings
+// depend on the profile type: TagProviderStandardSettings and
+// TagProviderRemoteSettings hold those of STANDARD and REMOTE providers.
 type TagProviderConfig struct {
 	Profile     TagProviderProfile `json:"profile"`
 	Description string             `json:"description,omitempty"`
 	Settings    map[string]any     `json:"settings"`
 }
 
+// TagProviderPermission grants the users of a role, connecting from a zone,
+// access to the tags of a provider
+type TagProviderPermission struct {
+	Role        string `json:"role,omitempty"`
+	Zone        string `json:"zone,omitempty"`
+	AccessLevel string `json:"accessLevel"`
+}
+
+// TagProviderStandardSettings are the settings of STANDARD tag providers
+type TagProviderStandardSettings struct {
+	HistoryEnabled          bool                    `json:"historyEnabled"`
+	DefaultHistoryProvider  string                  `json:"defaultHistoryProvider,omitempty"`
+	AllowBackfill           bool                    `json:"allowBackfill"`
+	EnableTagReferenceStore bool                    `json:"enableTagReferenceStore"`
+	AlarmSync               bool                    `json:"alarmSync"`
+	Permissions             []TagProviderPermission `json:"permissions,omitempty"`
+}
+
+// TagProviderRemoteSettings are the settings of REMOTE tag providers, which
+// expose the tags of a provider on another gateway of the Gateway Network
+type TagProviderRemoteSettings struct {
+	GatewayName     string `json:"gatewayName"`
+	ProviderName    string `json:"providerName"`
+	HistoryMode     string `json:"historyMode"`
+	HistoryProvider string `json:"historyProvider,omitempty"`
+	AlarmMode       string `json:"alarmMode"`
+	AlarmsEnabled   bool   `json:"alarmsEnabled"`
+}
+
 type UserSourceProfile struct {
 	Type               string `json:"type"`
-	FailoverProfile    string `json:"failoverProfile,omitempty"`
+	FailoverProfile    string `json:"failoverProfile"`
 	FailoverMode       string `json:"failoverMode,omitempty"`
 	ScheduleRestricted bool   `json:"scheduleRestricted,omitempty"`
 }
 
+// UserSourceConfig is the config of a user source. The keys of Settings depend
+// on the profile type: UserSourceADSettings holds those of the Active Directory
+// types, UserSourceDatabaseSettings those of DATASOURCE user sources, and
+// AD_DB_HYBRID user sources have both.
 type UserSourceConfig struct {
-	Profile UserSourceProfile `json:"profile"`
+	Profile  UserSourceProfile `json:"profile"`
+	Settings map[string]any    `json:"settings,omitempty"`
+}
+
+// UserSourceADSettings are the settings of user sources that authenticate
+// against Active Directory
+type UserSourceADSettings struct {
+	Domain          string `json:"domain"`
+	PrimaryHost     string `json:"primaryHost"`
+	PrimaryPort     int    `json:"primaryPort"`
+	SecondaryHost   string `json:"secondaryHost,omitempty"`
+	SecondaryPort   int    `json:"secondaryPort,omitempty"`
+	SSLEnabled      bool   `json:"sslEnabled"`
+	GatewayUsername string `json:"gatewayUsername,omitempty"`
+	GatewayPassword any    `json:"gatewayPassword,omitempty"`
+	UserSearchBase  string `json:"userSearchBase,omitempty"`
+	RoleSearchBase  string `json:"roleSearchBase,omitempty"`
+	RoleFilter      string `json:"roleFilter,omitempty"`
+	GroupFilter     string `json:"groupFilter,omitempty"`
+}
+
+// UserSourceDatabaseSettings are the settings of user sources that keep users
+// and roles in a database. With the AUTOMATIC schema mode, the gateway creates
+// and manages the tables; with MANUAL, it runs the given queries instead.
+type UserSourceDatabaseSettings struct {
+	Datasource          string `json:"datasource"`
+	SchemaMode          string `json:"schemaMode"`
+	TablePrefix         string `json:"tablePrefix,omitempty"`
+	AuthenticationQuery string `json:"authenticationQuery,omitempty"`
+	UserListQuery       string `json:"userListQuery,omitempty"`
+	RoleListQuery       string `json:"roleListQuery,omitempty"`
+	UserRolesQuery      string `json:"userRolesQuery,omitempty"`
+}
+
+// UserSourceRole is a role of an internal user source. In the API, roles are
+// identified by name, and users list the names of their roles; in the data
+// directory's users.json, roles are stored under rolename and users refer to
+// them by UUID, which FilesystemClient translates.
+type UserSourceRole struct {
+	Name string `json:"name"`
+}
+
+// UserSourceContactInfo is a way of contacting a user, such as an email address
+type UserSourceContactInfo struct {
+	Type  string `json:"contactType"`
+	Value string `json:"value"`
+}
+
+// UserSourceUser is a user of an internal user source. The password is only
+// sent; the gateway never returns it.
+type UserSourceUser struct {
+	Username    string                  `json:"username"`
+	FirstName   string                  `json:"firstName,omitempty"`
+	LastName    string                  `json:"lastName,omitempty"`
+	Schedule    string                  `json:"schedule,omitempty"`
+	Language    string                  `json:"language,omitempty"`
+	Badge       string                  `json:"badge,omitempty"`
+	Roles       []string                `json:"roles"`
+	ContactInfo []UserSourceContactInfo `json:"contactInfo"`
+	Password    string                  `json:"password,omitempty"`
 }
 
 type Project struct {
@@ -93,6 +238,15 @@ type Project struct {
 	IdentityProvider string `json:"identityProvider,omitempty"`
 }
 
+// DeploymentMode is a named config collection whose resources override those
+// of the core collection while the gateway runs in that mode
+type DeploymentMode struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
 type AuditProfileSettings struct {
 	DatabaseName          string `json:"databaseName,omitempty"`
 	PruneEnabled          bool   `json:"pruneEnabled,omitempty"`
@@ -129,8 +283,51 @@ type OpcUaConnectionEndpoint struct {
 	SecurityMode   string `json:"securityMode"`
 }
 
+// OpcUaConnectionSettings holds the settings the provider manages. The stored
+// config has more (failover, security, and further advanced settings), which
+// updates keep as they are.
 type OpcUaConnectionSettings struct {
-	Endpoint OpcUaConnectionEndpoint `json:"endpoint"`
+	Endpoint       OpcUaConnectionEndpoint        `json:"endpoint"`
+	Authentication *OpcUaConnectionAuthentication `json:"authentication,omitempty"`
+	KeepAlive      OpcUaConnectionKeepAlive       `json:"keepAlive"`
+	Advanced       OpcUaConnectionAdvanced        `json:"advanced"`
+}
+
+// OpcUaConnectionAuthentication is the user identity sessions are activated
+// with: a username and password, or an X.509 certificate and its private key.
+// Without one, sessions are anonymous.
+type OpcUaConnectionAuthentication struct {
+	Username    string          `json:"username,omitempty"`
+	Password    *IgnitionSecret `json:"password,omitempty"`
+	Certificate string          `json:"certificate,omitempty"`
+	PrivateKey  *IgnitionSecret `json:"privateKey,omitempty"`
+}
+
+// OpcUaConnectionKeepAlive holds how the connection checks that the server is
+// still there. The fields are pointers, so that those left out keep the
+// gateway's defaults.
+type OpcUaConnectionKeepAlive struct {
+	Interval        *int64 `json:"interval,omitempty"`
+	Timeout         *int64 `json:"timeout,omitempty"`
+	FailuresAllowed *int64 `json:"failuresAllowed,omitempty"`
+}
+
+// OpcUaConnectionAdvanced holds the session settings of a connection. They are
+// pointers, so that those left out keep the gateway's defaults.
+type OpcUaConnectionAdvanced struct {
+	ConnectTimeout             *int64  `json:"connectTimeout,omitempty"`
+	RequestTimeout             *int64  `json:"requestTimeout,omitempty"`
+	SessionTimeout             *int64  `json:"sessionTimeout,omitempty"`
+	MaxPerOperation            *int64  `json:"maxPerOperation,omitempty"`
+	MaxNotificationsPerPublish *int64  `json:"maxNotificationsPerPublish,omitempty"`
+	BrowseOrigin               *string `json:"browseOrigin,omitempty"`
+}
+
+// OpcUaTrustedCertificate is a certificate in the trust store of the gateway's
+// OPC UA client, identified by its SHA-1 thumbprint as OPC UA does
+type OpcUaTrustedCertificate struct {
+	Thumbprint  string `json:"thumbprint"`
+	Certificate string `json:"certificate"`
 }
 
 type OpcUaConnectionProfile struct {
@@ -241,6 +438,13 @@ type IdentityProviderOidcConfig struct {
 	JsonWebKeysEndpointEnabled bool            `json:"jsonWebKeysEndpointEnabled"`
 	UserInfoEndpoint           string          `json:"userInfoEndpoint,omitempty"`
 	EndSessionEndpoint         string          `json:"endSessionEndpoint,omitempty"`
+	Scopes                     []string        `json:"scopes,omitempty"`
+	TokenEndpointAuthMethod    string          `json:"tokenEndpointAuthMethod,omitempty"`
+	PkceEnabled                bool            `json:"pkceEnabled"`
+	Prompt                     string          `json:"prompt,omitempty"`
+	MaxAge                     int64           `json:"maxAge,omitempty"`
+	IdTokenSigningAlgorithms   []string        `json:"idTokenSigningAlgorithms,omitempty"`
+	PreferUserInfoClaims       bool            `json:"preferUserInfoClaims"`
 }
 
 type IdentityProviderSamlConfig struct {
@@ -253,18 +457,47 @@ type IdentityProviderSamlConfig struct {
 		Uri     string `json:"uri"`
 		Binding string `json:"binding"`
 	} `json:"ssoServiceConfig"`
-	ForceAuthnEnabled              bool     `json:"forceAuthnEnabled"`
-	ResponseSignaturesRequired     bool     `json:"responseSignaturesRequired"`
-	AssertionSignaturesRequired    bool     `json:"assertionSignaturesRequired"`
-	IdpMetadataUrl                 string   `json:"idpMetadataUrl,omitempty"`
-	IdpMetadataUrlEnabled          bool     `json:"idpMetadataUrlEnabled"`
-	SignatureVerifyingCertificates []string `json:"signatureVerifyingCertificates"`
-	SignatureVerifyingKeys         []any    `json:"signatureVerifyingKeys"`
+	ForceAuthnEnabled              bool                     `json:"forceAuthnEnabled"`
+	ResponseSignaturesRequired     bool                     `json:"responseSignaturesRequired"`
+	AssertionSignaturesRequired    bool                     `json:"assertionSignaturesRequired"`
+	IdpMetadataUrl                 string                   `json:"idpMetadataUrl,omitempty"`
+	IdpMetadataUrlEnabled          bool                     `json:"idpMetadataUrlEnabled"`
+	SignatureVerifyingCertificates []string                 `json:"signatureVerifyingCertificates"`
+	SignatureVerifyingKeys         []any                    `json:"signatureVerifyingKeys"`
+	SpSigningKeyPair               *IdentityProviderKeyPair `json:"spSigningKeyPair,omitempty"`
+	SpEncryptionKeyPair            *IdentityProviderKeyPair `json:"spEncryptionKeyPair,omitempty"`
+}
+
+// IdentityProviderKeyPair is a PEM certificate and its private key, which the
+// gateway only accepts encrypted
+type IdentityProviderKeyPair struct {
+	Certificate string          `json:"certificate"`
+	PrivateKey  *IgnitionSecret `json:"privateKey,omitempty"`
+}
+
+// IdentityProviderRoleMapping assigns a role to users for whom the expression,
+// evaluated against the IdP's response, is true
+type IdentityProviderRoleMapping struct {
+	Role       string `json:"role"`
+	Expression string `json:"expression"`
 }
 
+// IdentityProviderSecurityLevelRule grants the security levels of roles and
+// security zones to users for whom the expression is true
+type IdentityProviderSecurityLevelRule struct {
+	Expression string   `json:"expression"`
+	Roles      []string `json:"roles"`
+	Zones      []string `json:"zones"`
+}
+
+// IdentityProviderConfig holds the type-specific config of an identity provider,
+// and the rules shared by every type that map users into Ignition
 type IdentityProviderConfig struct {
-	Type   string `json:"type"`
-	Config any    `json:"config"`
+	Type                 string                              `json:"type"`
+	Config               any                                 `json:"config"`
+	UserAttributeMapping map[string]string                   `json:"userAttributeMapping,omitempty"`
+	RoleMapping          []IdentityProviderRoleMapping       `json:"roleMapping,omitempty"`
+	SecurityLevelRules   []IdentityProviderSecurityLevelRule `json:"securityLevelRules,omitempty"`
 }
 
 type GanOutgoingConfig struct {
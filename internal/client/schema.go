@@ -0,0 +1,462 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// openAPIPath is where the gateway serves the OpenAPI document of its REST API
+const openAPIPath = "/openapi"
+
+// ConfigSchema is the subset of JSON Schema, as used by OpenAPI documents, that
+// describes the config of a gateway resource type.
+type ConfigSchema struct {
+	Ref                  string                   `json:"$ref,omitempty"`
+	Type                 schemaTypes              `json:"type,omitempty"`
+	Nullable             bool                     `json:"nullable,omitempty"`
+	Properties           map[string]*ConfigSchema `json:"properties,omitempty"`
+	Required             []string                 `json:"required,omitempty"`
+	AdditionalProperties *additionalProperties    `json:"additionalProperties,omitempty"`
+	Items                *ConfigSchema            `json:"items,omitempty"`
+	Enum                 []any                    `json:"enum,omitempty"`
+	Minimum              *float64                 `json:"minimum,omitempty"`
+	Maximum              *float64                 `json:"maximum,omitempty"`
+	MinLength            *int                     `json:"minLength,omitempty"`
+	MaxLength            *int                     `json:"maxLength,omitempty"`
+	AllOf                []*ConfigSchema          `json:"allOf,omitempty"`
+	AnyOf                []*ConfigSchema          `json:"anyOf,omitempty"`
+	OneOf                []*ConfigSchema          `json:"oneOf,omitempty"`
+}
+
+// schemaTypes holds the type keyword, which is a single type name in OpenAPI 3.0
+// and may be a list of them in OpenAPI 3.1
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = schemaTypes{name}
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*t = names
+	return nil
+}
+
+// additionalProperties holds the additionalProperties keyword, which is either
+// a boolean or the schema of the additional values
+type additionalProperties struct {
+	Allowed bool
+	Schema  *ConfigSchema
+}
+
+func (a *additionalProperties) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.Allowed); err == nil {
+		return nil
+	}
+	a.Allowed = true
+	return json.Unmarshal(data, &a.Schema)
+}
+
+// SchemaViolation describes a value of a config that does not conform to its schema
+type SchemaViolation struct {
+	// Path holds the keys, and array indexes, leading to the value
+	Path []string
+	// Message describes the violation
+	Message string
+	// Missing is set when the violation is a required key that is absent
+	Missing bool
+}
+
+func (v SchemaViolation) Error() string {
+	if len(v.Path) == 0 {
+		return v.Message
+	}
+	return strings.Join(v.Path, ".") + ": " + v.Message
+}
+
+// Validate checks config, which may be any value that marshals to JSON, against
+// the schema. Objects that declare properties are treated as closed unless they
+// allow additional properties, since the gateway rejects unknown keys. Null
+// values are accepted anywhere, as they are omitted by the gateway.
+func (s *ConfigSchema) Validate(config any) ([]SchemaViolation, error) {
+	b, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, err
+	}
+	return s.validate(value, nil), nil
+}
+
+func (s *ConfigSchema) validate(value any, path []string) []SchemaViolation {
+	if s == nil || value == nil {
+		return nil
+	}
+	if len(s.AllOf) > 0 {
+		return s.merged().validate(value, path)
+	}
+
+	violation := func(format string, args ...any) []SchemaViolation {
+		return []SchemaViolation{{Path: path, Message: fmt.Sprintf(format, args...)}}
+	}
+
+	if alternatives := append(append([]*ConfigSchema{}, s.AnyOf...), s.OneOf...); len(alternatives) > 0 {
+		// Report the violations of the closest alternative
+		var closest []SchemaViolation
+		for i, alt := range alternatives {
+			violations := alt.validate(value, path)
+			if len(violations) == 0 {
+				closest = nil
+				break
+			}
+			if i == 0 || len(violations) < len(closest) {
+				closest = violations
+			}
+		}
+		if len(closest) > 0 {
+			return closest
+		}
+	}
+
+	if len(s.Type) > 0 && !s.Type.allows(value) {
+		return violation("expected %s, got %s", strings.Join(s.Type, " or "), jsonTypeOf(value))
+	}
+	if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+		allowed := make([]string, len(s.Enum))
+		for i, e := range s.Enum {
+			b, _ := json.Marshal(e)
+			allowed[i] = string(b)
+		}
+		return violation("must be one of %s", strings.Join(allowed, ", "))
+	}
+
+	var violations []SchemaViolation
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				violations = append(violations, SchemaViolation{
+					Path:    appendPath(path, name),
+					Message: "required key is missing",
+					Missing: true,
+				})
+			}
+		}
+
+		closed := len(s.Properties) > 0 && (s.AdditionalProperties == nil || !s.AdditionalProperties.Allowed)
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if property, ok := s.Properties[k]; ok {
+				violations = append(violations, property.validate(v[k], appendPath(path, k))...)
+			} else if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+				violations = append(violations, s.AdditionalProperties.Schema.validate(v[k], appendPath(path, k))...)
+			} else if closed {
+				message := "unknown key"
+				if suggestion := s.closestProperty(k); suggestion != "" {
+					message += fmt.Sprintf(", did you mean %q?", suggestion)
+				}
+				violations = append(violations, SchemaViolation{Path: appendPath(path, k), Message: message})
+			}
+		}
+	case []any:
+		for i, item := range v {
+			violations = append(violations, s.Items.validate(item, appendPath(path, strconv.Itoa(i)))...)
+		}
+	case string:
+		if n := utf8.RuneCountInString(v); s.MinLength != nil && n < *s.MinLength {
+			return violation("must be at least %d characters long", *s.MinLength)
+		} else if s.MaxLength != nil && n > *s.MaxLength {
+			return violation("must be at most %d characters long", *s.MaxLength)
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			return violation("must be at least %v", *s.Minimum)
+		} else if s.Maximum != nil && v > *s.Maximum {
+			return violation("must be at most %v", *s.Maximum)
+		}
+	}
+	return violations
+}
+
+// merged returns the schema with the schemas of its allOf keyword combined into it
+func (s *ConfigSchema) merged() *ConfigSchema {
+	m := *s
+	m.AllOf = nil
+	m.Properties = make(map[string]*ConfigSchema, len(s.Properties))
+	for k, p := range s.Properties {
+		m.Properties[k] = p
+	}
+	m.Required = append([]string{}, s.Required...)
+	m.AnyOf = append([]*ConfigSchema{}, s.AnyOf...)
+	m.OneOf = append([]*ConfigSchema{}, s.OneOf...)
+
+	for _, part := range s.AllOf {
+		if part == nil {
+			continue
+		}
+		if len(part.AllOf) > 0 {
+			part = part.merged()
+		}
+		for k, p := range part.Properties {
+			m.Properties[k] = p
+		}
+		m.Required = append(m.Required, part.Required...)
+		if len(m.Type) == 0 {
+			m.Type = part.Type
+		}
+		if part.AdditionalProperties != nil && part.AdditionalProperties.Allowed {
+			m.AdditionalProperties = part.AdditionalProperties
+		}
+		m.AnyOf = append(m.AnyOf, part.AnyOf...)
+		m.OneOf = append(m.OneOf, part.OneOf...)
+	}
+	return &m
+}
+
+// closestProperty returns the declared property that the given key is most
+// likely a misspelling of, or an empty string when there is none
+func (s *ConfigSchema) closestProperty(key string) string {
+	best, bestDistance := "", 3
+	for name := range s.Properties {
+		if strings.EqualFold(name, key) {
+			return name
+		}
+		if d := editDistance(strings.ToLower(name), strings.ToLower(key)); d < bestDistance || (d == bestDistance && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+func (t schemaTypes) allows(value any) bool {
+	actual := jsonTypeOf(value)
+	for _, name := range t {
+		if name == actual || (name == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonTypeOf returns the JSON Schema type name of a decoded JSON value
+func jsonTypeOf(value any) string {
+	switch v := value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return "null"
+	}
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendPath(path []string, key string) []string {
+	return append(append(make([]string, 0, len(path)+1), path...), key)
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// openAPIDocument is the part of the gateway's OpenAPI document that describes
+// the resource endpoints
+type openAPIDocument struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*ConfigSchema `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPIOperation struct {
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema *ConfigSchema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+}
+
+// ResourceTypeSchema returns the schema of the config of the given resource
+// type, taken from the request body of its create (or update) endpoint, or nil
+// when the document does not describe the type.
+func (d *openAPIDocument) ResourceTypeSchema(module, resourceType string) *ConfigSchema {
+	item, ok := d.Paths["/data/api/v1/resources/"+module+"/"+resourceType]
+	if !ok {
+		return nil
+	}
+
+	for _, method := range []string{"post", "put"} {
+		var op openAPIOperation
+		if raw, ok := item[method]; !ok || json.Unmarshal(raw, &op) != nil || op.RequestBody == nil {
+			continue
+		}
+		media, ok := op.RequestBody.Content["application/json"]
+		if !ok || media.Schema == nil {
+			continue
+		}
+
+		// The body is a list of resources, each holding its config
+		s := d.deref(media.Schema)
+		if s != nil && len(s.Type) > 0 && s.Type[0] == "array" {
+			s = d.deref(s.Items)
+		}
+		if s == nil || s.Properties["config"] == nil {
+			continue
+		}
+		return d.link(s.Properties["config"], make(map[*ConfigSchema]*ConfigSchema))
+	}
+	return nil
+}
+
+// deref follows the component references of s, returning nil for unknown references
+func (d *openAPIDocument) deref(s *ConfigSchema) *ConfigSchema {
+	for i := 0; s != nil && s.Ref != "" && i < 32; i++ {
+		s = d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	return s
+}
+
+// link replaces every reference within s with the schema it refers to
+func (d *openAPIDocument) link(s *ConfigSchema, linked map[*ConfigSchema]*ConfigSchema) *ConfigSchema {
+	s = d.deref(s)
+	if s == nil {
+		return nil
+	}
+	if l, ok := linked[s]; ok {
+		return l
+	}
+
+	l := *s
+	linked[s] = &l
+	if len(s.Properties) > 0 {
+		l.Properties = make(map[string]*ConfigSchema, len(s.Properties))
+		for k, p := range s.Properties {
+			l.Properties[k] = d.link(p, linked)
+		}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		l.AdditionalProperties = &additionalProperties{Allowed: true, Schema: d.link(s.AdditionalProperties.Schema, linked)}
+	}
+	l.Items = d.link(s.Items, linked)
+	l.AllOf = d.linkAll(s.AllOf, linked)
+	l.AnyOf = d.linkAll(s.AnyOf, linked)
+	l.OneOf = d.linkAll(s.OneOf, linked)
+	return &l
+}
+
+func (d *openAPIDocument) linkAll(schemas []*ConfigSchema, linked map[*ConfigSchema]*ConfigSchema) []*ConfigSchema {
+	if schemas == nil {
+		return nil
+	}
+	out := make([]*ConfigSchema, len(schemas))
+	for i, s := range schemas {
+		out[i] = d.link(s, linked)
+	}
+	return out
+}
+
+// GetResourceTypeSchema returns the schema of the config of the given resource
+// type, as published in the gateway's OpenAPI document, or nil when the
+// gateway does not describe it. The document is fetched once and cached.
+func (c *Client) GetResourceTypeSchema(ctx context.Context, module, resourceType string) (*ConfigSchema, error) {
+	doc, err := c.openAPIDocument(ctx)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+	return doc.ResourceTypeSchema(module, resourceType), nil
+}
+
+// openAPIDocument returns the cached OpenAPI document, fetching it on first
+// use. It returns nil when the gateway does not serve one.
+func (c *Client) openAPIDocument(ctx context.Context) (*openAPIDocument, error) {
+	c.openAPIMu.Lock()
+	defer c.openAPIMu.Unlock()
+
+	if c.openAPI != nil {
+		return c.openAPI, nil
+	}
+
+	// Use a custom request to tell a gateway without the document from a failure
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, c.HostURL+openAPIPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Ignition-API-Token", c.Token)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	doc := &openAPIDocument{}
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+	case res.StatusCode < 200 || res.StatusCode >= 300:
+		body, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("fetching the OpenAPI document failed with status: %d, body: %s", res.StatusCode, body)
+	default:
+		if err := json.NewDecoder(res.Body).Decode(doc); err != nil {
+			return nil, fmt.Errorf("failed to decode OpenAPI document: %w", err)
+		}
+	}
+	// Gateways that do not serve the document are cached as describing nothing
+	c.openAPI = doc
+	return doc, nil
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const testOpenAPIDocument = `{
+	"openapi": "3.0.1",
+	"paths": {
+		"/data/api/v1/resources/com.inductiveautomation.opcua/ModbusTcp": {
+			"parameters": [],
+			"post": {
+				"requestBody": {
+					"content": {
+						"application/json": {
+							"schema": {"type": "array", "items": {"$ref": "#/components/schemas/ModbusTcpResource"}}
+						}
+					}
+				}
+			}
+		}
+	},
+	"components": {
+		"schemas": {
+			"ModbusTcpResource": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"config": {"$ref": "#/components/schemas/ModbusTcpConfig"}
+				}
+			},
+			"ModbusTcpConfig": {
+				"type": "object",
+				"required": ["hostname"],
+				"properties": {
+					"hostname": {"type": "string", "minLength": 1},
+					"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+					"zeroBased": {"type": "boolean"},
+					"connectTimeout": {"type": "integer"},
+					"byteOrder": {"type": "string", "enum": ["BIG_ENDIAN", "LITTLE_ENDIAN"]},
+					"units": {"type": "array", "items": {"$ref": "#/components/schemas/Unit"}}
+				}
+			},
+			"Unit": {
+				"type": "object",
+				"properties": {"id": {"type": "integer"}}
+			}
+		}
+	}
+}`
+
+func TestClient_GetResourceTypeSchema(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/openapi" {
+			t.Errorf("Expected path /openapi, got %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(testOpenAPIDocument))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL, "test-token", false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	schema, err := c.GetResourceTypeSchema(context.Background(), "com.inductiveautomation.opcua", "ModbusTcp")
+	if err != nil {
+		t.Fatalf("GetResourceTypeSchema failed: %v", err)
+	}
+	if schema == nil || schema.Properties["hostname"] == nil {
+		t.Fatalf("Expected the ModbusTcp config schema, got %+v", schema)
+	}
+	if unit := schema.Properties["units"].Items; unit == nil || unit.Properties["id"] == nil {
+		t.Errorf("Expected the units items reference to be resolved, got %+v", unit)
+	}
+
+	// Undescribed types have no schema, and the document is only fetched once
+	schema, err = c.GetResourceTypeSchema(context.Background(), "ignition", "database-connection")
+	if err != nil || schema != nil {
+		t.Errorf("Expected no schema for an undescribed type, got %+v, %v", schema, err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected the OpenAPI document to be fetched once, got %d requests", requests)
+	}
+}
+
+func TestClient_GetResourceTypeSchema_NotServed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(server.URL, "test-token", false)
+	schema, err := c.GetResourceTypeSchema(context.Background(), "com.inductiveautomation.opcua", "ModbusTcp")
+	if err != nil || schema != nil {
+		t.Errorf("Expected no schema from a gateway without an OpenAPI document, got %+v, %v", schema, err)
+	}
+}
+
+func TestConfigSchema_Validate(t *testing.T) {
+	var doc openAPIDocument
+	if err := json.Unmarshal([]byte(testOpenAPIDocument), &doc); err != nil {
+		t.Fatal(err)
+	}
+	schema := doc.ResourceTypeSchema("com.inductiveautomation.opcua", "ModbusTcp")
+
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{
+			name:   "valid",
+			config: `{"hostname": "plc", "port": 502, "units": [{"id": 1}], "byteOrder": null}`,
+		},
+		{
+			name:   "misspelled key",
+			config: `{"hostname": "plc", "zeroBase": true, "Port": 502}`,
+			want:   []string{`Port: unknown key, did you mean "port"?`, `zeroBase: unknown key, did you mean "zeroBased"?`},
+		},
+		{
+			name:   "wrong types",
+			config: `{"hostname": "plc", "port": "502", "connectTimeout": 1.5}`,
+			want:   []string{"connectTimeout: expected integer, got number", "port: expected integer, got string"},
+		},
+		{
+			name:   "constraints",
+			config: `{"hostname": "", "port": 70000, "byteOrder": "MIDDLE", "units": [{"id": 1}, {"unit": 2}]}`,
+			want: []string{
+				`byteOrder: must be one of "BIG_ENDIAN", "LITTLE_ENDIAN"`,
+				"hostname: must be at least 1 characters long",
+				"port: must be at most 65535",
+				"units.1.unit: unknown key",
+			},
+		},
+		{
+			name:   "missing",
+			config: `{"port": 502}`,
+			want:   []string{"hostname: required key is missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config any
+			if err := json.Unmarshal([]byte(tt.config), &config); err != nil {
+				t.Fatal(err)
+			}
+			violations, err := schema.Validate(config)
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			var got []string
+			for _, v := range violations {
+				got = append(got, v.Error())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected violations %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConfigSchema_ValidateComposition(t *testing.T) {
+	var schema ConfigSchema
+	err := json.Unmarshal([]byte(`{
+		"allOf": [
+			{"type": "object", "properties": {"name": {"type": "string"}}},
+			{"properties": {"profile": {"oneOf": [
+				{"type": "object", "properties": {"type": {"enum": ["internal"]}}},
+				{"type": "object", "properties": {"type": {"enum": ["database"]}, "datasource": {"type": "string"}}}
+			]}}}
+		]
+	}`), &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	violations, _ := schema.Validate(map[string]any{"name": "users", "profile": map[string]any{"type": "database", "datasource": "mes"}})
+	if len(violations) != 0 {
+		t.Errorf("Expected no violations, got %v", violations)
+	}
+
+	violations, _ = schema.Validate(map[string]any{"name": "users", "profile": map[string]any{"type": "database", "datasorce": "mes"}})
+	if len(violations) != 1 || violations[0].Error() != `profile.datasorce: unknown key, did you mean "datasource"?` {
+		t.Errorf("Expected a misspelled datasource key, got %v", violations)
+	}
+}
@@ -0,0 +1,268 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// storedShapes converts between the REST API shape of a resource's config and
+// the shape stored in its config.json, keyed by module/type. Resource types not
+// listed are stored exactly as the API represents them.
+var storedShapes = map[string]struct {
+	toAPI    func(json.RawMessage) (json.RawMessage, error)
+	toStored func(json.RawMessage) (json.RawMessage, error)
+}{
+	"ignition/identity-provider": {identityProviderToAPI, identityProviderToStored},
+}
+
+// APIConfig converts the content of a resource's config.json into the config
+// the REST API returns for it
+func APIConfig(module, resourceType string, raw json.RawMessage) (json.RawMessage, error) {
+	if shape, ok := storedShapes[module+"/"+resourceType]; ok && len(raw) > 0 {
+		return shape.toAPI(raw)
+	}
+	return raw, nil
+}
+
+// StoredConfig converts a resource config in its REST API shape into the
+// content of the resource's config.json
+func StoredConfig(module, resourceType string, raw json.RawMessage) (json.RawMessage, error) {
+	if shape, ok := storedShapes[module+"/"+resourceType]; ok && len(raw) > 0 {
+		return shape.toStored(raw)
+	}
+	return raw, nil
+}
+
+// APIResourceType returns the resource type the REST API reports for a stored
+// resource. Devices are reported by their driver type (e.g., ModbusTcp), which
+// is stored in the profile of their config.
+func APIResourceType(module, resourceType string, raw json.RawMessage) string {
+	if module != "com.inductiveautomation.opcua" || resourceType != "device" {
+		return resourceType
+	}
+
+	var device struct {
+		Profile struct {
+			Type string `json:"type"`
+		} `json:"profile"`
+	}
+	if err := json.Unmarshal(raw, &device); err != nil || device.Profile.Type == "" {
+		return resourceType
+	}
+	return device.Profile.Type
+}
+
+// identityProviderProfileKeys are the identity provider settings stored in the
+// profile half of its config.json; everything else is stored in settings
+var identityProviderProfileKeys = map[string]bool{
+	"securityLevelRules":  true,
+	"userAttributeMapper": true,
+	"userGrants":          true,
+}
+
+// identityProviderToAPI converts a stored {profile, settings} identity provider
+// into the API's {type, config} form, where config merges both halves
+func identityProviderToAPI(raw json.RawMessage) (json.RawMessage, error) {
+	var stored struct {
+		Type     string         `json:"type"`
+		Profile  map[string]any `json:"profile"`
+		Settings map[string]any `json:"settings"`
+	}
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		return nil, err
+	}
+	if stored.Type != "" || stored.Profile == nil {
+		return raw, nil
+	}
+
+	config := make(map[string]any, len(stored.Profile)+len(stored.Settings))
+	for k, v := range stored.Profile {
+		if k != "type" {
+			config[k] = v
+		}
+	}
+	for k, v := range stored.Settings {
+		config[k] = v
+	}
+
+	return json.Marshal(map[string]any{
+		"type":   stored.Profile["type"],
+		"config": config,
+	})
+}
+
+// identityProviderToStored splits an API {type, config} identity provider into
+// the stored {profile, settings} form
+func identityProviderToStored(raw json.RawMessage) (json.RawMessage, error) {
+	var api struct {
+		Type   string         `json:"type"`
+		Config map[string]any `json:"config"`
+	}
+	if err := json.Unmarshal(raw, &api); err != nil {
+		return nil, err
+	}
+	if api.Type == "" {
+		return raw, nil
+	}
+
+	profile := map[string]any{"type": api.Type}
+	settings := make(map[string]any)
+	for k, v := range api.Config {
+		if identityProviderProfileKeys[k] {
+			profile[k] = v
+		} else {
+			settings[k] = v
+		}
+	}
+
+	return json.Marshal(map[string]any{
+		"profile":  profile,
+		"settings": settings,
+	})
+}
+
+// properties is a Java properties file in XML form (e.g., redundancy.xml)
+type properties struct {
+	XMLName xml.Name `xml:"properties"`
+	Comment string   `xml:"comment,omitempty"`
+	Entries []struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"entry"`
+}
+
+// redundancyProperties maps the properties in redundancy.xml onto the JSON
+// fields of RedundancyConfig. Properties prefixed with redundancy.gan. map onto
+// the fields of its gatewayNetworkSetup.
+var redundancyProperties = map[string]string{
+	"redundancy.noderole":           "role",
+	"redundancy.activehistorylevel": "activeHistoryLevel",
+	"redundancy.joinwaittime":       "joinWaitTime",
+	"redundancy.masterrecoverymode": "recoveryMode",
+}
+
+const redundancyNetworkPrefix = "redundancy.gan."
+
+// DecodeRedundancyProperties converts the content of a gateway's redundancy.xml
+// into a RedundancyConfig
+func DecodeRedundancyProperties(data []byte) (*RedundancyConfig, error) {
+	var props properties
+	if err := xml.Unmarshal(data, &props); err != nil {
+		return nil, err
+	}
+
+	cfg := make(map[string]any)
+	gan := make(map[string]any)
+	for _, e := range props.Entries {
+		if field, ok := redundancyProperties[e.Key]; ok {
+			cfg[field] = propertyValue(e.Value)
+		} else if field, ok := strings.CutPrefix(e.Key, redundancyNetworkPrefix); ok {
+			gan[field] = propertyValue(e.Value)
+		}
+	}
+	if len(gan) > 0 {
+		cfg["gatewayNetworkSetup"] = gan
+	}
+
+	// Round-trip through JSON so the property names map onto the model's JSON tags
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var out RedundancyConfig
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("error decoding redundancy settings: %w", err)
+	}
+	return &out, nil
+}
+
+// EncodeRedundancyProperties writes config into the redundancy.xml content in
+// data, keeping any properties it does not cover. data may be empty.
+func EncodeRedundancyProperties(data []byte, config RedundancyConfig) ([]byte, error) {
+	values := make(map[string]string)
+	if len(bytes.TrimSpace(data)) > 0 {
+		var props properties
+		if err := xml.Unmarshal(data, &props); err != nil {
+			return nil, err
+		}
+		for _, e := range props.Entries {
+			values[e.Key] = e.Value
+		}
+	}
+
+	var fields map[string]any
+	if err := roundTrip(config, &fields); err != nil {
+		return nil, err
+	}
+	for key, field := range redundancyProperties {
+		if v, ok := fields[field]; ok {
+			values[key] = propertyString(v)
+		}
+	}
+	if gan, ok := fields["gatewayNetworkSetup"].(map[string]any); ok {
+		for field, v := range gan {
+			values[redundancyNetworkPrefix+field] = propertyString(v)
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	props := properties{Comment: "Redundancy Settings"}
+	for _, k := range keys {
+		props.Entries = append(props.Entries, struct {
+			Key   string `xml:"key,attr"`
+			Value string `xml:",chardata"`
+		}{Key: k, Value: values[k]})
+	}
+
+	out, err := xml.MarshalIndent(props, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	header := xml.Header + `<!DOCTYPE properties SYSTEM "http://java.sun.com/dtd/properties.dtd">` + "\n"
+	return append(append([]byte(header), out...), '\n'), nil
+}
+
+// propertyValue converts a property string into a boolean or number where possible
+func propertyValue(s string) any {
+	s = strings.TrimSpace(s)
+	if s == "true" || s == "false" {
+		return s == "true"
+	}
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return n
+	}
+	return s
+}
+
+// propertyString formats a JSON value as a property string
+func propertyString(v any) string {
+	switch v := v.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// roundTrip copies src into dest through its JSON encoding
+func roundTrip(src, dest any) error {
+	raw, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, dest)
+}
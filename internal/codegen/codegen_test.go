@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDocument = `{
+	"paths": {
+		"/data/api/v1/resources/com.example/widget": {
+			"post": {
+				"requestBody": {
+					"content": {
+						"application/json": {
+							"schema": {"type": "array", "items": {
+								"type": "object",
+								"properties": {"name": {"type": "string"}, "config": {"$ref": "#/components/schemas/WidgetSettings"}}
+							}}
+						}
+					}
+				}
+			}
+		}
+	},
+	"components": {
+		"schemas": {
+			"WidgetSettings": {
+				"allOf": [
+					{"$ref": "#/components/schemas/Named"},
+					{
+						"type": "object",
+						"required": ["hostUrl", "retries"],
+						"properties": {
+							"hostUrl": {"type": "string", "description": "The URL of the host."},
+							"retries": {"type": "integer"},
+							"timeout": {"type": ["number", "null"]},
+							"useSSL": {"type": "boolean"},
+							"password": {"$ref": "#/components/schemas/EmbeddedSecret"},
+							"mode": {"type": "string", "enum": ["FAST", "SAFE"]},
+							"tags": {"type": "array", "items": {"type": "object", "properties": {"key": {"type": "string"}}}},
+							"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+							"extra": {"type": "object"},
+							"profile": {"oneOf": [{"type": "string"}, {"type": "integer"}]}
+						}
+					}
+				]
+			},
+			"Named": {
+				"type": "object",
+				"properties": {"title": {"type": "string"}}
+			},
+			"EmbeddedSecret": {"type": "object", "properties": {"type": {"type": "string"}}}
+		}
+	}
+}`
+
+func testGenerated(t *testing.T, reserved map[string]bool) (*generated, error) {
+	t.Helper()
+	var doc document
+	if err := json.Unmarshal([]byte(testDocument), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return generate(&doc, &config{
+		ExternalTypes: map[string]string{"EmbeddedSecret": "IgnitionSecret"},
+		Resources:     []resourceType{{Module: "com.example", Type: "widget", Name: "Widget"}},
+	}, reserved)
+}
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		name, exported, attribute string
+	}{
+		{"connectURL", "ConnectURL", "connect_url"},
+		{"jdbcDriver", "JDBCDriver", "jdbc_driver"},
+		{"JdbcDriverConfig", "JDBCDriverConfig", "jdbc_driver_config"},
+		{"useSSL", "UseSSL", "use_ssl"},
+		{"max-idle", "MaxIdle", "max_idle"},
+		{"opcUaServer2", "OPCUAServer2", "opc_ua_server2"},
+		{"2fa", "X2fa", "2fa"},
+	}
+	for _, tt := range tests {
+		if got := exportedName(tt.name); got != tt.exported {
+			t.Errorf("exportedName(%q) = %q, expected %q", tt.name, got, tt.exported)
+		}
+		if got := attributeName(tt.name); got != tt.attribute {
+			t.Errorf("attributeName(%q) = %q, expected %q", tt.name, got, tt.attribute)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	g, err := testGenerated(t, map[string]bool{"IgnitionSecret": true})
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	files, err := g.files("openapi.json", "client")
+	if err != nil {
+		t.Fatalf("Rendering failed: %v", err)
+	}
+
+	for name, src := range files {
+		if _, err := parser.ParseFile(token.NewFileSet(), name, src, 0); err != nil {
+			t.Errorf("%s does not parse: %v", name, err)
+		}
+		if !bytes.HasPrefix(src, []byte("// Code generated by codegen from openapi.json. DO NOT EDIT.")) {
+			t.Errorf("%s lacks the generated code header", name)
+		}
+	}
+
+	models := string(files["models_gen.go"])
+	for _, want := range []string{
+		"// WidgetConfig is the config of com.example/widget resources.",
+		"type WidgetConfig struct {",
+		"\tHostURL string `json:\"hostUrl\"`",
+		"\tRetries int64 `json:\"retries\"`",
+		"\tTimeout *float64 `json:\"timeout,omitempty\"`",
+		"\tUseSSL *bool `json:\"useSSL,omitempty\"`",
+		"\tPassword *IgnitionSecret `json:\"password,omitempty\"`",
+		"\t// One of FAST, SAFE.",
+		"\tTags []WidgetConfigTagsItem `json:\"tags,omitempty\"`",
+		"\tLabels map[string]string `json:\"labels,omitempty\"`",
+		"\tExtra map[string]any `json:\"extra,omitempty\"`",
+		"\tProfile any `json:\"profile,omitempty\"`",
+		"\tTitle string `json:\"title,omitempty\"`",
+		"type WidgetConfigTagsItem struct {",
+	} {
+		if !strings.Contains(strings.Join(strings.Fields(models), " "), strings.Join(strings.Fields(want), " ")) {
+			t.Errorf("models_gen.go lacks %q:\n%s", want, models)
+		}
+	}
+
+	for name, want := range map[string]string{
+		"client_gen.go":      `c.CreateResourceWithModule(ctx, "com.example", "widget", i, &r)`,
+		"mock_client_gen.go": "DeleteWidgetFunc func(ctx context.Context, n, s string) error",
+	} {
+		if !strings.Contains(strings.Join(strings.Fields(string(files[name])), " "), want) {
+			t.Errorf("%s lacks %q:\n%s", name, want, files[name])
+		}
+	}
+}
+
+func TestGenerate_Reserved(t *testing.T) {
+	_, err := testGenerated(t, map[string]bool{"WidgetConfig": true})
+	if err == nil || !strings.Contains(err.Error(), "WidgetConfig is already declared by hand") {
+		t.Errorf("Expected a conflict with the hand-written WidgetConfig, got %v", err)
+	}
+}
+
+func TestSkeleton(t *testing.T) {
+	g, err := testGenerated(t, nil)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	name, src, err := g.skeleton("com.example/widget")
+	if err != nil {
+		t.Fatalf("Rendering the skeleton failed: %v", err)
+	}
+	if name != "widget.go" {
+		t.Errorf("Expected widget.go, got %s", name)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), name, src, 0); err != nil {
+		t.Fatalf("The skeleton does not parse: %v", err)
+	}
+
+	code := strings.Join(strings.Fields(string(src)), " ")
+	for _, want := range []string{
+		`resp.TypeName = req.ProviderTypeName + "_widget"`,
+		"HostURL types.String `tfsdk:\"host_url\"`",
+		"Retries: model.Retries.ValueInt64(),",
+		"Timeout: model.Timeout.ValueFloat64Pointer(),",
+		"model.UseSSL = types.BoolPointerValue(config.UseSSL)",
+		"model.Title = base.StringToNullableString(config.Title)",
+		"// TODO: map the tags setting ([]WidgetConfigTagsItem)",
+		"CreateFunc: c.CreateWidget,",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("The skeleton lacks %q:\n%s", want, src)
+		}
+	}
+
+	if _, _, err := g.skeleton("com.example/gadget"); err == nil {
+		t.Error("Expected an error for a resource type that is not listed")
+	}
+}
+
+func TestSkeleton_KeepsExisting(t *testing.T) {
+	g, err := testGenerated(t, nil)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "widget.go"), []byte("package resources\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.writeSkeleton("com.example/widget", dir); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected the existing widget.go to be kept, got %v", err)
+	}
+}
+
+// TestGeneratedFilesUpToDate fails when the generated client files differ from
+// what the saved document and config produce, e.g. after either was edited
+// without running go generate
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "client")
+	var doc document
+	if err := readJSON(filepath.Join(dir, "openapi", "openapi.json"), &doc); err != nil {
+		t.Fatal(err)
+	}
+	var cfg config
+	if err := readJSON(filepath.Join(dir, "openapi", "resources.json"), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	reserved, err := declaredTypes(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := generate(&doc, &cfg, reserved)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	files, err := g.files("openapi/openapi.json", "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date; run go generate ./internal/client", name)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Command codegen generates the typed client code of gateway resource types
+// from an OpenAPI document checked into the repository, so that it runs offline.
+//
+// For every resource type listed in the config file, it writes the Go structs
+// of its config to models_gen.go, its typed IgnitionClient methods to
+// client_gen.go and their MockClient stubs to mock_client_gen.go. With
+// -skeleton, it instead writes a skeleton resource implementation of one of the
+// listed types, to be completed by hand.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "codegen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("codegen", flag.ContinueOnError)
+	specFile := flags.String("spec", "openapi/openapi.json", "the OpenAPI document describing the resource types")
+	configFile := flags.String("config", "openapi/resources.json", "the resource types to generate")
+	out := flags.String("out", ".", "the directory of the client package")
+	pkg := flags.String("package", "client", "the name of the client package")
+	skeleton := flags.String("skeleton", "", "write a skeleton resource of the given module/type instead")
+	resourcesDir := flags.String("resources", "../provider/resources", "the directory to write skeleton resources to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var doc document
+	if err := readJSON(*specFile, &doc); err != nil {
+		return err
+	}
+	var cfg config
+	if err := readJSON(*configFile, &cfg); err != nil {
+		return err
+	}
+
+	reserved, err := declaredTypes(*out)
+	if err != nil {
+		return err
+	}
+	g, err := generate(&doc, &cfg, reserved)
+	if err != nil {
+		return err
+	}
+
+	if *skeleton != "" {
+		file, err := g.writeSkeleton(*skeleton, *resourcesDir)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %s; register its resource and list resource in the provider.\n", file)
+		return nil
+	}
+
+	files, err := g.files(filepath.ToSlash(*specFile), *pkg)
+	if err != nil {
+		return err
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(*out, name), src, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generated holds the types and resources derived from a document
+type generated struct {
+	models    *models
+	resources []resource
+}
+
+// generate derives the config types of the resource types listed in cfg.
+// reserved holds the type names that the package already declares.
+func generate(doc *document, cfg *config, reserved map[string]bool) (*generated, error) {
+	g := &generated{models: newModels(doc, cfg.ExternalTypes, reserved)}
+	for _, rt := range cfg.Resources {
+		if rt.Module == "" || rt.Type == "" || rt.Name == "" {
+			return nil, fmt.Errorf("resource types need a module, type and name, got %+v", rt)
+		}
+		s, err := doc.configSchema(rt.Module, rt.Type)
+		if err != nil {
+			return nil, err
+		}
+		t, err := g.models.config(rt, s)
+		if err != nil {
+			return nil, fmt.Errorf("%s/%s: %w", rt.Module, rt.Type, err)
+		}
+		if st, ok := g.models.structs[t]; ok {
+			st.Doc = fmt.Sprintf("is the config of %s/%s resources.", rt.Module, rt.Type)
+		}
+		g.resources = append(g.resources, resource{resourceType: rt, Config: t})
+	}
+	return g, nil
+}
+
+// files renders the generated files of the client package
+func (g *generated) files(source, pkg string) (map[string][]byte, error) {
+	data := fileData{
+		Source:    source,
+		Package:   pkg,
+		Structs:   g.models.sorted(),
+		Resources: g.resources,
+	}
+	files := make(map[string][]byte)
+	for name, tmpl := range map[string]*template.Template{
+		"models_gen.go":      modelsTemplate,
+		"client_gen.go":      clientTemplate,
+		"mock_client_gen.go": mockTemplate,
+	} {
+		src, err := render(tmpl, data)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = src
+	}
+	return files, nil
+}
+
+// skeleton renders the skeleton resource of the given module/type
+func (g *generated) skeleton(moduleType string) (string, []byte, error) {
+	for _, r := range g.resources {
+		if r.Module+"/"+r.Type != moduleType {
+			continue
+		}
+		data := newSkeletonData(r, g.models.structs[r.Config])
+		src, err := render(skeletonTemplate, data)
+		return data.TypeName + ".go", src, err
+	}
+	return "", nil, fmt.Errorf("%s is not listed in the config", moduleType)
+}
+
+// writeSkeleton writes the skeleton resource of the given module/type to dir,
+// refusing to overwrite an existing implementation
+func (g *generated) writeSkeleton(moduleType, dir string) (string, error) {
+	name, src, err := g.skeleton(moduleType)
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(dir, name)
+	if _, err := os.Stat(file); err == nil {
+		return "", fmt.Errorf("%s already exists", file)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	return file, os.WriteFile(file, src, 0o644)
+}
+
+// declaredTypes returns the names of the types declared by hand in the Go
+// files of dir, which generated types must not redeclare
+func declaredTypes(dir string) (map[string]bool, error) {
+	names := make(map[string]bool)
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_gen.go") || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				names[spec.(*ast.TypeSpec).Name.Name] = true
+			}
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// structType is a Go struct generated from an object schema
+type structType struct {
+	Name   string
+	Doc    string
+	Fields []field
+}
+
+// field is a field of a generated struct
+type field struct {
+	Name     string
+	JSONName string
+	// Type is the Go type of the field, which is a pointer for optional scalars
+	// and structs so that unset values are left out rather than zeroed
+	Type     string
+	Doc      string
+	Required bool
+	// Scalar is the Go type of the field's value when it is a string, bool,
+	// int64 or float64, and empty otherwise
+	Scalar string
+}
+
+// models derives Go types from the schemas of a document
+type models struct {
+	doc      *document
+	external map[string]string
+	// reserved holds the names of the package's hand-written types
+	reserved map[string]bool
+	// names holds the Go names given to components, overriding the default
+	names   map[string]string
+	structs map[string]*structType
+	order   []string
+}
+
+func newModels(doc *document, external map[string]string, reserved map[string]bool) *models {
+	return &models{
+		doc:      doc,
+		external: external,
+		reserved: reserved,
+		names:    make(map[string]string),
+		structs:  make(map[string]*structType),
+	}
+}
+
+// sorted returns the generated structs ordered by name
+func (m *models) sorted() []*structType {
+	names := append([]string(nil), m.order...)
+	sort.Strings(names)
+	out := make([]*structType, len(names))
+	for i, n := range names {
+		out[i] = m.structs[n]
+	}
+	return out
+}
+
+// config returns the Go type of a resource type's config, naming it after the
+// resource type
+func (m *models) config(rt resourceType, s *schema) (string, error) {
+	name := rt.Name + "Config"
+	if s.Ref != "" {
+		component, err := componentName(s.Ref)
+		if err != nil {
+			return "", err
+		}
+		if ext, ok := m.external[component]; ok {
+			return ext, nil
+		}
+		m.names[component] = name
+	}
+	return m.goType(s, name)
+}
+
+// goType returns the Go type of the values described by s, generating the
+// structs it needs. hint names the struct of an inline object schema.
+func (m *models) goType(s *schema, hint string) (string, error) {
+	if s == nil {
+		return "any", nil
+	}
+
+	if s.Ref != "" {
+		component, err := componentName(s.Ref)
+		if err != nil {
+			return "", err
+		}
+		if ext, ok := m.external[component]; ok {
+			return ext, nil
+		}
+		target, err := m.doc.resolve(s)
+		if err != nil {
+			return "", err
+		}
+		name, ok := m.names[component]
+		if !ok {
+			name = exportedName(component)
+		}
+		return m.goType(target, name)
+	}
+
+	if len(s.AnyOf) > 0 || len(s.OneOf) > 0 {
+		// Alternatives of different shapes have no common Go type
+		return "any", nil
+	}
+
+	t, _ := s.Type.primary()
+	switch {
+	case t == "string":
+		return "string", nil
+	case t == "boolean":
+		return "bool", nil
+	case t == "integer":
+		return "int64", nil
+	case t == "number":
+		return "float64", nil
+	case t == "array":
+		item, err := m.goType(s.Items, hint+"Item")
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case len(s.Properties) > 0 || len(s.AllOf) > 0:
+		return m.object(s, hint)
+	case t == "object":
+		if additional := s.additionalSchema(); additional != nil {
+			value, err := m.goType(additional, hint+"Value")
+			if err != nil {
+				return "", err
+			}
+			return "map[string]" + value, nil
+		}
+		return "map[string]any", nil
+	}
+	return "any", nil
+}
+
+// object generates the struct of an object schema, merging the properties of
+// the schemas it is composed of with allOf
+func (m *models) object(s *schema, name string) (string, error) {
+	if _, ok := m.structs[name]; ok {
+		return name, nil
+	}
+	if m.reserved[name] {
+		return "", fmt.Errorf("%s is already declared by hand; map its component in externalTypes or rename the resource type", name)
+	}
+
+	st := &structType{Name: name, Doc: sentence("holds", s.Description)}
+	// Registered before its fields are derived, so that recursive schemas refer to it
+	m.structs[name] = st
+	m.order = append(m.order, name)
+
+	properties := make(map[string]*schema)
+	required := make(map[string]bool)
+	var merge func(s *schema) error
+	merge = func(s *schema) error {
+		s, err := m.doc.resolve(s)
+		if err != nil {
+			return err
+		}
+		for k, p := range s.Properties {
+			properties[k] = p
+		}
+		for _, k := range s.Required {
+			required[k] = true
+		}
+		for _, part := range s.AllOf {
+			if err := merge(part); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := merge(s); err != nil {
+		return "", err
+	}
+
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := properties[k]
+		fieldName := exportedName(k)
+		t, err := m.goType(p, name+fieldName)
+		if err != nil {
+			return "", fmt.Errorf("%s.%s: %w", name, k, err)
+		}
+
+		f := field{Name: fieldName, JSONName: k, Type: t, Required: required[k]}
+		resolved, err := m.doc.resolve(p)
+		if err != nil {
+			return "", err
+		}
+		f.Doc = describe(resolved)
+
+		_, nullable := resolved.Type.primary()
+		nullable = nullable || resolved.Nullable
+		switch {
+		case t == "string":
+			f.Scalar = t
+			if nullable && f.Required {
+				f.Type = "*" + t
+			}
+		case t == "bool" || t == "int64" || t == "float64":
+			f.Scalar = t
+			if !f.Required || nullable {
+				f.Type = "*" + t
+			}
+		case (m.structs[t] != nil || m.isExternal(t)) && (!f.Required || nullable):
+			f.Type = "*" + t
+		}
+		st.Fields = append(st.Fields, f)
+	}
+	return name, nil
+}
+
+// isExternal reports whether t is a hand-written type mapped in externalTypes
+func (m *models) isExternal(t string) bool {
+	for _, ext := range m.external {
+		if ext == t {
+			return true
+		}
+	}
+	return false
+}
+
+// describe returns the doc comment of a field with the given schema
+func describe(s *schema) string {
+	doc := strings.TrimSpace(s.Description)
+	if len(s.Enum) == 0 {
+		return doc
+	}
+	values := make([]string, len(s.Enum))
+	for i, v := range s.Enum {
+		values[i] = fmt.Sprintf("%v", v)
+	}
+	if doc != "" && !strings.HasSuffix(doc, ".") {
+		doc += "."
+	}
+	return strings.TrimSpace(doc + " One of " + strings.Join(values, ", ") + ".")
+}
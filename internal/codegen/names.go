@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// initialisms are the words that Go names spell in upper case
+var initialisms = map[string]bool{
+	"API": true, "DB": true, "DN": true, "GAN": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IDP": true, "JDBC": true, "JSON": true, "LDAP": true, "OIDC": true, "OPC": true, "SAML": true,
+	"SMTP": true, "SQL": true, "SSL": true, "TCP": true, "TLS": true, "UA": true, "URI": true,
+	"URL": true, "UUID": true, "XML": true,
+}
+
+// words splits a camelCase, PascalCase, kebab-case or snake_case name into words
+func words(name string) []string {
+	var out []string
+	runes := []rune(name)
+	start := 0
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+			if i > start && unicode.IsUpper(runes[i]) {
+				prev := runes[i-1]
+				next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				// A word starts at an upper case letter that follows a lower case
+				// letter or digit, or that ends a run of upper case letters
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && next) {
+					out = append(out, string(runes[start:i]))
+					start = i
+				}
+			}
+			continue
+		}
+		if i > start {
+			out = append(out, string(runes[start:i]))
+		}
+		start = i + 1
+	}
+	return out
+}
+
+// exportedName converts a JSON key or component name to an exported Go name
+// (e.g., connectURL to ConnectURL, jdbcDriver to JDBCDriver)
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, w := range words(name) {
+		if upper := strings.ToUpper(w); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		r := []rune(w)
+		b.WriteRune(unicode.ToUpper(r[0]))
+		b.WriteString(string(r[1:]))
+	}
+	if b.Len() == 0 || unicode.IsDigit([]rune(b.String())[0]) {
+		return "X" + b.String()
+	}
+	return b.String()
+}
+
+// attributeName converts a JSON key to the snake_case form of a Terraform
+// attribute name (e.g., connectURL to connect_url)
+func attributeName(key string) string {
+	ws := words(key)
+	for i, w := range ws {
+		ws[i] = strings.ToLower(w)
+	}
+	return strings.Join(ws, "_")
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// resource is a resource type together with the Go type of its config
+type resource struct {
+	resourceType
+	Config string
+}
+
+type fileData struct {
+	Source    string
+	Package   string
+	Structs   []*structType
+	Resources []resource
+}
+
+var funcs = template.FuncMap{
+	"comment": comment,
+}
+
+var modelsTemplate = template.Must(template.New("models").Funcs(funcs).Parse(`// Code generated by codegen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+{{range .Structs}}
+{{if .Doc}}{{comment "" (print .Name " " .Doc)}}
+{{end}}type {{.Name}} struct {
+{{- range .Fields}}
+{{- if .Doc}}
+{{comment "\t" .Doc}}
+{{- end}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSONName}}{{if not .Required}},omitempty{{end}}"` + "`" + `
+{{- end}}
+}
+{{end}}`))
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by codegen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+{{if .Resources}}
+import "context"
+{{end}}
+// generatedResources holds the typed methods of the resource types generated
+// from the OpenAPI document
+type generatedResources interface {
+{{- range .Resources}}
+	Get{{.Name}}(ctx context.Context, name string) (*ResourceResponse[{{.Config}}], error)
+	Create{{.Name}}(ctx context.Context, item ResourceResponse[{{.Config}}]) (*ResourceResponse[{{.Config}}], error)
+	Update{{.Name}}(ctx context.Context, item ResourceResponse[{{.Config}}]) (*ResourceResponse[{{.Config}}], error)
+	Delete{{.Name}}(ctx context.Context, name, signature string) error
+{{- end}}
+}
+{{range .Resources}}
+func (c typedResources) Get{{.Name}}(ctx context.Context, n string) (*ResourceResponse[{{.Config}}], error) {
+	return getR[{{.Config}}](ctx, c, "{{.Module}}", "{{.Type}}", n)
+}
+func (c typedResources) Create{{.Name}}(ctx context.Context, i ResourceResponse[{{.Config}}]) (*ResourceResponse[{{.Config}}], error) {
+	var r ResourceResponse[{{.Config}}]
+	err := c.CreateResourceWithModule(ctx, "{{.Module}}", "{{.Type}}", i, &r)
+	return &r, err
+}
+func (c typedResources) Update{{.Name}}(ctx context.Context, i ResourceResponse[{{.Config}}]) (*ResourceResponse[{{.Config}}], error) {
+	var r ResourceResponse[{{.Config}}]
+	err := c.UpdateResourceWithModule(ctx, "{{.Module}}", "{{.Type}}", i, &r)
+	return &r, err
+}
+func (c typedResources) Delete{{.Name}}(ctx context.Context, n, s string) error {
+	return c.DeleteResourceWithModule(ctx, "{{.Module}}", "{{.Type}}", n, s)
+}
+{{end}}`))
+
+var mockTemplate = template.Must(template.New("mock").Parse(`// Code generated by codegen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+{{if .Resources}}
+import "context"
+{{end}}
+// GeneratedMockFuncs holds the stubs of the MockClient methods generated from
+// the OpenAPI document
+type GeneratedMockFuncs struct {
+{{- range .Resources}}
+	Get{{.Name}}Func    func(ctx context.Context, n string) (*ResourceResponse[{{.Config}}], error)
+	Create{{.Name}}Func func(ctx context.Context, i ResourceResponse[{{.Config}}]) (*ResourceResponse[{{.Config}}], error)
+	Update{{.Name}}Func func(ctx context.Context, i ResourceResponse[{{.Config}}]) (*ResourceResponse[{{.Config}}], error)
+	Delete{{.Name}}Func func(ctx context.Context, n, s string) error
+{{- end}}
+}
+{{range .Resources}}
+func (m *MockClient) Get{{.Name}}(ctx context.Context, n string) (*ResourceResponse[{{.Config}}], error) {
+	if m.Get{{.Name}}Func != nil {
+		return m.Get{{.Name}}Func(ctx, n)
+	}
+	return &ResourceResponse[{{.Config}}]{}, nil
+}
+func (m *MockClient) Create{{.Name}}(ctx context.Context, i ResourceResponse[{{.Config}}]) (*ResourceResponse[{{.Config}}], error) {
+	if m.Create{{.Name}}Func != nil {
+		return m.Create{{.Name}}Func(ctx, i)
+	}
+	return &ResourceResponse[{{.Config}}]{}, nil
+}
+func (m *MockClient) Update{{.Name}}(ctx context.Context, i ResourceResponse[{{.Config}}]) (*ResourceResponse[{{.Config}}], error) {
+	if m.Update{{.Name}}Func != nil {
+		return m.Update{{.Name}}Func(ctx, i)
+	}
+	return &ResourceResponse[{{.Config}}]{}, nil
+}
+func (m *MockClient) Delete{{.Name}}(ctx context.Context, n, s string) error {
+	if m.Delete{{.Name}}Func != nil {
+		return m.Delete{{.Name}}Func(ctx, n, s)
+	}
+	return nil
+}
+{{end}}`))
+
+// render executes tmpl and formats the result as Go source
+func render(tmpl *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting %s: %w\n%s", tmpl.Name(), err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// comment formats text as a Go comment wrapped at 80 columns, with each line
+// starting with indent
+func comment(indent, text string) string {
+	var lines []string
+	line := indent + "//"
+	for _, w := range strings.Fields(text) {
+		if len(line)+1+len(w) > 80 && line != indent+"//" {
+			lines = append(lines, line)
+			line = indent + "//"
+		}
+		line += " " + w
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
+// sentence returns description as the predicate of a doc comment (e.g., "The
+// settings." becomes "holds the settings.")
+func sentence(verb, description string) string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return ""
+	}
+	r := []rune(description)
+	// Lower the first letter unless it starts an initialism
+	if len(r) < 2 || !unicode.IsUpper(r[1]) {
+		r[0] = unicode.ToLower(r[0])
+	}
+	description = string(r)
+	if !strings.HasSuffix(description, ".") {
+		description += "."
+	}
+	return verb + " " + description
+}
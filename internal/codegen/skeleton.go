@@ -0,0 +1,297 @@
+package main
+
+import (
+	"strings"
+	"text/template"
+)
+
+// reservedAttributes are the attribute names that resources declare for
+// themselves, or that Terraform reserves, which config keys cannot map to
+var reservedAttributes = map[string]bool{
+	"id": true, "name": true, "description": true, "enabled": true, "collection": true, "signature": true,
+	"count": true, "depends_on": true, "for_each": true, "lifecycle": true, "provider": true,
+	"provisioner": true, "connection": true,
+}
+
+// attribute is a resource attribute that maps to a scalar config setting
+type attribute struct {
+	field
+	Attribute string
+}
+
+// Kind returns the framework name of the attribute's type (e.g., String)
+func (a attribute) Kind() string {
+	switch a.Scalar {
+	case "bool":
+		return "Bool"
+	case "int64":
+		return "Int64"
+	case "float64":
+		return "Float64"
+	}
+	return "String"
+}
+
+// PlanValue returns the expression that maps the model's value to the config
+func (a attribute) PlanValue() string {
+	if strings.HasPrefix(a.Type, "*") {
+		return "model." + a.Name + ".Value" + a.Kind() + "Pointer()"
+	}
+	return "model." + a.Name + ".Value" + a.Kind() + "()"
+}
+
+// StateValue returns the expression that maps the config's value to the model
+func (a attribute) StateValue() string {
+	switch {
+	case strings.HasPrefix(a.Type, "*"):
+		return "types." + a.Kind() + "PointerValue(config." + a.Name + ")"
+	case a.Scalar == "string" && !a.Required:
+		return "base.StringToNullableString(config." + a.Name + ")"
+	}
+	return "types." + a.Kind() + "Value(config." + a.Name + ")"
+}
+
+type skeletonData struct {
+	resource
+	// TypeName is the suffix of the Terraform resource type (e.g., jdbc_driver)
+	TypeName string
+	// Title names the resource type in descriptions (e.g., JDBC driver)
+	Title      string
+	Article    string
+	Attributes []attribute
+	// Unmapped holds the config settings that need hand-written attributes
+	Unmapped []field
+}
+
+func newSkeletonData(r resource, config *structType) skeletonData {
+	data := skeletonData{
+		resource: r,
+		TypeName: attributeName(r.Name),
+		Title:    title(r.Name),
+		Article:  "a",
+	}
+	if strings.ContainsRune("aeiouAEIOU", rune(data.Title[0])) {
+		data.Article = "an"
+	}
+	if config == nil {
+		return data
+	}
+	for _, f := range config.Fields {
+		name := attributeName(f.JSONName)
+		if f.Scalar == "" || reservedAttributes[name] {
+			data.Unmapped = append(data.Unmapped, f)
+			continue
+		}
+		data.Attributes = append(data.Attributes, attribute{field: f, Attribute: name})
+	}
+	return data
+}
+
+// title returns the words of a Go name in lower case, keeping initialisms
+// (e.g., JDBCDriver becomes JDBC driver)
+func title(name string) string {
+	ws := words(name)
+	for i, w := range ws {
+		if !initialisms[strings.ToUpper(w)] {
+			ws[i] = strings.ToLower(w)
+		}
+	}
+	return strings.Join(ws, " ")
+}
+
+var skeletonTemplate = template.Must(template.New("skeleton").Parse(`package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &{{.Name}}Resource{}
+var _ resource.ResourceWithImportState = &{{.Name}}Resource{}
+var _ resource.ResourceWithIdentity = &{{.Name}}Resource{}
+var _ resource.ResourceWithModifyPlan = &{{.Name}}Resource{}
+var _ list.ListResourceWithConfigure = &{{.Name}}Resource{}
+
+func New{{.Name}}Resource() resource.Resource {
+	return &{{.Name}}Resource{}
+}
+
+func New{{.Name}}ListResource() list.ListResource {
+	return &{{.Name}}Resource{}
+}
+
+// {{.Name}}Resource defines the resource implementation.
+type {{.Name}}Resource struct {
+	client  client.IgnitionClient
+	generic base.GenericIgnitionResource[client.{{.Config}}, {{.Name}}ResourceModel]
+}
+
+// {{.Name}}ResourceModel describes the resource data model.
+type {{.Name}}ResourceModel struct {
+	base.BaseResourceModel
+	base.CollectionResourceModel
+{{- range .Attributes}}
+	{{.Name}} types.{{.Kind}} ` + "`" + `tfsdk:"{{.Attribute}}"` + "`" + `
+{{- end}}
+}
+
+func (r *{{.Name}}Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_{{.TypeName}}"
+	base.AllowRenames(resp)
+}
+
+func (r *{{.Name}}Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages {{.Article}} {{.Title}} in Ignition.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					base.UseNameForID(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the {{.Title}}.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the {{.Title}}.",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the {{.Title}} is enabled.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+{{- range .Attributes}}
+			"{{.Attribute}}": schema.{{.Kind}}Attribute{
+{{- if .Doc}}
+				Description: {{printf "%q" .Doc}},
+{{- end}}
+{{- if .Required}}
+				Required: true,
+{{- else}}
+				Optional: true,
+{{- end}}
+			},
+{{- end}}
+			"collection": base.CollectionAttribute(),
+			"signature": schema.StringAttribute{
+				Description: "The signature of the resource.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *{{.Name}}Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(client.IgnitionClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.IgnitionClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = c
+	r.generic = base.GenericIgnitionResource[client.{{.Config}}, {{.Name}}ResourceModel]{
+		Client:       c,
+		Handler:      r,
+		Module:       "{{.Module}}",
+		ResourceType: "{{.Type}}",
+		CreateFunc:   c.Create{{.Name}},
+		GetFunc:      c.Get{{.Name}},
+		UpdateFunc:   c.Update{{.Name}},
+		DeleteFunc:   c.Delete{{.Name}},
+		Schemas:      base.SchemaValidatorFrom(req.ProviderData),
+	}
+}
+
+func (r *{{.Name}}Resource) MapPlanToClient(ctx context.Context, model *{{.Name}}ResourceModel) (client.{{.Config}}, error) {
+{{- range .Unmapped}}
+	// TODO: map the {{.JSONName}} setting ({{.Type}})
+{{- end}}
+	return client.{{.Config}}{
+{{- range .Attributes}}
+		{{.Name}}: {{.PlanValue}},
+{{- end}}
+	}, nil
+}
+
+func (r *{{.Name}}Resource) MapClientToState(ctx context.Context, name string, config *client.{{.Config}}, model *{{.Name}}ResourceModel) error {
+	model.Name = types.StringValue(name)
+{{- range .Attributes}}
+	model.{{.Name}} = {{.StateValue}}
+{{- end}}
+	return nil
+}
+
+func (r *{{.Name}}Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data {{.Name}}ResourceModel
+	r.generic.Create(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *{{.Name}}Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data {{.Name}}ResourceModel
+	r.generic.Read(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *{{.Name}}Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data {{.Name}}ResourceModel
+	r.generic.Update(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *{{.Name}}Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data {{.Name}}ResourceModel
+	r.generic.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *{{.Name}}Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.generic.CheckSchema(ctx, req, resp)
+}
+
+func (r *{{.Name}}Resource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
+func (r *{{.Name}}Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.generic.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &{{.Name}}ResourceModel{
+		BaseResourceModel: base.BaseResourceModel{
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
+		},
+	})...)
+}
+
+func (r *{{.Name}}Resource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists the {{.Title}} resources configured on the gateway.")
+}
+
+func (r *{{.Name}}Resource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.generic.List(ctx, req, stream, func(_ *client.ResourceResponse[client.{{.Config}}]) (*{{.Name}}ResourceModel, *base.BaseResourceModel) {
+		var data {{.Name}}ResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
+`))
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// schema is the subset of JSON Schema, as used by OpenAPI documents, that the
+// generator reads to derive Go types
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 schemaTypes        `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Nullable             bool               `json:"nullable"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Enum                 []any              `json:"enum"`
+	AllOf                []*schema          `json:"allOf"`
+	AnyOf                []*schema          `json:"anyOf"`
+	OneOf                []*schema          `json:"oneOf"`
+}
+
+// schemaTypes holds the type keyword, which is a single type name in OpenAPI 3.0
+// and may be a list of them in OpenAPI 3.1
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = schemaTypes{name}
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*t = names
+	return nil
+}
+
+// primary returns the first type that is not null, and whether null is allowed
+func (t schemaTypes) primary() (string, bool) {
+	name, null := "", false
+	for _, n := range t {
+		if n == "null" {
+			null = true
+		} else if name == "" {
+			name = n
+		}
+	}
+	return name, null
+}
+
+// additionalSchema returns the schema of the additional properties of s, or
+// nil when it does not describe them
+func (s *schema) additionalSchema() *schema {
+	if len(s.AdditionalProperties) == 0 {
+		return nil
+	}
+	var additional schema
+	if err := json.Unmarshal(s.AdditionalProperties, &additional); err != nil {
+		// additionalProperties is a boolean
+		return nil
+	}
+	return &additional
+}
+
+type document struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type operation struct {
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema *schema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+}
+
+// config lists the resource types to generate and the components that map to
+// hand-written types
+type config struct {
+	// ExternalTypes maps component names to Go types of the package that are
+	// written by hand, which are used instead of generating them
+	ExternalTypes map[string]string `json:"externalTypes"`
+	Resources     []resourceType    `json:"resources"`
+}
+
+type resourceType struct {
+	Module string `json:"module"`
+	Type   string `json:"type"`
+	// Name is the Go name of the resource type (e.g., JDBCDriver), from which
+	// the names of its config struct and client methods are derived
+	Name string `json:"name"`
+}
+
+func readJSON(file string, v any) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", file, err)
+	}
+	return nil
+}
+
+func componentName(ref string) (string, error) {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok {
+		return "", fmt.Errorf("unsupported reference %q", ref)
+	}
+	return name, nil
+}
+
+// resolve follows the component reference of s
+func (d *document) resolve(s *schema) (*schema, error) {
+	for i := 0; s != nil && s.Ref != ""; i++ {
+		if i == 32 {
+			return nil, fmt.Errorf("reference cycle at %q", s.Ref)
+		}
+		name, err := componentName(s.Ref)
+		if err != nil {
+			return nil, err
+		}
+		target, ok := d.Components.Schemas[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown component %q", name)
+		}
+		s = target
+	}
+	return s, nil
+}
+
+// configSchema returns the schema of the config of the given resource type,
+// which the resource endpoint takes as the config of each resource in its
+// request body. It is returned unresolved, so that a component reference keeps
+// its name.
+func (d *document) configSchema(module, resourceType string) (*schema, error) {
+	p := "/data/api/v1/resources/" + module + "/" + resourceType
+	item, ok := d.Paths[p]
+	if !ok {
+		return nil, fmt.Errorf("the document does not describe %s", p)
+	}
+
+	for _, method := range []string{"post", "put"} {
+		var op operation
+		if raw, ok := item[method]; !ok || json.Unmarshal(raw, &op) != nil || op.RequestBody == nil {
+			continue
+		}
+		media, ok := op.RequestBody.Content["application/json"]
+		if !ok || media.Schema == nil {
+			continue
+		}
+
+		s, err := d.resolve(media.Schema)
+		if err != nil {
+			return nil, err
+		}
+		if t, _ := s.Type.primary(); t == "array" {
+			if s, err = d.resolve(s.Items); err != nil {
+				return nil, err
+			}
+		}
+		if s != nil && s.Properties["config"] != nil {
+			return s.Properties["config"], nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no request body with a resource config", p)
+}
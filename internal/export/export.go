@@ -0,0 +1,311 @@
+// Package export generates Terraform configuration for the resources on a
+// gateway. Every resource is read through the provider's own list resources,
+// so the generated HCL matches what the provider would store in state and
+// applies without changes once the accompanying import blocks have run.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/gwbk"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ProviderTypeName is the prefix of every exported resource type
+const ProviderTypeName = "ignition"
+
+// Resource is a resource read from the gateway, ready to be rendered as HCL
+type Resource struct {
+	// TypeName is the Terraform resource type (e.g., ignition_project)
+	TypeName string
+	// Label is the resource name in the configuration, unique per type
+	Label string
+	// ID is the import ID of the resource
+	ID string
+	// Module and ResourceType identify the resource type on the gateway
+	Module       string
+	ResourceType string
+
+	result     list.ListResult
+	references []base.Reference
+	// secrets holds the paths of the sensitive attributes set on the gateway
+	secrets map[string]bool
+}
+
+// Gateway reads every resource on the gateway behind c. Resources that cannot
+// be read are skipped with a warning.
+func Gateway(ctx context.Context, c client.IgnitionClient) ([]Resource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	p, ok := provider.New("export")().(fwprovider.ProviderWithListResources)
+	if !ok {
+		diags.AddError("Unsupported Provider", "The provider does not implement list resources.")
+		return nil, diags
+	}
+
+	var out []Resource
+	for _, newListResource := range p.ListResources(ctx) {
+		items, d := listAll(ctx, c, newListResource())
+		diags.Append(d...)
+		out = append(out, items...)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].TypeName < out[j].TypeName })
+	assignLabels(out)
+	return out, diags
+}
+
+// Backup reads every resource in the gateway backup at the given path. The
+// backup's configuration is unpacked into a temporary directory and read with a
+// FilesystemClient, so no gateway is needed.
+func Backup(ctx context.Context, name string) ([]Resource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	dir, err := os.MkdirTemp("", "gwbk-")
+	if err != nil {
+		diags.AddError("Error reading gateway backup", err.Error())
+		return nil, diags
+	}
+	defer os.RemoveAll(dir)
+
+	if err := gwbk.Extract(name, dir); err != nil {
+		diags.AddError("Error reading gateway backup", err.Error())
+		return nil, diags
+	}
+
+	c, err := client.NewFilesystemClient(dir)
+	if err != nil {
+		diags.AddError("Error creating client", err.Error())
+		return nil, diags
+	}
+
+	return Gateway(ctx, c)
+}
+
+// listAll reads every instance of a list resource
+func listAll(ctx context.Context, c client.IgnitionClient, lr list.ListResource) ([]Resource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	r, ok := lr.(resource.ResourceWithIdentity)
+	if !ok {
+		return nil, diags
+	}
+
+	var metaResp resource.MetadataResponse
+	r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: ProviderTypeName}, &metaResp)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	diags.Append(schemaResp.Diagnostics...)
+
+	var identityResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identityResp)
+	diags.Append(identityResp.Diagnostics...)
+
+	if configurable, ok := lr.(list.ListResourceWithConfigure); ok {
+		var configureResp resource.ConfigureResponse
+		configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: c}, &configureResp)
+		diags.Append(configureResp.Diagnostics...)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	req := list.ListRequest{
+		IncludeResource:        true,
+		ResourceSchema:         schemaResp.Schema,
+		ResourceIdentitySchema: identityResp.IdentitySchema,
+	}
+	var stream list.ListResultsStream
+	lr.List(ctx, req, &stream)
+	if stream.Results == nil {
+		return nil, diags
+	}
+
+	var references []base.Reference
+	if withReferences, ok := lr.(base.ResourceWithReferences); ok {
+		references = withReferences.ReferenceAttributes()
+	}
+	var secrets []base.Secret
+	if withSecrets, ok := lr.(base.ResourceWithSecrets); ok {
+		secrets = withSecrets.SecretAttributes()
+	}
+
+	var out []Resource
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			if result.DisplayName == "" {
+				diags.Append(result.Diagnostics...)
+				return nil, diags
+			}
+			for _, d := range result.Diagnostics.Errors() {
+				diags.AddWarning(
+					fmt.Sprintf("Skipped %s %q", metaResp.TypeName, result.DisplayName),
+					fmt.Sprintf("%s: %s", d.Summary(), d.Detail()),
+				)
+			}
+			continue
+		}
+
+		var identity base.ResourceIdentityModel
+		diags.Append(result.Identity.Get(ctx, &identity)...)
+
+		set, err := setSecrets(ctx, c, identity.Module.ValueString(), identity.Type.ValueString(), result.DisplayName, secrets)
+		if err != nil {
+			diags.AddWarning(
+				fmt.Sprintf("Skipped %s %q", metaResp.TypeName, result.DisplayName),
+				fmt.Sprintf("Unable to read its secrets: %s", err),
+			)
+			continue
+		}
+
+		out = append(out, Resource{
+			TypeName:     metaResp.TypeName,
+			ID:           result.DisplayName,
+			Module:       identity.Module.ValueString(),
+			ResourceType: identity.Type.ValueString(),
+			result:       result,
+			references:   references,
+			secrets:      set,
+		})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, diags
+}
+
+// setSecrets returns the paths of the secrets that have a value in the config of
+// the named resource. The gateway only returns them encrypted, so they are not
+// part of the listed state.
+func setSecrets(ctx context.Context, c client.IgnitionClient, module, resourceType, name string, secrets []base.Secret) (map[string]bool, error) {
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+
+	var res client.ResourceResponse[json.RawMessage]
+	if err := c.GetResourceWithModule(ctx, module, resourceType, name, &res); err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]bool, len(secrets))
+	for _, s := range secrets {
+		if s.IsSet(res.Config) {
+			set[s.Path.String()] = true
+		}
+	}
+	return set, nil
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// assignLabels derives a unique configuration label for each resource from its
+// name. A label that is taken gets the first free numeric suffix, which may
+// itself be the label another name sanitizes to.
+func assignLabels(resources []Resource) {
+	taken := make(map[string]bool)
+	for i := range resources {
+		label := strings.Trim(invalidLabelChars.ReplaceAllString(strings.ToLower(resources[i].ID), "_"), "_")
+		if label == "" || !hclsyntax.ValidIdentifier(label) || (label[0] >= '0' && label[0] <= '9') {
+			label = "r_" + label
+		}
+
+		candidate := label
+		for n := 2; taken[resources[i].TypeName+"."+candidate]; n++ {
+			candidate = fmt.Sprintf("%s_%d", label, n)
+		}
+		taken[resources[i].TypeName+"."+candidate] = true
+		resources[i].Label = candidate
+	}
+}
+
+// File is a generated configuration file
+type File struct {
+	// Name is the file name (e.g., ignition_project.tf)
+	Name    string
+	Content []byte
+}
+
+// Files renders the resources as one file per resource type, each resource
+// followed by the import block that adopts it into state. Secrets are set from
+// variables declared in variables.tf, which comes first when any are needed.
+func Files(ctx context.Context, resources []Resource) ([]File, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	r := newRenderer(resources)
+	files := make(map[string]*hclwrite.File)
+	var order []string
+
+	for _, res := range resources {
+		f, ok := files[res.TypeName]
+		if !ok {
+			f = hclwrite.NewEmptyFile()
+			files[res.TypeName] = f
+			order = append(order, res.TypeName)
+		} else {
+			f.Body().AppendNewline()
+		}
+		body := f.Body()
+
+		block := body.AppendNewBlock("resource", []string{res.TypeName, res.Label})
+		diags.Append(r.writeResource(ctx, block.Body(), res)...)
+
+		body.AppendNewline()
+		imp := body.AppendNewBlock("import", nil)
+		imp.Body().SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: res.TypeName},
+			hcl.TraverseAttr{Name: res.Label},
+		})
+		imp.Body().SetAttributeValue("id", cty.StringVal(res.ID))
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var out []File
+	if len(r.variables) > 0 {
+		out = append(out, File{Name: "variables.tf", Content: r.variablesFile().Bytes()})
+	}
+	for _, typeName := range order {
+		out = append(out, File{Name: typeName + ".tf", Content: files[typeName].Bytes()})
+	}
+	return out, diags
+}
+
+// Write renders the resources as a single configuration
+func Write(ctx context.Context, w io.Writer, resources []Resource) diag.Diagnostics {
+	files, diags := Files(ctx, resources)
+	if diags.HasError() {
+		return diags
+	}
+
+	for i, f := range files {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				diags.AddError("Error writing configuration", err.Error())
+				return diags
+			}
+		}
+		if _, err := w.Write(f.Content); err != nil {
+			diags.AddError("Error writing configuration", err.Error())
+			return diags
+		}
+	}
+	return diags
+}
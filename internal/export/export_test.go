@@ -0,0 +1,226 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/fakegateway"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestGateway(t *testing.T) {
+	g := fakegateway.New()
+	defer g.Close()
+
+	g.PutResource("ignition", "database-connection", fakegateway.Resource{
+		Name:   "MES DB",
+		Config: json.RawMessage(`{"driver": "MariaDB", "translator": "MYSQL", "connectURL": "jdbc:mariadb://db/mes", "username": "mes", "password": {"type": "Embedded", "data": {}}}`),
+	})
+	g.PutResource("com.inductiveautomation.opcua", "device", fakegateway.Resource{
+		Name:   "press",
+		Type:   "ModbusTcp",
+		Config: json.RawMessage(`{"profile": {"type": "ModbusTcp"}, "settings": {"hostname": "10.0.0.5", "port": 502}}`),
+	})
+	g.PutProject(client.Project{Name: "hmi", Enabled: true, DefaultDB: "MES DB"})
+
+	c, err := client.NewClient(g.URL, g.Token, false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	resources, diags := Gateway(context.Background(), c)
+	if diags.HasError() {
+		t.Fatalf("Gateway failed: %v", diags)
+	}
+
+	var buf bytes.Buffer
+	if diags := Write(context.Background(), &buf, resources); diags.HasError() {
+		t.Fatalf("Write failed: %v", diags)
+	}
+	out := buf.String()
+	// Compare with whitespace collapsed, as attributes are aligned
+	flat := strings.Join(strings.Fields(out), " ")
+
+	if _, parseDiags := hclsyntax.ParseConfig(buf.Bytes(), "export.tf", hcl.InitialPos); parseDiags.HasErrors() {
+		t.Fatalf("Generated configuration does not parse: %s\n%s", parseDiags, out)
+	}
+
+	for _, want := range []string{
+		`resource "ignition_database_connection" "mes_db" {`,
+		`connect_url = "jdbc:mariadb://db/mes"`,
+		`password = var.database_connection_mes_db_password`,
+		`variable "database_connection_mes_db_password" {`,
+		`sensitive = true`,
+		`type = "ModbusTcp"`,
+		`to = ignition_database_connection.mes_db`,
+		`id = "MES DB"`,
+		`resource "ignition_device" "press" {`,
+		`parameters = jsonencode({`,
+		`resource "ignition_project" "hmi" {`,
+		`default_db = ignition_database_connection.mes_db.name`,
+		`to = ignition_project.hmi`,
+	} {
+		if !strings.Contains(flat, want) {
+			t.Errorf("Expected output to contain %q:\n%s", want, out)
+		}
+	}
+
+	// Computed-only attributes are left out of the resource blocks
+	_, block, _ := strings.Cut(flat, `resource "ignition_database_connection" "mes_db" {`)
+	block, _, _ = strings.Cut(block, "import {")
+	for _, unwanted := range []string{"signature =", "id ="} {
+		if strings.Contains(block, unwanted) {
+			t.Errorf("Expected computed attribute %q to be omitted:\n%s", unwanted, out)
+		}
+	}
+}
+
+func TestFiles(t *testing.T) {
+	g := fakegateway.New()
+	defer g.Close()
+
+	g.PutResource("ignition", "user-source", fakegateway.Resource{
+		Name:   "corp",
+		Config: json.RawMessage(`{"profile": {"type": "INTERNAL"}}`),
+	})
+	g.PutResource("ignition", "identity-provider", fakegateway.Resource{
+		Name:   "corp",
+		Config: json.RawMessage(`{"type": "internal", "config": {"userSource": "corp"}}`),
+	})
+	// A reference to a resource that was not exported stays a literal name
+	g.PutResource("ignition", "identity-provider", fakegateway.Resource{
+		Name:   "legacy",
+		Config: json.RawMessage(`{"type": "internal", "config": {"userSource": "removed"}}`),
+	})
+	g.PutResource("ignition", "identity-provider", fakegateway.Resource{
+		Name:   "sso",
+		Config: json.RawMessage(`{"type": "oidc", "config": {"clientId": "ignition", "clientSecret": {"type": "Embedded", "data": {}}, "providerId": "https://idp.example.com"}}`),
+	})
+
+	c, err := client.NewClient(g.URL, g.Token, false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	resources, diags := Gateway(context.Background(), c)
+	if diags.HasError() {
+		t.Fatalf("Gateway failed: %v", diags)
+	}
+
+	files, diags := Files(context.Background(), resources)
+	if diags.HasError() {
+		t.Fatalf("Files failed: %v", diags)
+	}
+
+	contents := make(map[string]string)
+	for _, f := range files {
+		contents[f.Name] = strings.Join(strings.Fields(string(f.Content)), " ")
+	}
+
+	for _, name := range []string{"variables.tf", "ignition_gan_settings.tf", "ignition_identity_provider.tf", "ignition_redundancy.tf", "ignition_user_source.tf"} {
+		if _, ok := contents[name]; !ok {
+			t.Errorf("Expected a file named %s, got %v", name, files)
+		}
+	}
+
+	idp := contents["ignition_identity_provider.tf"]
+	if !strings.Contains(idp, `user_source = ignition_user_source.corp.name`) {
+		t.Errorf("Expected a reference to the exported user source:\n%s", idp)
+	}
+	if !strings.Contains(idp, `user_source = "removed"`) {
+		t.Errorf("Expected a literal name for a user source that was not exported:\n%s", idp)
+	}
+	if !strings.Contains(contents["variables.tf"], `variable "identity_provider_sso_client_secret" {`) {
+		t.Errorf("Expected a variable for the client secret:\n%s", contents["variables.tf"])
+	}
+	// Secrets that are not set on the gateway get no variable
+	if strings.Contains(contents["variables.tf"], "identity_provider_corp_") || strings.Contains(idp, "var.identity_provider_corp_") {
+		t.Errorf("Expected no variable for the internal identity provider:\n%s", contents["variables.tf"])
+	}
+}
+
+func TestBackup(t *testing.T) {
+	resources, diags := Backup(context.Background(), "../../assets/ignition.gwbk")
+	if diags.HasError() {
+		t.Fatalf("Backup failed: %v", diags)
+	}
+
+	labels := make(map[string]string)
+	for _, res := range resources {
+		labels[res.TypeName+"."+res.Label] = res.ID
+	}
+
+	for address, id := range map[string]string{
+		"ignition_tag_provider.default":                     "default",
+		"ignition_identity_provider.default":                "default",
+		"ignition_user_source.opcua_module":                 "opcua-module",
+		"ignition_opc_ua_connection.ignition_opc_ua_server": "Ignition OPC UA Server",
+		"ignition_gan_settings.gateway_network_settings":    "gateway-network-settings",
+		"ignition_redundancy.gateway_redundancy":            "gateway-redundancy",
+		"ignition_jdbc_driver.mysql":                        "MySQL",
+		"ignition_database_translator.sqlite":               "SQLITE",
+	} {
+		if labels[address] != id {
+			t.Errorf("Expected %s to import %q, got %q", address, id, labels[address])
+		}
+	}
+
+	files, diags := Files(context.Background(), resources)
+	if diags.HasError() {
+		t.Fatalf("Files failed: %v", diags)
+	}
+	var variables string
+	for _, f := range files {
+		if f.Name == "variables.tf" {
+			variables = string(f.Content)
+		}
+	}
+	// The OPC UA connection is the only resource in the backup with a secret
+	if got := strings.Count(variables, "variable "); got != 1 || !strings.Contains(variables, `variable "opc_ua_connection_ignition_opc_ua_server_password"`) {
+		t.Errorf("Expected only the OPC UA connection password to be a variable, got:\n%s", variables)
+	}
+}
+
+func TestAssignLabels(t *testing.T) {
+	resources := []Resource{
+		{TypeName: "ignition_project", ID: "Line 1"},
+		{TypeName: "ignition_project", ID: "line-1"},
+		{TypeName: "ignition_project", ID: "2nd"},
+		{TypeName: "ignition_tag_provider", ID: "Line 1"},
+	}
+	assignLabels(resources)
+
+	want := []string{"line_1", "line_1_2", "r_2nd", "line_1"}
+	for i, res := range resources {
+		if res.Label != want[i] {
+			t.Errorf("Expected label %q for %q, got %q", want[i], res.ID, res.Label)
+		}
+	}
+
+	// Suffixed labels do not collide with the labels other names sanitize to
+	resources = []Resource{
+		{TypeName: "ignition_database_connection", ID: "db"},
+		{TypeName: "ignition_database_connection", ID: "DB"},
+		{TypeName: "ignition_database_connection", ID: "db 2"},
+		{TypeName: "ignition_database_connection", ID: "db-2"},
+		{TypeName: "ignition_database_connection", ID: "db_2"},
+	}
+	assignLabels(resources)
+
+	want = []string{"db", "db_2", "db_2_2", "db_2_3", "db_2_4"}
+	labels := make(map[string]bool)
+	for i, res := range resources {
+		if res.Label != want[i] {
+			t.Errorf("Expected label %q for %q, got %q", want[i], res.ID, res.Label)
+		}
+		if labels[res.Label] {
+			t.Errorf("Duplicate label %q", res.Label)
+		}
+		labels[res.Label] = true
+	}
+}
@@ -0,0 +1,405 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/jsontypes"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// renderer renders resources as HCL. It replaces names of other exported
+// resources with references to them and collects a variable for every secret.
+type renderer struct {
+	// addresses maps module/type/name to the name attribute of the exported resource
+	addresses map[string]hcl.Traversal
+	variables []variable
+}
+
+// variable is an input variable supplying a secret that cannot be exported
+type variable struct {
+	name    string
+	address string
+	path    string
+}
+
+func newRenderer(resources []Resource) *renderer {
+	r := &renderer{addresses: make(map[string]hcl.Traversal, len(resources))}
+	for _, res := range resources {
+		r.addresses[res.Module+"/"+res.ResourceType+"/"+res.ID] = hcl.Traversal{
+			hcl.TraverseRoot{Name: res.TypeName},
+			hcl.TraverseAttr{Name: res.Label},
+			hcl.TraverseAttr{Name: "name"},
+		}
+	}
+	return r
+}
+
+// resourceRenderer renders the attributes of a single resource
+type resourceRenderer struct {
+	*renderer
+	res        Resource
+	references map[string]base.Reference
+}
+
+// writeResource renders the configurable attributes and blocks of a listed resource
+func (r *renderer) writeResource(ctx context.Context, body *hclwrite.Body, res Resource) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	s, ok := res.result.Resource.Schema.(schema.Schema)
+	if !ok {
+		diags.AddError("Unsupported Schema", fmt.Sprintf("Unexpected schema type %T for %q.", res.result.Resource.Schema, res.ID))
+		return diags
+	}
+
+	var values map[string]tftypes.Value
+	if err := res.result.Resource.Raw.As(&values); err != nil {
+		diags.AddError("Error reading resource", fmt.Sprintf("Unable to read %q: %s", res.ID, err))
+		return diags
+	}
+
+	rr := &resourceRenderer{renderer: r, res: res, references: make(map[string]base.Reference, len(res.references))}
+	for _, ref := range res.references {
+		rr.references[ref.Path.String()] = ref
+	}
+
+	if err := rr.writeBody(body, "", s.Attributes, s.Blocks, values); err != nil {
+		diags.AddError("Error rendering resource", fmt.Sprintf("Unable to render %q: %s", res.ID, err))
+	}
+	return diags
+}
+
+func (r *resourceRenderer) writeBody(body *hclwrite.Body, prefix string, attrs map[string]schema.Attribute, blocks map[string]schema.Block, values map[string]tftypes.Value) error {
+	for _, name := range attributeOrder(attrs) {
+		tokens, ok, err := r.attributeTokens(prefix+name, attrs[name], values[name])
+		if err != nil {
+			return fmt.Errorf("%s%s: %w", prefix, name, err)
+		}
+		if ok {
+			body.SetAttributeRaw(name, tokens)
+		}
+	}
+
+	for _, name := range sortedKeys(blocks) {
+		v, ok := values[name]
+		if !ok || v.IsNull() || !v.IsKnown() {
+			continue
+		}
+
+		var nestedAttrs map[string]schema.Attribute
+		var nestedBlocks map[string]schema.Block
+		var objects []tftypes.Value
+		switch b := blocks[name].(type) {
+		case schema.SingleNestedBlock:
+			nestedAttrs, nestedBlocks = b.Attributes, b.Blocks
+			objects = []tftypes.Value{v}
+		case schema.ListNestedBlock:
+			nestedAttrs, nestedBlocks = b.NestedObject.Attributes, b.NestedObject.Blocks
+			if err := v.As(&objects); err != nil {
+				return fmt.Errorf("%s%s: %w", prefix, name, err)
+			}
+		case schema.SetNestedBlock:
+			nestedAttrs, nestedBlocks = b.NestedObject.Attributes, b.NestedObject.Blocks
+			if err := v.As(&objects); err != nil {
+				return fmt.Errorf("%s%s: %w", prefix, name, err)
+			}
+		default:
+			return fmt.Errorf("%s%s: unsupported block type %T", prefix, name, b)
+		}
+
+		for _, obj := range objects {
+			var nested map[string]tftypes.Value
+			if err := obj.As(&nested); err != nil {
+				return fmt.Errorf("%s%s: %w", prefix, name, err)
+			}
+			block := body.AppendNewBlock(name, nil)
+			if err := r.writeBody(block.Body(), prefix+name+".", nestedAttrs, nestedBlocks, nested); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// attributeTokens renders an attribute value. It reports false for attributes
+// that are left out of the configuration: computed-only attributes and unset values.
+func (r *resourceRenderer) attributeTokens(attrPath string, a schema.Attribute, v tftypes.Value) (hclwrite.Tokens, bool, error) {
+	if !a.IsRequired() && !a.IsOptional() {
+		return nil, false, nil
+	}
+
+	// The gateway never returns secrets in plain text, so those set on the
+	// gateway are read from a variable and the rest are left out
+	if a.IsSensitive() {
+		if !r.res.secrets[attrPath] {
+			return nil, false, nil
+		}
+		return hclwrite.TokensForTraversal(r.secret(attrPath)), true, nil
+	}
+
+	if v.IsNull() || !v.IsKnown() {
+		return nil, false, nil
+	}
+
+	if ref, ok := r.references[attrPath]; ok {
+		var name string
+		if err := v.As(&name); err != nil {
+			return nil, false, err
+		}
+		if target, ok := r.addresses[ref.Module+"/"+ref.ResourceType+"/"+name]; ok {
+			return hclwrite.TokensForTraversal(target), true, nil
+		}
+	}
+
+	var tokens hclwrite.Tokens
+	var err error
+	switch a := a.(type) {
+	case schema.StringAttribute:
+		if _, ok := a.CustomType.(jsontypes.NormalizedType); ok {
+			tokens, err = jsonTokens(v)
+			return tokens, err == nil, err
+		}
+	case schema.SingleNestedAttribute:
+		tokens, err = r.objectTokens(attrPath+".", a.Attributes, v)
+		return tokens, err == nil, err
+	case schema.ListNestedAttribute:
+		tokens, err = r.tupleTokens(attrPath+".", a.NestedObject.Attributes, v)
+		return tokens, err == nil, err
+	case schema.SetNestedAttribute:
+		tokens, err = r.tupleTokens(attrPath+".", a.NestedObject.Attributes, v)
+		return tokens, err == nil, err
+	}
+
+	val, err := ctyValue(v)
+	if err != nil {
+		return nil, false, err
+	}
+	return hclwrite.TokensForValue(val), true, nil
+}
+
+func (r *resourceRenderer) objectTokens(prefix string, attrs map[string]schema.Attribute, v tftypes.Value) (hclwrite.Tokens, error) {
+	var values map[string]tftypes.Value
+	if err := v.As(&values); err != nil {
+		return nil, err
+	}
+
+	var items []hclwrite.ObjectAttrTokens
+	for _, name := range attributeOrder(attrs) {
+		tokens, ok, err := r.attributeTokens(prefix+name, attrs[name], values[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if ok {
+			items = append(items, hclwrite.ObjectAttrTokens{Name: hclwrite.TokensForIdentifier(name), Value: tokens})
+		}
+	}
+	return hclwrite.TokensForObject(items), nil
+}
+
+func (r *resourceRenderer) tupleTokens(prefix string, attrs map[string]schema.Attribute, v tftypes.Value) (hclwrite.Tokens, error) {
+	var elems []tftypes.Value
+	if err := v.As(&elems); err != nil {
+		return nil, err
+	}
+
+	items := make([]hclwrite.Tokens, 0, len(elems))
+	for _, elem := range elems {
+		tokens, err := r.objectTokens(prefix, attrs, elem)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, tokens)
+	}
+	return hclwrite.TokensForTuple(items), nil
+}
+
+// secret declares the variable holding a sensitive attribute and returns a reference to it
+func (r *resourceRenderer) secret(attrPath string) hcl.Traversal {
+	name := strings.TrimPrefix(r.res.TypeName, ProviderTypeName+"_") + "_" + r.res.Label + "_" + strings.ReplaceAll(attrPath, ".", "_")
+	r.variables = append(r.variables, variable{
+		name:    name,
+		address: r.res.TypeName + "." + r.res.Label,
+		path:    attrPath,
+	})
+	return hcl.Traversal{hcl.TraverseRoot{Name: "var"}, hcl.TraverseAttr{Name: name}}
+}
+
+// variablesFile declares the secret variables. They default to null, so that
+// the configuration validates before the secrets are supplied.
+func (r *renderer) variablesFile() *hclwrite.File {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	for i, v := range r.variables {
+		if i > 0 {
+			body.AppendNewline()
+		}
+		block := body.AppendNewBlock("variable", []string{v.name})
+		block.Body().SetAttributeValue("description", cty.StringVal(fmt.Sprintf("The %s of %s", v.path, v.address)))
+		block.Body().SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+		block.Body().SetAttributeValue("sensitive", cty.True)
+		block.Body().SetAttributeValue("default", cty.NullVal(cty.String))
+	}
+	return f
+}
+
+// jsonTokens renders a JSON string as a jsonencode() call so it stays readable
+func jsonTokens(v tftypes.Value) (hclwrite.Tokens, error) {
+	var s string
+	if err := v.As(&s); err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return hclwrite.TokensForValue(cty.StringVal(s)), nil
+	}
+
+	val, err := jsonValue(doc)
+	if err != nil {
+		return nil, err
+	}
+	return hclwrite.TokensForFunctionCall("jsonencode", hclwrite.TokensForValue(val)), nil
+}
+
+func jsonValue(doc any) (cty.Value, error) {
+	switch doc := doc.(type) {
+	case nil:
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	case bool:
+		return cty.BoolVal(doc), nil
+	case string:
+		return cty.StringVal(doc), nil
+	case json.Number:
+		return cty.ParseNumberVal(doc.String())
+	case []any:
+		if len(doc) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		elems := make([]cty.Value, 0, len(doc))
+		for _, e := range doc {
+			val, err := jsonValue(e)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elems = append(elems, val)
+		}
+		return cty.TupleVal(elems), nil
+	case map[string]any:
+		if len(doc) == 0 {
+			return cty.EmptyObjectVal, nil
+		}
+		attrs := make(map[string]cty.Value, len(doc))
+		for k, e := range doc {
+			val, err := jsonValue(e)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			attrs[k] = val
+		}
+		return cty.ObjectVal(attrs), nil
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported JSON value %T", doc)
+	}
+}
+
+// ctyValue converts a Terraform value into the equivalent HCL value. Lists and
+// sets become tuples and maps become objects, which render identically.
+func ctyValue(v tftypes.Value) (cty.Value, error) {
+	if v.IsNull() {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		if err := v.As(&s); err != nil {
+			return cty.NilVal, err
+		}
+		return cty.StringVal(s), nil
+	case typ.Is(tftypes.Number):
+		var n big.Float
+		if err := v.As(&n); err != nil {
+			return cty.NilVal, err
+		}
+		return cty.NumberVal(&n), nil
+	case typ.Is(tftypes.Bool):
+		var b bool
+		if err := v.As(&b); err != nil {
+			return cty.NilVal, err
+		}
+		return cty.BoolVal(b), nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return cty.NilVal, err
+		}
+		if len(elems) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		vals := make([]cty.Value, 0, len(elems))
+		for _, e := range elems {
+			val, err := ctyValue(e)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			vals = append(vals, val)
+		}
+		return cty.TupleVal(vals), nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil {
+			return cty.NilVal, err
+		}
+		if len(attrs) == 0 {
+			return cty.EmptyObjectVal, nil
+		}
+		vals := make(map[string]cty.Value, len(attrs))
+		for k, e := range attrs {
+			val, err := ctyValue(e)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			vals[k] = val
+		}
+		return cty.ObjectVal(vals), nil
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported value type %s", typ)
+	}
+}
+
+// attributeOrder returns attribute names with name first and the rest sorted
+func attributeOrder(attrs map[string]schema.Attribute) []string {
+	names := sortedKeys(attrs)
+	for i, name := range names {
+		if name == "name" {
+			copy(names[1:i+1], names[:i])
+			names[0] = "name"
+			break
+		}
+	}
+	return names
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
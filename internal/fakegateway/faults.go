@@ -0,0 +1,86 @@
+package fakegateway
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault describes a failure injected into matching requests
+type Fault struct {
+	// Method restricts the fault to one HTTP method. Any method matches when empty.
+	Method string
+	// PathPrefix restricts the fault to request paths with this prefix. Any path
+	// matches when empty.
+	PathPrefix string
+	// Status is the HTTP status returned (e.g., 503 or 409)
+	Status int
+	// Message is the problem message returned with non-503 statuses
+	Message string
+	// Count is the number of requests to fail. The fault is permanent when zero.
+	Count int
+}
+
+// InjectFault fails matching requests until the fault is exhausted. Faults are
+// matched in the order they were injected.
+func (g *Gateway) InjectFault(f Fault) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.faults = append(g.faults, &f)
+}
+
+// ClearFaults removes every injected fault
+func (g *Gateway) ClearFaults() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.faults = nil
+}
+
+// Restart simulates a gateway restart: every request is answered with 503
+// Service Unavailable until the given duration has elapsed. Stored
+// configuration survives the restart.
+func (g *Gateway) Restart(d time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.restartUntil = time.Now().Add(d)
+}
+
+// matchFault returns the first fault matching the request and consumes one of
+// its remaining failures. The caller must hold g.mu.
+func (g *Gateway) matchFault(r *http.Request) *Fault {
+	for i, f := range g.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.PathPrefix) {
+			continue
+		}
+
+		matched := *f
+		if f.Count > 0 {
+			f.Count--
+			if f.Count == 0 {
+				g.faults = append(g.faults[:i], g.faults[i+1:]...)
+			}
+		}
+		return &matched
+	}
+	return nil
+}
+
+func writeFault(w http.ResponseWriter, f *Fault) {
+	if f.Status == http.StatusServiceUnavailable {
+		w.WriteHeader(f.Status)
+		_, _ = w.Write([]byte("Service Unavailable"))
+		return
+	}
+
+	message := f.Message
+	if message == "" {
+		message = http.StatusText(f.Status)
+	}
+	writeError(w, f.Status, "%s", message)
+}
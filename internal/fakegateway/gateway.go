@@ -0,0 +1,382 @@
+// Package fakegateway provides an in-process fake of the Ignition Gateway REST
+// API for unit and acceptance tests. It keeps all configuration in memory,
+// issues and validates resource signatures and can inject faults such as
+// restarts and conflicts.
+package fakegateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+)
+
+// DefaultToken is the API token accepted by a Gateway created with New
+const DefaultToken = "fake:token"
+
+// Resource is a resource as stored by the fake gateway. The config is kept as
+// raw JSON so that it round-trips exactly as the provider sent it.
+type Resource = client.ResourceResponse[json.RawMessage]
+
+// Gateway is an in-memory Ignition Gateway served over HTTP
+type Gateway struct {
+	// URL is the base URL of the gateway (e.g., http://127.0.0.1:12345)
+	URL string
+	// Token is the API token required in the X-Ignition-API-Token header
+	Token string
+
+	server *httptest.Server
+
+	mu           sync.Mutex
+	resources    map[string]map[string]*Resource
+	files        map[string]map[string][]byte
+	projects     map[string]client.Project
+	modes        map[string]client.DeploymentMode
+	users        map[string]map[string]client.UserSourceUser
+	roles        map[string]map[string]bool
+	trusted      map[string]string
+	schemas      map[string]json.RawMessage
+	redundancy   client.RedundancyConfig
+	faults       []*Fault
+	restartUntil time.Time
+	revision     int
+	requests     []string
+}
+
+// New starts a fake gateway seeded with the singleton resources every
+// gateway has. Call Close when done.
+func New() *Gateway {
+	g := &Gateway{
+		Token:     DefaultToken,
+		resources: make(map[string]map[string]*Resource),
+		files:     make(map[string]map[string][]byte),
+		projects:  make(map[string]client.Project),
+		modes:     make(map[string]client.DeploymentMode),
+		users:     make(map[string]map[string]client.UserSourceUser),
+		roles:     make(map[string]map[string]bool),
+		trusted:   make(map[string]string),
+		schemas:   make(map[string]json.RawMessage),
+		redundancy: client.RedundancyConfig{
+			Role:               "Independent",
+			ActiveHistoryLevel: "Full",
+			JoinWaitTime:       30000,
+			RecoveryMode:       "Automatic",
+		},
+	}
+
+	g.PutResource("ignition", "gateway-network-settings", Resource{
+		Name: "gateway-network-settings",
+		Config: json.RawMessage(`{"requireSSL":true,"requireTwoWayAuth":false,"allowIncoming":true,` +
+			`"securityPolicy":"ApprovedOnly","websocketSessionIdleTimeout":30000,"tempFilesMaxAgeHours":24}`),
+	})
+
+	g.server = httptest.NewServer(g.handler())
+	g.URL = g.server.URL
+	return g
+}
+
+// Close shuts down the gateway's HTTP server
+func (g *Gateway) Close() {
+	g.server.Close()
+}
+
+// PutResource stores a resource under the given module and resource type in
+// res.Collection (core when empty), replacing any existing resource with the
+// same name in that collection, and returns its new signature.
+func (g *Gateway) PutResource(module, resourceType string, res Resource) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.storeAt(module, resourceType, res)
+}
+
+// GetResource returns a copy of the resource stored in the core collection, if any
+func (g *Gateway) GetResource(module, resourceType, name string) (Resource, bool) {
+	return g.GetCollectionResource(client.DefaultCollection, module, resourceType, name)
+}
+
+// GetCollectionResource returns a copy of the resource stored in the given collection, if any
+func (g *Gateway) GetCollectionResource(collection, module, resourceType, name string) (Resource, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	res, ok := g.resources[collectionKey(collection, module, resourceType)][name]
+	if !ok {
+		return Resource{}, false
+	}
+	return *res, true
+}
+
+// ResourceFile returns the data file uploaded for a resource in the core
+// collection, if any
+func (g *Gateway) ResourceFile(module, resourceType, name, filename string) ([]byte, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	data, ok := g.files[collectionKey(client.DefaultCollection, module, resourceType)+"/"+name][filename]
+	return data, ok
+}
+
+// User returns a copy of a user of the given user source as stored, password
+// included, if any
+func (g *Gateway) User(userSource, username string) (client.UserSourceUser, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	u, ok := g.users[userSource][username]
+	return u, ok
+}
+
+// TrustedCertificate returns the PEM certificate with the given thumbprint in
+// the OPC UA client trust store, if any
+func (g *Gateway) TrustedCertificate(thumbprint string) (string, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	cert, ok := g.trusted[thumbprint]
+	return cert, ok
+}
+
+// Touch simulates a change made outside of Terraform (e.g., in the web UI) by
+// issuing a new signature for the resource in the core collection. Updates and
+// deletes that use the previous signature will then fail with a conflict.
+func (g *Gateway) Touch(module, resourceType, name string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	res, ok := g.resources[collectionKey(client.DefaultCollection, module, resourceType)][name]
+	if !ok {
+		return false
+	}
+	res.Signature = g.sign(res)
+	return true
+}
+
+// PutDeploymentMode stores a deployment mode, replacing any existing mode with the same name
+func (g *Gateway) PutDeploymentMode(m client.DeploymentMode) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.modes[m.Name] = m
+}
+
+// PutResourceTypeSchema publishes the JSON schema of the config of a resource
+// type in the gateway's OpenAPI document
+func (g *Gateway) PutResourceTypeSchema(module, resourceType string, schema json.RawMessage) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.schemas[key(module, resourceType)] = schema
+}
+
+// PutProject stores a project, replacing any existing project with the same name
+func (g *Gateway) PutProject(p client.Project) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.projects[p.Name] = p
+}
+
+// GetProject returns the stored project, if any
+func (g *Gateway) GetProject(name string) (client.Project, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	p, ok := g.projects[name]
+	return p, ok
+}
+
+// Redundancy returns the current redundancy configuration
+func (g *Gateway) Redundancy() client.RedundancyConfig {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.redundancy
+}
+
+// PutRedundancy replaces the redundancy configuration
+func (g *Gateway) PutRedundancy(cfg client.RedundancyConfig) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.redundancy = cfg
+}
+
+// Requests returns every request received so far as "METHOD /path"
+func (g *Gateway) Requests() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return append([]string(nil), g.requests...)
+}
+
+// storeAt stores the resource in res.Collection (core when empty) and returns
+// its new signature. The caller must hold g.mu.
+func (g *Gateway) storeAt(module, resourceType string, res Resource) string {
+	if res.Collection == "" {
+		res.Collection = client.DefaultCollection
+	}
+	k := collectionKey(res.Collection, module, resourceType)
+	if g.resources[k] == nil {
+		g.resources[k] = make(map[string]*Resource)
+	}
+	if res.Module == "" {
+		res.Module = module
+	}
+	if res.Type == "" {
+		res.Type = resourceType
+	}
+	if res.Enabled == nil {
+		enabled := true
+		res.Enabled = &enabled
+	}
+	if len(res.Config) == 0 {
+		res.Config = json.RawMessage(`{}`)
+	}
+	res.Signature = g.sign(&res)
+	g.resources[k][res.Name] = &res
+	return res.Signature
+}
+
+// sign returns a new signature for the resource. Signatures change on every
+// write, even when the content is unchanged, as they do on a real gateway.
+func (g *Gateway) sign(res *Resource) string {
+	g.revision++
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s/%s/%s/%d/", res.Module, res.Type, res.Name, g.revision)
+	_, _ = h.Write(res.Config)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (g *Gateway) sortedResources(collection, module, resourceType string) []*Resource {
+	k := collectionKey(collection, module, resourceType)
+	items := make([]*Resource, 0, len(g.resources[k]))
+	for _, res := range g.resources[k] {
+		items = append(items, res)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items
+}
+
+// effectiveResources returns the resources of a type as the gateway sees them:
+// for each name, the one from the most derived collection. The fake has no
+// active deployment mode, so resources in other collections (e.g., modes)
+// override core, and local overrides everything.
+func (g *Gateway) effectiveResources(module, resourceType string) []*Resource {
+	rank := func(collection string) int {
+		switch collection {
+		case "external":
+			return 0
+		case client.DefaultCollection:
+			return 1
+		case "local":
+			return 3
+		default:
+			return 2
+		}
+	}
+
+	effective := make(map[string]*Resource)
+	for k, items := range g.resources {
+		collection, ok := strings.CutSuffix(k, ":"+key(module, resourceType))
+		if !ok {
+			continue
+		}
+		for name, res := range items {
+			prev, ok := effective[name]
+			if !ok || rank(collection) > rank(prev.Collection) ||
+				(rank(collection) == rank(prev.Collection) && collection < prev.Collection) {
+				effective[name] = res
+			}
+		}
+	}
+
+	items := make([]*Resource, 0, len(effective))
+	for _, res := range effective {
+		items = append(items, res)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items
+}
+
+func sortProjects(projects []client.Project) {
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+}
+
+func key(module, resourceType string) string {
+	return module + "/" + resourceType
+}
+
+// collectionKey addresses the resources of one type in one collection
+func collectionKey(collection, module, resourceType string) string {
+	return collection + ":" + key(module, resourceType)
+}
+
+// handler wraps the API routes with authentication, request logging and fault injection
+func (g *Gateway) handler() http.Handler {
+	mux := g.routes()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.mu.Lock()
+		g.requests = append(g.requests, r.Method+" "+r.URL.Path)
+		restarting := time.Now().Before(g.restartUntil)
+		fault := g.matchFault(r)
+		g.mu.Unlock()
+
+		if restarting {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("Gateway is restarting"))
+			return
+		}
+		if fault != nil {
+			writeFault(w, fault)
+			return
+		}
+
+		if r.Header.Get("X-Ignition-API-Token") != g.Token {
+			writeError(w, http.StatusUnauthorized, "Invalid or missing API token")
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an APIErrorResponse with the given problem message
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	var resp client.APIErrorResponse
+	resp.Problem = &struct {
+		Message    string   `json:"message"`
+		StackTrace []string `json:"stacktrace,omitempty"`
+	}{Message: fmt.Sprintf(format, args...)}
+	writeJSON(w, status, resp)
+}
+
+// writeFieldError writes an APIErrorResponse describing an invalid field
+func writeFieldError(w http.ResponseWriter, field, message string) {
+	var resp client.APIErrorResponse
+	resp.Messages = []string{"Validation failed"}
+	resp.FieldMessages = append(resp.FieldMessages, struct {
+		FieldName string   `json:"fieldName"`
+		Messages  []string `json:"messages"`
+	}{FieldName: field, Messages: []string{message}})
+	resp.Problem = &struct {
+		Message    string   `json:"message"`
+		StackTrace []string `json:"stacktrace,omitempty"`
+	}{Message: "Validation failed"}
+	writeJSON(w, http.StatusBadRequest, resp)
+}
@@ -0,0 +1,434 @@
+package fakegateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+)
+
+func newTestClient(t *testing.T, g *Gateway) *client.Client {
+	t.Helper()
+
+	c, err := client.NewClient(g.URL, g.Token, false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.HTTPClient.RetryWaitMin = 10 * time.Millisecond
+	c.HTTPClient.RetryWaitMax = 50 * time.Millisecond
+	return c
+}
+
+func TestGateway_ResourceLifecycle(t *testing.T) {
+	g := New()
+	defer g.Close()
+	c := newTestClient(t, g)
+	ctx := context.Background()
+
+	created, err := c.CreateTagProvider(ctx, client.ResourceResponse[client.TagProviderConfig]{
+		Name:   "plant",
+		Config: client.TagProviderConfig{Profile: client.TagProviderProfile{Type: "STANDARD"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateTagProvider failed: %v", err)
+	}
+	if created.Signature == "" {
+		t.Fatal("Expected a signature to be issued on create")
+	}
+	if created.Config.Profile.Type != "STANDARD" {
+		t.Errorf("Expected profile type STANDARD, got %q", created.Config.Profile.Type)
+	}
+
+	if _, err := c.CreateTagProvider(ctx, *created); err == nil {
+		t.Error("Expected a conflict creating a duplicate resource")
+	}
+
+	created.Description = "Updated"
+	updated, err := c.UpdateTagProvider(ctx, *created)
+	if err != nil {
+		t.Fatalf("UpdateTagProvider failed: %v", err)
+	}
+	if updated.Signature == created.Signature {
+		t.Error("Expected a new signature after update")
+	}
+
+	// The original signature is now stale
+	if _, err := c.UpdateTagProvider(ctx, *created); err == nil || !strings.Contains(err.Error(), "Signature mismatch") {
+		t.Errorf("Expected signature mismatch, got %v", err)
+	}
+	if err := c.DeleteTagProvider(ctx, "plant", created.Signature); err == nil {
+		t.Error("Expected delete with a stale signature to fail")
+	}
+
+	if err := c.RenameResourceWithModule(ctx, "ignition", "tag-provider", "plant", "site", updated.Signature); err != nil {
+		t.Fatalf("RenameResourceWithModule failed: %v", err)
+	}
+	renamed, err := c.GetTagProvider(ctx, "site")
+	if err != nil {
+		t.Fatalf("GetTagProvider after rename failed: %v", err)
+	}
+	if _, ok := g.GetResource("ignition", "tag-provider", "plant"); ok {
+		t.Error("Expected the old name to be gone after rename")
+	}
+
+	if err := c.DeleteTagProvider(ctx, "site", renamed.Signature); err != nil {
+		t.Fatalf("DeleteTagProvider failed: %v", err)
+	}
+
+	var apiErr *client.APIErrorResponse
+	if _, err := c.GetTagProvider(ctx, "site"); !errors.As(err, &apiErr) {
+		t.Errorf("Expected an APIErrorResponse for a deleted resource, got %v", err)
+	}
+}
+
+func TestGateway_Touch(t *testing.T) {
+	g := New()
+	defer g.Close()
+	c := newTestClient(t, g)
+	ctx := context.Background()
+
+	g.PutResource("ignition", "database-connection", Resource{Name: "mes"})
+	db, err := c.GetDatabaseConnection(ctx, "mes")
+	if err != nil {
+		t.Fatalf("GetDatabaseConnection failed: %v", err)
+	}
+
+	g.Touch("ignition", "database-connection", "mes")
+	if _, err := c.UpdateDatabaseConnection(ctx, *db); err == nil {
+		t.Error("Expected a conflict after an out-of-band change")
+	}
+}
+
+func TestGateway_ListResources(t *testing.T) {
+	g := New()
+	defer g.Close()
+	c := newTestClient(t, g)
+
+	for _, name := range []string{"b", "a", "c"} {
+		g.PutResource("ignition", "user-source", Resource{Name: name})
+	}
+
+	items, err := c.ListResourcesWithModule(context.Background(), "ignition", "user-source")
+	if err != nil {
+		t.Fatalf("ListResourcesWithModule failed: %v", err)
+	}
+	if len(items) != 3 || items[0].Name != "a" || items[2].Name != "c" {
+		t.Errorf("Unexpected items: %+v", items)
+	}
+}
+
+func TestGateway_Singletons(t *testing.T) {
+	g := New()
+	defer g.Close()
+	c := newTestClient(t, g)
+	ctx := context.Background()
+
+	settings, err := c.GetGanGeneralSettings(ctx)
+	if err != nil {
+		t.Fatalf("GetGanGeneralSettings failed: %v", err)
+	}
+	settings.Config.RequireSSL = false
+	settings.Signature = ""
+	if _, err := c.UpdateGanGeneralSettings(ctx, *settings); err != nil {
+		t.Fatalf("UpdateGanGeneralSettings failed: %v", err)
+	}
+
+	if err := c.UpdateRedundancyConfig(ctx, client.RedundancyConfig{Role: "Master"}); err != nil {
+		t.Fatalf("UpdateRedundancyConfig failed: %v", err)
+	}
+	if g.Redundancy().Role != "Master" {
+		t.Errorf("Expected redundancy role Master, got %q", g.Redundancy().Role)
+	}
+}
+
+func TestGateway_Projects(t *testing.T) {
+	g := New()
+	defer g.Close()
+	c := newTestClient(t, g)
+	ctx := context.Background()
+
+	if _, err := c.CreateProject(ctx, client.Project{Name: "global", Enabled: true}); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if _, err := c.CreateProject(ctx, client.Project{Name: "hmi", Parent: "global"}); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+
+	if _, err := c.RenameProject(ctx, "global", "base"); err != nil {
+		t.Fatalf("RenameProject failed: %v", err)
+	}
+	if p, _ := g.GetProject("hmi"); p.Parent != "base" {
+		t.Errorf("Expected child project to follow renamed parent, got %q", p.Parent)
+	}
+
+	projects, err := c.ListProjects(ctx)
+	if err != nil {
+		t.Fatalf("ListProjects failed: %v", err)
+	}
+	if len(projects) != 2 {
+		t.Errorf("Expected 2 projects, got %d", len(projects))
+	}
+}
+
+func TestGateway_DeploymentModes(t *testing.T) {
+	g := New()
+	defer g.Close()
+	c := newTestClient(t, g)
+	ctx := context.Background()
+	dev := client.WithCollection(ctx, "dev")
+
+	// Resources cannot be written to a collection that does not exist
+	item := client.ResourceResponse[client.TagProviderConfig]{
+		Name:   "plant",
+		Config: client.TagProviderConfig{Profile: client.TagProviderProfile{Type: "STANDARD"}},
+	}
+	if _, err := c.CreateTagProvider(dev, item); err == nil {
+		t.Fatal("Expected an error creating a resource in an unknown collection")
+	}
+
+	if _, err := c.CreateDeploymentMode(ctx, client.DeploymentMode{Name: "dev", Title: "Development", Enabled: true}); err != nil {
+		t.Fatalf("CreateDeploymentMode failed: %v", err)
+	}
+	if _, err := c.CreateTagProvider(ctx, item); err != nil {
+		t.Fatalf("CreateTagProvider in core failed: %v", err)
+	}
+	item.Description = "Development override"
+	override, err := c.CreateTagProvider(dev, item)
+	if err != nil {
+		t.Fatalf("CreateTagProvider in dev failed: %v", err)
+	}
+	if override.Collection != "dev" || override.Description != "Development override" {
+		t.Errorf("Expected the dev override, got %+v", override)
+	}
+
+	if core, _ := g.GetResource("ignition", "tag-provider", "plant"); core.Description != "" {
+		t.Errorf("Expected the core resource to be unchanged, got %q", core.Description)
+	}
+	if effective, err := c.GetTagProvider(ctx, "plant"); err != nil || effective.Collection != "dev" {
+		t.Errorf("Expected the dev override to be effective, got %+v (%v)", effective, err)
+	}
+
+	if err := c.DeleteTagProvider(dev, "plant", override.Signature); err != nil {
+		t.Fatalf("DeleteTagProvider in dev failed: %v", err)
+	}
+	if _, ok := g.GetCollectionResource("dev", "ignition", "tag-provider", "plant"); ok {
+		t.Error("Expected the dev override to be deleted")
+	}
+	if _, ok := g.GetResource("ignition", "tag-provider", "plant"); !ok {
+		t.Error("Expected the core resource to remain")
+	}
+
+	modes, err := c.ListDeploymentModes(ctx)
+	if err != nil || len(modes) != 1 || modes[0].Title != "Development" {
+		t.Fatalf("Unexpected deployment modes: %+v (%v)", modes, err)
+	}
+	if err := c.DeleteDeploymentMode(ctx, "dev"); err != nil {
+		t.Fatalf("DeleteDeploymentMode failed: %v", err)
+	}
+	if _, err := c.GetDeploymentMode(ctx, "dev"); err == nil {
+		t.Error("Expected the deployment mode to be deleted")
+	}
+}
+
+func TestGateway_UserSourceUsers(t *testing.T) {
+	g := New()
+	defer g.Close()
+	c := newTestClient(t, g)
+	ctx := context.Background()
+
+	if _, err := c.CreateUserSourceRole(ctx, "default", client.UserSourceRole{Name: "Operator"}); err == nil {
+		t.Fatal("Expected an error creating a role in an unknown user source")
+	}
+	source := client.ResourceResponse[client.UserSourceConfig]{
+		Name:   "default",
+		Config: client.UserSourceConfig{Profile: client.UserSourceProfile{Type: "INTERNAL"}},
+	}
+	if _, err := c.CreateUserSource(ctx, source); err != nil {
+		t.Fatalf("CreateUserSource failed: %v", err)
+	}
+
+	user := client.UserSourceUser{Username: "jdoe", FirstName: "Jane", Roles: []string{"Operator"}, Password: "secret"}
+	if _, err := c.CreateUserSourceUser(ctx, "default", user); err == nil {
+		t.Fatal("Expected an error assigning an unknown role")
+	}
+	if _, err := c.CreateUserSourceRole(ctx, "default", client.UserSourceRole{Name: "Operator"}); err != nil {
+		t.Fatalf("CreateUserSourceRole failed: %v", err)
+	}
+	created, err := c.CreateUserSourceUser(ctx, "default", user)
+	if err != nil {
+		t.Fatalf("CreateUserSourceUser failed: %v", err)
+	}
+	if created.Password != "" || len(created.Roles) != 1 {
+		t.Errorf("Expected the user without its password, got %+v", created)
+	}
+
+	// Updates without a password keep the current one
+	user.Password = ""
+	user.LastName = "Doe"
+	if _, err := c.UpdateUserSourceUser(ctx, "default", user); err != nil {
+		t.Fatalf("UpdateUserSourceUser failed: %v", err)
+	}
+	if stored, _ := g.User("default", "jdoe"); stored.Password != "secret" || stored.LastName != "Doe" {
+		t.Errorf("Unexpected stored user: %+v", stored)
+	}
+
+	// Deleting a role takes it away from its users
+	if err := c.DeleteUserSourceRole(ctx, "default", "Operator"); err != nil {
+		t.Fatalf("DeleteUserSourceRole failed: %v", err)
+	}
+	if u, err := c.GetUserSourceUser(ctx, "default", "jdoe"); err != nil || len(u.Roles) != 0 {
+		t.Errorf("Expected the user to have no roles, got %+v (%v)", u, err)
+	}
+
+	if err := c.DeleteUserSourceUser(ctx, "default", "jdoe"); err != nil {
+		t.Fatalf("DeleteUserSourceUser failed: %v", err)
+	}
+	if _, err := c.GetUserSourceUser(ctx, "default", "jdoe"); err == nil {
+		t.Error("Expected the user to be deleted")
+	}
+}
+
+func TestGateway_ResourceTypeSchemas(t *testing.T) {
+	g := New()
+	defer g.Close()
+	c := newTestClient(t, g)
+
+	g.PutResourceTypeSchema("com.inductiveautomation.opcua", "ModbusTcp",
+		json.RawMessage(`{"type":"object","properties":{"hostname":{"type":"string"},"port":{"type":"integer"}}}`))
+
+	schema, err := c.GetResourceTypeSchema(context.Background(), "com.inductiveautomation.opcua", "ModbusTcp")
+	if err != nil {
+		t.Fatalf("GetResourceTypeSchema failed: %v", err)
+	}
+	violations, err := schema.Validate(map[string]any{"hostname": "plc", "prot": 502})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Error() != `prot: unknown key, did you mean "port"?` {
+		t.Errorf("Expected a misspelled port key, got %v", violations)
+	}
+}
+
+func TestGateway_Faults(t *testing.T) {
+	g := New()
+	defer g.Close()
+	c := newTestClient(t, g)
+	ctx := context.Background()
+
+	// Transient 503s are retried by the client
+	g.InjectFault(Fault{PathPrefix: "/data/api/v1/resources/find", Status: http.StatusServiceUnavailable, Count: 2})
+	if _, err := c.GetGanGeneralSettings(ctx); err != nil {
+		t.Fatalf("Expected retries to succeed, got %v", err)
+	}
+
+	g.Restart(100 * time.Millisecond)
+	if _, err := c.GetGanGeneralSettings(ctx); err != nil {
+		t.Fatalf("Expected request to succeed after restart, got %v", err)
+	}
+
+	g.InjectFault(Fault{Method: http.MethodPost, Status: http.StatusConflict, Message: "Resource is locked", Count: 1})
+	if _, err := c.CreateTagProvider(ctx, client.ResourceResponse[client.TagProviderConfig]{Name: "plant"}); err == nil || !strings.Contains(err.Error(), "Resource is locked") {
+		t.Errorf("Expected injected conflict, got %v", err)
+	}
+	if _, err := c.CreateTagProvider(ctx, client.ResourceResponse[client.TagProviderConfig]{Name: "plant"}); err != nil {
+		t.Errorf("Expected fault to be exhausted, got %v", err)
+	}
+}
+
+func TestGateway_Authentication(t *testing.T) {
+	g := New()
+	defer g.Close()
+
+	c, err := client.NewClient(g.URL, "wrong-token", false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := c.GetProject(context.Background(), "any"); err == nil || !strings.Contains(err.Error(), "API token") {
+		t.Errorf("Expected an authentication error, got %v", err)
+	}
+}
+
+func TestGateway_Encrypt(t *testing.T) {
+	g := New()
+	defer g.Close()
+	c := newTestClient(t, g)
+
+	secret, err := c.EncryptSecret(context.Background(), "hunter2")
+	if err != nil {
+		t.Fatalf("EncryptSecret failed: %v", err)
+	}
+	data, ok := secret.Data.(map[string]any)
+	if secret.Type != "Embedded" || !ok || data["ciphertext"] == "" {
+		t.Errorf("Unexpected secret: %+v", secret)
+	}
+}
+
+func TestGateway_UploadResourceFile(t *testing.T) {
+	g := New()
+	defer g.Close()
+	c := newTestClient(t, g)
+	ctx := context.Background()
+
+	if err := c.UploadResourceFile(ctx, "ignition", "database-driver", "DB2", "db2.jar", "", []byte("jar")); err == nil {
+		t.Fatal("Expected an error uploading a file of an unknown resource")
+	}
+	signature := g.PutResource("ignition", "database-driver", Resource{Name: "DB2"})
+	if err := c.UploadResourceFile(ctx, "ignition", "database-driver", "DB2", "db2.jar", "stale", []byte("jar")); err == nil {
+		t.Fatal("Expected a signature mismatch")
+	}
+	if err := c.UploadResourceFile(ctx, "ignition", "database-driver", "DB2", "db2.jar", signature, []byte("jar")); err != nil {
+		t.Fatalf("UploadResourceFile failed: %v", err)
+	}
+
+	if data, ok := g.ResourceFile("ignition", "database-driver", "DB2", "db2.jar"); !ok || string(data) != "jar" {
+		t.Errorf("Expected the uploaded file, got %q", data)
+	}
+	res, _ := g.GetResource("ignition", "database-driver", "DB2")
+	if res.Signature == signature {
+		t.Error("Expected a new signature after the upload")
+	}
+
+	if err := c.DeleteResourceFile(ctx, "ignition", "database-driver", "DB2", "db2.jar", signature); err == nil {
+		t.Fatal("Expected a signature mismatch")
+	}
+	if err := c.DeleteResourceFile(ctx, "ignition", "database-driver", "DB2", "db2.jar", res.Signature); err != nil {
+		t.Fatalf("DeleteResourceFile failed: %v", err)
+	}
+	if _, ok := g.ResourceFile("ignition", "database-driver", "DB2", "db2.jar"); ok {
+		t.Error("Expected the file to be deleted")
+	}
+}
+
+func TestGateway_OpcUaTrustedCertificates(t *testing.T) {
+	g := New()
+	defer g.Close()
+	c := newTestClient(t, g)
+	ctx := context.Background()
+
+	if err := c.TrustOpcUaCertificate(ctx, client.OpcUaTrustedCertificate{Thumbprint: "ab12"}); err == nil {
+		t.Fatal("Expected an error trusting an empty certificate")
+	}
+	cert := client.OpcUaTrustedCertificate{Thumbprint: "ab12", Certificate: "-----BEGIN CERTIFICATE-----"}
+	if err := c.TrustOpcUaCertificate(ctx, cert); err != nil {
+		t.Fatalf("TrustOpcUaCertificate failed: %v", err)
+	}
+	if got, ok := g.TrustedCertificate("ab12"); !ok || got != cert.Certificate {
+		t.Errorf("Expected the certificate to be trusted, got %q", got)
+	}
+
+	if err := c.DeleteOpcUaTrustedCertificate(ctx, "ab12"); err != nil {
+		t.Fatalf("DeleteOpcUaTrustedCertificate failed: %v", err)
+	}
+	if _, ok := g.TrustedCertificate("ab12"); ok {
+		t.Error("Expected the certificate to be removed")
+	}
+	if err := c.DeleteOpcUaTrustedCertificate(ctx, "ab12"); err == nil {
+		t.Error("Expected an error removing an unknown certificate")
+	}
+}
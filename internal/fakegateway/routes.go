@@ -0,0 +1,874 @@
+package fakegateway
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+)
+
+const apiPrefix = "/data/api/v1"
+
+// singletons are the resource types that always exist exactly once and may be
+// updated without a signature
+var singletons = map[string]bool{
+	key("ignition", "gateway-network-settings"): true,
+}
+
+func (g *Gateway) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+apiPrefix+"/resources/find/{module}/{type}", g.findResource)
+	mux.HandleFunc("GET "+apiPrefix+"/resources/find/{module}/{type}/{name}", g.findResource)
+	mux.HandleFunc("GET "+apiPrefix+"/resources/list/{module}/{type}", g.listResources)
+	mux.HandleFunc("POST "+apiPrefix+"/resources/{module}/{type}", g.createResources)
+	mux.HandleFunc("PUT "+apiPrefix+"/resources/{module}/{type}", g.updateResources)
+	mux.HandleFunc("DELETE "+apiPrefix+"/resources/{module}/{type}/{name}/{signature}", g.deleteResource)
+	mux.HandleFunc("POST "+apiPrefix+"/resources/rename/{module}/{type}", g.renameResources)
+	mux.HandleFunc("PUT "+apiPrefix+"/resources/datafile/{module}/{type}/{name}/{filename}", g.uploadResourceFile)
+	mux.HandleFunc("DELETE "+apiPrefix+"/resources/datafile/{module}/{type}/{name}/{filename}", g.deleteResourceFile)
+
+	mux.HandleFunc("GET "+apiPrefix+"/projects", g.listProjects)
+	mux.HandleFunc("GET "+apiPrefix+"/projects/list", g.listProjects)
+	mux.HandleFunc("GET "+apiPrefix+"/projects/find/{name}", g.findProject)
+	mux.HandleFunc("POST "+apiPrefix+"/projects", g.createProject)
+	mux.HandleFunc("PUT "+apiPrefix+"/projects/{name}", g.updateProject)
+	mux.HandleFunc("DELETE "+apiPrefix+"/projects/{name}", g.deleteProject)
+	mux.HandleFunc("POST "+apiPrefix+"/projects/rename/{name}", g.renameProject)
+
+	mux.HandleFunc("GET "+apiPrefix+"/modes/list", g.listModes)
+	mux.HandleFunc("GET "+apiPrefix+"/modes/find/{name}", g.findMode)
+	mux.HandleFunc("POST "+apiPrefix+"/modes", g.createMode)
+	mux.HandleFunc("PUT "+apiPrefix+"/modes/{name}", g.updateMode)
+	mux.HandleFunc("DELETE "+apiPrefix+"/modes/{name}", g.deleteMode)
+
+	mux.HandleFunc("GET "+apiPrefix+"/user-sources/{source}/users/find/{name}", g.findUser)
+	mux.HandleFunc("POST "+apiPrefix+"/user-sources/{source}/users", g.createUser)
+	mux.HandleFunc("PUT "+apiPrefix+"/user-sources/{source}/users/{name}", g.updateUser)
+	mux.HandleFunc("DELETE "+apiPrefix+"/user-sources/{source}/users/{name}", g.deleteUser)
+	mux.HandleFunc("GET "+apiPrefix+"/user-sources/{source}/roles/find/{name}", g.findRole)
+	mux.HandleFunc("POST "+apiPrefix+"/user-sources/{source}/roles", g.createRole)
+	mux.HandleFunc("DELETE "+apiPrefix+"/user-sources/{source}/roles/{name}", g.deleteRole)
+
+	mux.HandleFunc("PUT "+apiPrefix+"/opcua/client/trusted-certificates/{thumbprint}", g.trustCertificate)
+	mux.HandleFunc("DELETE "+apiPrefix+"/opcua/client/trusted-certificates/{thumbprint}", g.deleteTrustedCertificate)
+
+	mux.HandleFunc("GET "+apiPrefix+"/redundancy/config", g.getRedundancy)
+	mux.HandleFunc("POST "+apiPrefix+"/redundancy/config", g.setRedundancy)
+
+	mux.HandleFunc("POST "+apiPrefix+"/encryption/encrypt", g.encrypt)
+
+	mux.HandleFunc("GET /openapi", g.openAPI)
+
+	return mux
+}
+
+// builtinCollections are the collections every gateway has, in addition to its deployment modes
+var builtinCollections = map[string]bool{
+	"external":               true,
+	client.DefaultCollection: true,
+	"local":                  true,
+}
+
+// collection returns the collection addressed by the request's collection
+// query parameter. Without one, writes go to core and reads see the effective
+// configuration (see effectiveResources). It writes an error and returns false
+// when the collection does not exist. The caller must hold g.mu.
+func (g *Gateway) collection(w http.ResponseWriter, r *http.Request) (string, bool) {
+	collection := r.URL.Query().Get("collection")
+	if collection == "" {
+		return client.DefaultCollection, true
+	}
+	if _, ok := g.modes[collection]; !ok && !builtinCollections[collection] {
+		writeFieldError(w, "collection", "Unknown collection: "+collection)
+		return "", false
+	}
+	return collection, true
+}
+
+func (g *Gateway) findResource(w http.ResponseWriter, r *http.Request) {
+	module, resourceType, name := r.PathValue("module"), r.PathValue("type"), r.PathValue("name")
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	collection, ok := g.collection(w, r)
+	if !ok {
+		return
+	}
+
+	items := g.sortedResources(collection, module, resourceType)
+	if !r.URL.Query().Has("collection") {
+		items = g.effectiveResources(module, resourceType)
+	}
+
+	var res *Resource
+	for _, item := range items {
+		// Singletons are found without a name
+		if name == "" || item.Name == name {
+			res = item
+			break
+		}
+	}
+
+	if res == nil {
+		writeError(w, http.StatusNotFound, "Resource not found: %s/%s/%s", module, resourceType, name)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (g *Gateway) listResources(w http.ResponseWriter, r *http.Request) {
+	module, resourceType := r.PathValue("module"), r.PathValue("type")
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	collection, ok := g.collection(w, r)
+	if !ok {
+		return
+	}
+	items := g.sortedResources(collection, module, resourceType)
+	if !r.URL.Query().Has("collection") {
+		items = g.effectiveResources(module, resourceType)
+	}
+
+	var page client.ResourceListResponse[client.ResourceListItem]
+	page.Items = []client.ResourceListItem{}
+	page.Metadata.Total = len(items)
+	page.Metadata.Matching = len(items)
+	page.Metadata.Limit = limit
+	page.Metadata.Offset = offset
+
+	for i := offset; i < len(items) && (limit <= 0 || i < offset+limit); i++ {
+		page.Items = append(page.Items, client.ResourceListItem{
+			Name:        items[i].Name,
+			Collection:  items[i].Collection,
+			Enabled:     items[i].Enabled,
+			Description: items[i].Description,
+			Signature:   items[i].Signature,
+		})
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (g *Gateway) createResources(w http.ResponseWriter, r *http.Request) {
+	g.writeResources(w, r, false)
+}
+
+func (g *Gateway) updateResources(w http.ResponseWriter, r *http.Request) {
+	g.writeResources(w, r, true)
+}
+
+// writeResources applies a batch of creates or updates. The batch is validated
+// in full before any change is stored, so a failed batch leaves no changes.
+func (g *Gateway) writeResources(w http.ResponseWriter, r *http.Request, update bool) {
+	module, resourceType := r.PathValue("module"), r.PathValue("type")
+
+	var items []Resource
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	collection, ok := g.collection(w, r)
+	if !ok {
+		return
+	}
+
+	k := collectionKey(collection, module, resourceType)
+	for _, item := range items {
+		if item.Name == "" {
+			writeFieldError(w, "name", "A name is required.")
+			return
+		}
+
+		existing, exists := g.resources[k][item.Name]
+		switch {
+		case !update && exists:
+			writeError(w, http.StatusConflict, "Resource already exists: %s/%s/%s", module, resourceType, item.Name)
+			return
+		case update && !exists:
+			writeError(w, http.StatusNotFound, "Resource not found: %s/%s/%s", module, resourceType, item.Name)
+			return
+		case update && item.Signature == "" && !singletons[key(module, resourceType)]:
+			writeFieldError(w, "signature", "A signature is required to modify a resource.")
+			return
+		case update && item.Signature != "" && item.Signature != existing.Signature:
+			writeError(w, http.StatusConflict, "Signature mismatch: %s/%s/%s was modified by another user", module, resourceType, item.Name)
+			return
+		}
+	}
+
+	changes := client.ResourceChangesResponse{Success: true}
+	for _, item := range items {
+		if update && item.Type == "" {
+			// Keep the stored type (e.g., a device driver) when not resent
+			item.Type = g.resources[k][item.Name].Type
+		}
+		item.Module = module
+		item.Collection = collection
+		if item.Type == "" {
+			item.Type = resourceType
+		}
+
+		signature := g.storeAt(module, resourceType, item)
+		changes.Changes = append(changes.Changes, struct {
+			Name         string `json:"name"`
+			Type         string `json:"type"`
+			Collection   string `json:"collection"`
+			NewSignature string `json:"newSignature"`
+		}{Name: item.Name, Type: resourceType, Collection: collection, NewSignature: signature})
+	}
+	writeJSON(w, http.StatusOK, changes)
+}
+
+func (g *Gateway) deleteResource(w http.ResponseWriter, r *http.Request) {
+	module, resourceType := r.PathValue("module"), r.PathValue("type")
+	name, signature := r.PathValue("name"), r.PathValue("signature")
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	collection, ok := g.collection(w, r)
+	if !ok {
+		return
+	}
+
+	k := collectionKey(collection, module, resourceType)
+	existing, ok := g.resources[k][name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Resource not found: %s/%s/%s", module, resourceType, name)
+		return
+	}
+	if existing.Signature != signature {
+		writeError(w, http.StatusConflict, "Signature mismatch: %s/%s/%s was modified by another user", module, resourceType, name)
+		return
+	}
+
+	delete(g.resources[k], name)
+	delete(g.files, k+"/"+name)
+	writeJSON(w, http.StatusOK, client.ResourceChangesResponse{Success: true})
+}
+
+func (g *Gateway) renameResources(w http.ResponseWriter, r *http.Request) {
+	module, resourceType := r.PathValue("module"), r.PathValue("type")
+
+	var renames []client.ResourceRename
+	if err := json.NewDecoder(r.Body).Decode(&renames); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	collection, ok := g.collection(w, r)
+	if !ok {
+		return
+	}
+
+	k := collectionKey(collection, module, resourceType)
+	for _, rename := range renames {
+		existing, ok := g.resources[k][rename.Name]
+		switch {
+		case !ok:
+			writeError(w, http.StatusNotFound, "Resource not found: %s/%s/%s", module, resourceType, rename.Name)
+			return
+		case rename.NewName == "":
+			writeFieldError(w, "newName", "A new name is required.")
+			return
+		case g.resources[k][rename.NewName] != nil:
+			writeError(w, http.StatusConflict, "Resource already exists: %s/%s/%s", module, resourceType, rename.NewName)
+			return
+		case existing.Signature != rename.Signature:
+			writeError(w, http.StatusConflict, "Signature mismatch: %s/%s/%s was modified by another user", module, resourceType, rename.Name)
+			return
+		}
+	}
+
+	for _, rename := range renames {
+		res := *g.resources[k][rename.Name]
+		delete(g.resources[k], rename.Name)
+		res.Name = rename.NewName
+		g.storeAt(module, resourceType, res)
+		if files, ok := g.files[k+"/"+rename.Name]; ok {
+			delete(g.files, k+"/"+rename.Name)
+			g.files[k+"/"+rename.NewName] = files
+		}
+	}
+	writeJSON(w, http.StatusOK, client.ResourceChangesResponse{Success: true})
+}
+
+// uploadResourceFile stores a data file of a resource. Like any other change,
+// it issues the resource a new signature.
+func (g *Gateway) uploadResourceFile(w http.ResponseWriter, r *http.Request) {
+	module, resourceType := r.PathValue("module"), r.PathValue("type")
+	name, filename := r.PathValue("name"), r.PathValue("filename")
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	collection, ok := g.collection(w, r)
+	if !ok {
+		return
+	}
+
+	k := collectionKey(collection, module, resourceType)
+	existing, ok := g.resources[k][name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Resource not found: %s/%s/%s", module, resourceType, name)
+		return
+	}
+	if existing.Signature != r.URL.Query().Get("signature") {
+		writeError(w, http.StatusConflict, "Signature mismatch: %s/%s/%s was modified by another user", module, resourceType, name)
+		return
+	}
+
+	if g.files[k+"/"+name] == nil {
+		g.files[k+"/"+name] = make(map[string][]byte)
+	}
+	g.files[k+"/"+name][filename] = data
+	existing.Signature = g.sign(existing)
+	writeJSON(w, http.StatusOK, client.ResourceChangesResponse{Success: true})
+}
+
+// deleteResourceFile removes a data file of a resource and issues the
+// resource a new signature
+func (g *Gateway) deleteResourceFile(w http.ResponseWriter, r *http.Request) {
+	module, resourceType := r.PathValue("module"), r.PathValue("type")
+	name, filename := r.PathValue("name"), r.PathValue("filename")
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	collection, ok := g.collection(w, r)
+	if !ok {
+		return
+	}
+
+	k := collectionKey(collection, module, resourceType)
+	existing, ok := g.resources[k][name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Resource not found: %s/%s/%s", module, resourceType, name)
+		return
+	}
+	if existing.Signature != r.URL.Query().Get("signature") {
+		writeError(w, http.StatusConflict, "Signature mismatch: %s/%s/%s was modified by another user", module, resourceType, name)
+		return
+	}
+
+	delete(g.files[k+"/"+name], filename)
+	existing.Signature = g.sign(existing)
+	writeJSON(w, http.StatusOK, client.ResourceChangesResponse{Success: true})
+}
+
+func (g *Gateway) listProjects(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	projects := make([]client.Project, 0, len(g.projects))
+	for _, p := range g.projects {
+		projects = append(projects, p)
+	}
+	sortProjects(projects)
+	writeJSON(w, http.StatusOK, projects)
+}
+
+func (g *Gateway) findProject(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	p, ok := g.projects[r.PathValue("name")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Project not found: %s", r.PathValue("name"))
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (g *Gateway) createProject(w http.ResponseWriter, r *http.Request) {
+	var p client.Project
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+	if p.Name == "" {
+		writeFieldError(w, "name", "A project name is required.")
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.projects[p.Name]; exists {
+		writeError(w, http.StatusConflict, "Project already exists: %s", p.Name)
+		return
+	}
+	if p.Parent != "" {
+		if _, ok := g.projects[p.Parent]; !ok {
+			writeFieldError(w, "parent", "Parent project does not exist.")
+			return
+		}
+	}
+
+	g.projects[p.Name] = p
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (g *Gateway) updateProject(w http.ResponseWriter, r *http.Request) {
+	var p client.Project
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	name := r.PathValue("name")
+	if _, ok := g.projects[name]; !ok {
+		writeError(w, http.StatusNotFound, "Project not found: %s", name)
+		return
+	}
+
+	p.Name = name
+	g.projects[name] = p
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (g *Gateway) deleteProject(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	name := r.PathValue("name")
+	if _, ok := g.projects[name]; !ok {
+		writeError(w, http.StatusNotFound, "Project not found: %s", name)
+		return
+	}
+
+	delete(g.projects, name)
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (g *Gateway) renameProject(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+	if body.Name == "" {
+		writeFieldError(w, "name", "A new project name is required.")
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	name := r.PathValue("name")
+	p, ok := g.projects[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Project not found: %s", name)
+		return
+	}
+	if _, exists := g.projects[body.Name]; exists {
+		writeError(w, http.StatusConflict, "Project already exists: %s", body.Name)
+		return
+	}
+
+	delete(g.projects, name)
+	p.Name = body.Name
+	g.projects[p.Name] = p
+
+	// Child projects follow their renamed parent
+	for childName, child := range g.projects {
+		if child.Parent == name {
+			child.Parent = p.Name
+			g.projects[childName] = child
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (g *Gateway) listModes(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	modes := make([]client.DeploymentMode, 0, len(g.modes))
+	for _, m := range g.modes {
+		modes = append(modes, m)
+	}
+	sort.Slice(modes, func(i, j int) bool { return modes[i].Name < modes[j].Name })
+	writeJSON(w, http.StatusOK, modes)
+}
+
+func (g *Gateway) findMode(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	m, ok := g.modes[r.PathValue("name")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Deployment mode not found: %s", r.PathValue("name"))
+		return
+	}
+	writeJSON(w, http.StatusOK, m)
+}
+
+func (g *Gateway) createMode(w http.ResponseWriter, r *http.Request) {
+	var m client.DeploymentMode
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+	if m.Name == "" {
+		writeFieldError(w, "name", "A deployment mode name is required.")
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.modes[m.Name]; exists || builtinCollections[m.Name] {
+		writeError(w, http.StatusConflict, "Collection already exists: %s", m.Name)
+		return
+	}
+
+	g.modes[m.Name] = m
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (g *Gateway) updateMode(w http.ResponseWriter, r *http.Request) {
+	var m client.DeploymentMode
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	name := r.PathValue("name")
+	if _, ok := g.modes[name]; !ok {
+		writeError(w, http.StatusNotFound, "Deployment mode not found: %s", name)
+		return
+	}
+
+	m.Name = name
+	g.modes[name] = m
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+// deleteMode removes the mode together with the resources in its collection
+func (g *Gateway) deleteMode(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	name := r.PathValue("name")
+	if _, ok := g.modes[name]; !ok {
+		writeError(w, http.StatusNotFound, "Deployment mode not found: %s", name)
+		return
+	}
+
+	delete(g.modes, name)
+	for k := range g.resources {
+		if strings.HasPrefix(k, name+":") {
+			delete(g.resources, k)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+// userSource returns the name of the user source addressed by the request,
+// writing an error when it does not exist. g.mu must be held.
+func (g *Gateway) userSource(w http.ResponseWriter, r *http.Request) (string, bool) {
+	source := r.PathValue("source")
+	if _, ok := g.resources[collectionKey(client.DefaultCollection, "ignition", "user-source")][source]; !ok {
+		writeError(w, http.StatusNotFound, "User source not found: %s", source)
+		return "", false
+	}
+	return source, true
+}
+
+func (g *Gateway) findUser(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	source, ok := g.userSource(w, r)
+	if !ok {
+		return
+	}
+	u, ok := g.users[source][r.PathValue("name")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "User not found: %s", r.PathValue("name"))
+		return
+	}
+	// Passwords are never returned
+	u.Password = ""
+	writeJSON(w, http.StatusOK, u)
+}
+
+func (g *Gateway) createUser(w http.ResponseWriter, r *http.Request) {
+	g.writeUser(w, r, false)
+}
+
+func (g *Gateway) updateUser(w http.ResponseWriter, r *http.Request) {
+	g.writeUser(w, r, true)
+}
+
+// writeUser creates a user, or replaces the user named in the path, keeping
+// its password when the request has none
+func (g *Gateway) writeUser(w http.ResponseWriter, r *http.Request, update bool) {
+	var u client.UserSourceUser
+	if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	source, ok := g.userSource(w, r)
+	if !ok {
+		return
+	}
+	if update {
+		u.Username = r.PathValue("name")
+	}
+	existing, exists := g.users[source][u.Username]
+	if update {
+		if !exists {
+			writeError(w, http.StatusNotFound, "User not found: %s", u.Username)
+			return
+		}
+		if u.Password == "" {
+			u.Password = existing.Password
+		}
+	} else {
+		if u.Username == "" {
+			writeFieldError(w, "username", "A username is required.")
+			return
+		}
+		if exists {
+			writeError(w, http.StatusConflict, "User already exists: %s", u.Username)
+			return
+		}
+	}
+	for _, role := range u.Roles {
+		if !g.roles[source][role] {
+			writeFieldError(w, "roles", fmt.Sprintf("Unknown role: %s", role))
+			return
+		}
+	}
+
+	if g.users[source] == nil {
+		g.users[source] = make(map[string]client.UserSourceUser)
+	}
+	g.users[source][u.Username] = u
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (g *Gateway) deleteUser(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	source, ok := g.userSource(w, r)
+	if !ok {
+		return
+	}
+	name := r.PathValue("name")
+	if _, ok := g.users[source][name]; !ok {
+		writeError(w, http.StatusNotFound, "User not found: %s", name)
+		return
+	}
+	delete(g.users[source], name)
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (g *Gateway) findRole(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	source, ok := g.userSource(w, r)
+	if !ok {
+		return
+	}
+	name := r.PathValue("name")
+	if !g.roles[source][name] {
+		writeError(w, http.StatusNotFound, "Role not found: %s", name)
+		return
+	}
+	writeJSON(w, http.StatusOK, client.UserSourceRole{Name: name})
+}
+
+func (g *Gateway) createRole(w http.ResponseWriter, r *http.Request) {
+	var role client.UserSourceRole
+	if err := json.NewDecoder(r.Body).Decode(&role); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+	if role.Name == "" {
+		writeFieldError(w, "name", "A role name is required.")
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	source, ok := g.userSource(w, r)
+	if !ok {
+		return
+	}
+	if g.roles[source][role.Name] {
+		writeError(w, http.StatusConflict, "Role already exists: %s", role.Name)
+		return
+	}
+	if g.roles[source] == nil {
+		g.roles[source] = make(map[string]bool)
+	}
+	g.roles[source][role.Name] = true
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+// deleteRole removes the role, and takes it away from the users that had it
+func (g *Gateway) deleteRole(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	source, ok := g.userSource(w, r)
+	if !ok {
+		return
+	}
+	name := r.PathValue("name")
+	if !g.roles[source][name] {
+		writeError(w, http.StatusNotFound, "Role not found: %s", name)
+		return
+	}
+	delete(g.roles[source], name)
+	for username, u := range g.users[source] {
+		u.Roles = slices.DeleteFunc(u.Roles, func(role string) bool { return role == name })
+		g.users[source][username] = u
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (g *Gateway) trustCertificate(w http.ResponseWriter, r *http.Request) {
+	var cert client.OpcUaTrustedCertificate
+	if err := json.NewDecoder(r.Body).Decode(&cert); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+	if cert.Certificate == "" {
+		writeFieldError(w, "certificate", "A certificate is required.")
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.trusted[r.PathValue("thumbprint")] = cert.Certificate
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (g *Gateway) deleteTrustedCertificate(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	thumbprint := r.PathValue("thumbprint")
+	if _, ok := g.trusted[thumbprint]; !ok {
+		writeError(w, http.StatusNotFound, "Certificate not found: %s", thumbprint)
+		return
+	}
+	delete(g.trusted, thumbprint)
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func (g *Gateway) getRedundancy(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, g.redundancy)
+}
+
+func (g *Gateway) setRedundancy(w http.ResponseWriter, r *http.Request) {
+	var config client.RedundancyConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.redundancy = config
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+// encrypt returns a JWE-shaped document. The plaintext is only base64 encoded,
+// which is enough for tests to assert on what was encrypted.
+func (g *Gateway) encrypt(w http.ResponseWriter, r *http.Request) {
+	plaintext, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request body: %s", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"protected":  base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"dir","enc":"A256GCM"}`)),
+		"iv":         base64.RawURLEncoding.EncodeToString([]byte("fake-gateway")),
+		"ciphertext": base64.RawURLEncoding.EncodeToString(plaintext),
+		"tag":        base64.RawURLEncoding.EncodeToString([]byte("fake-tag")),
+	})
+}
+
+// openAPI serves an OpenAPI document describing the create endpoint of every
+// resource type with a published schema (see PutResourceTypeSchema)
+func (g *Gateway) openAPI(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	paths := make(map[string]any, len(g.schemas))
+	for k, schema := range g.schemas {
+		module, resourceType, _ := strings.Cut(k, "/")
+		paths[apiPrefix+"/resources/"+module+"/"+resourceType] = map[string]any{
+			"post": map[string]any{
+				"requestBody": map[string]any{
+					"content": map[string]any{
+						"application/json": map[string]any{
+							"schema": map[string]any{
+								"type": "array",
+								"items": map[string]any{
+									"type": "object",
+									"properties": map[string]any{
+										"name":   map[string]any{"type": "string"},
+										"config": schema,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"openapi": "3.0.1", "paths": paths})
+}
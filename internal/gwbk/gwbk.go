@@ -0,0 +1,372 @@
+// Package gwbk reads Ignition 8.3 gateway backups (.gwbk files) without a
+// running gateway. A backup is a zip archive holding the gateway's config
+// resources as JSON, which are decoded into the same models the REST client
+// uses.
+package gwbk
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+)
+
+const resourcesDir = "config/resources/"
+
+// Resource is a config resource read from a backup. The config is kept as raw
+// JSON in the shape the REST API uses; use Decode to convert it into one of
+// the client models.
+type Resource struct {
+	client.ResourceResponse[json.RawMessage]
+}
+
+// Backup is the configuration contained in a gateway backup
+type Backup struct {
+	// Version is the gateway version that created the backup
+	Version string
+	// Resources holds the effective config resources, sorted by module, type and name.
+	// When a resource is defined in several collections, the one from the most
+	// derived collection wins, as it does on the gateway.
+	Resources []Resource
+	// Projects holds the projects in the backup, sorted by name
+	Projects []client.Project
+	// Redundancy is the redundancy configuration, if the backup contains one
+	Redundancy *client.RedundancyConfig
+}
+
+// Open reads the backup at the given path
+func Open(name string) (*Backup, error) {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, fmt.Errorf("error opening gateway backup: %w", err)
+	}
+	defer zr.Close()
+
+	return read(&zr.Reader)
+}
+
+// Read reads a backup from r, which holds size bytes
+func Read(r io.ReaderAt, size int64) (*Backup, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("error opening gateway backup: %w", err)
+	}
+
+	return read(zr)
+}
+
+// Extract unpacks the configuration in the backup at the given path into dir,
+// laid out as a gateway data directory: the config resources, the projects and
+// redundancy.xml. The result can be read with client.NewFilesystemClient.
+func Extract(name, dir string) error {
+	zr, err := zip.OpenReader(name)
+	if err != nil {
+		return fmt.Errorf("error opening gateway backup: %w", err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if !dataDirEntry(f.Name) || f.FileInfo().IsDir() {
+			continue
+		}
+		rel := path.Clean(f.Name)
+		if !filepath.IsLocal(rel) {
+			return fmt.Errorf("gateway backup entry %s is outside the data directory", f.Name)
+		}
+		if err := extractFile(f, filepath.Join(dir, filepath.FromSlash(rel))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dataDirEntry reports whether the backup entry is part of the configuration
+// Extract unpacks
+func dataDirEntry(name string) bool {
+	return name == "redundancy.xml" || strings.HasPrefix(name, resourcesDir) || strings.HasPrefix(name, "projects/")
+}
+
+func extractFile(f *zip.File, dest string) error {
+	data, err := readAll(f)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("error extracting %s: %w", f.Name, err)
+	}
+	if err := os.WriteFile(dest, data, 0o644); err != nil {
+		return fmt.Errorf("error extracting %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// Find returns the resource with the given module, type and name, if any
+func (b *Backup) Find(module, resourceType, name string) (Resource, bool) {
+	for _, res := range b.Resources {
+		if res.Module == module && res.Type == resourceType && res.Name == name {
+			return res, true
+		}
+	}
+	return Resource{}, false
+}
+
+// Decode converts the raw resource config into the given client model
+func Decode[T any](res Resource) (*client.ResourceResponse[T], error) {
+	out := client.ResourceResponse[T]{
+		Module:      res.Module,
+		Type:        res.Type,
+		Name:        res.Name,
+		Collection:  res.Collection,
+		Enabled:     res.Enabled,
+		Description: res.Description,
+		Signature:   res.Signature,
+	}
+	if len(res.Config) > 0 {
+		if err := json.Unmarshal(res.Config, &out.Config); err != nil {
+			return nil, fmt.Errorf("error decoding %s/%s %q: %w", res.Module, res.Type, res.Name, err)
+		}
+	}
+	return &out, nil
+}
+
+// Resources decodes every resource of the given module and type into the given client model
+func Resources[T any](b *Backup, module, resourceType string) ([]client.ResourceResponse[T], error) {
+	var out []client.ResourceResponse[T]
+	for _, res := range b.Resources {
+		if res.Module != module || res.Type != resourceType {
+			continue
+		}
+		item, err := Decode[T](res)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *item)
+	}
+	return out, nil
+}
+
+// collection is the metadata in a collection's config-mode.json
+type collection struct {
+	Parent string `json:"parent"`
+}
+
+// resourceMeta is the metadata in a resource's resource.json
+type resourceMeta struct {
+	Description string `json:"description"`
+	Attributes  struct {
+		Enabled                   *bool  `json:"enabled"`
+		LastModificationSignature string `json:"lastModificationSignature"`
+	} `json:"attributes"`
+}
+
+// backupInfo is the content of backupinfo.xml
+type backupInfo struct {
+	Version string `xml:"version"`
+}
+
+func read(zr *zip.Reader) (*Backup, error) {
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	b := &Backup{}
+	collections := make(map[string]collection)
+	var candidates []Resource
+
+	for _, f := range zr.File {
+		switch {
+		case f.Name == "backupinfo.xml":
+			var info backupInfo
+			if err := readXML(f, &info); err != nil {
+				return nil, err
+			}
+			b.Version = strings.TrimSpace(info.Version)
+
+		case f.Name == "redundancy.xml":
+			cfg, err := readRedundancy(f)
+			if err != nil {
+				return nil, err
+			}
+			b.Redundancy = cfg
+
+		case strings.HasPrefix(f.Name, "projects/") && path.Base(f.Name) == "project.json":
+			parts := strings.Split(f.Name, "/")
+			if len(parts) != 3 {
+				continue
+			}
+			var p client.Project
+			if err := readJSON(f, &p); err != nil {
+				return nil, err
+			}
+			p.Name = parts[1]
+			b.Projects = append(b.Projects, p)
+
+		case strings.HasPrefix(f.Name, resourcesDir) && path.Base(f.Name) == "config-mode.json":
+			var c collection
+			if err := readJSON(f, &c); err != nil {
+				return nil, err
+			}
+			collections[path.Base(path.Dir(f.Name))] = c
+
+		case strings.HasPrefix(f.Name, resourcesDir) && path.Base(f.Name) == "resource.json":
+			res, ok, err := readResource(f, files)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				candidates = append(candidates, res)
+			}
+		}
+	}
+
+	// Resolve overrides only once every collection's parent is known
+	b.Resources = resolve(collections, candidates)
+
+	sort.Slice(b.Projects, func(i, j int) bool { return b.Projects[i].Name < b.Projects[j].Name })
+	return b, nil
+}
+
+// readResource reads the resource whose resource.json is f. Resources are
+// stored as config/resources/<collection>/<module>/<type>[/<name>]/resource.json;
+// singletons have no name directory and are named after their type.
+func readResource(f *zip.File, files map[string]*zip.File) (Resource, bool, error) {
+	dir := path.Dir(f.Name)
+	parts := strings.Split(strings.TrimPrefix(dir, resourcesDir), "/")
+	if len(parts) < 3 {
+		return Resource{}, false, nil
+	}
+
+	var res Resource
+	res.Collection = parts[0]
+	res.Module = parts[1]
+	res.Type = parts[2]
+	res.Name = res.Type
+	if len(parts) > 3 {
+		res.Name = strings.Join(parts[3:], "/")
+	}
+
+	var meta resourceMeta
+	if err := readJSON(f, &meta); err != nil {
+		return Resource{}, false, err
+	}
+	res.Description = meta.Description
+	res.Enabled = meta.Attributes.Enabled
+	res.Signature = meta.Attributes.LastModificationSignature
+
+	if cf, ok := files[dir+"/config.json"]; ok {
+		raw, err := readAll(cf)
+		if err != nil {
+			return Resource{}, false, err
+		}
+		res.Config = json.RawMessage(raw)
+	}
+
+	converted, err := client.APIConfig(res.Module, res.Type, res.Config)
+	if err != nil {
+		return Resource{}, false, fmt.Errorf("error converting %s/%s %q: %w", res.Module, res.Type, res.Name, err)
+	}
+	res.Config = converted
+
+	return res, true, nil
+}
+
+// resolve keeps the resource from the most derived collection for each module, type and name
+func resolve(collections map[string]collection, candidates []Resource) []Resource {
+	effective := make(map[string]Resource, len(candidates))
+	for _, res := range candidates {
+		k := res.Module + "/" + res.Type + "/" + res.Name
+		if prev, ok := effective[k]; !ok || depth(collections, res.Collection) > depth(collections, prev.Collection) {
+			effective[k] = res
+		}
+	}
+
+	out := make([]Resource, 0, len(effective))
+	for _, res := range effective {
+		out = append(out, res)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Module != b.Module {
+			return a.Module < b.Module
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+	return out
+}
+
+// depth returns how many parents a collection has. Collections that are not
+// described in the backup sort before all others.
+func depth(collections map[string]collection, name string) int {
+	d := 0
+	seen := make(map[string]bool)
+	for {
+		c, ok := collections[name]
+		if !ok || seen[name] {
+			return d
+		}
+		seen[name] = true
+		name = c.Parent
+		d++
+	}
+}
+
+// readRedundancy converts the redundancy.xml properties into a RedundancyConfig
+func readRedundancy(f *zip.File) (*client.RedundancyConfig, error) {
+	data, err := readAll(f)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := client.DecodeRedundancyProperties(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", f.Name, err)
+	}
+	return cfg, nil
+}
+
+func readAll(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", f.Name, err)
+	}
+	return data, nil
+}
+
+func readJSON(f *zip.File, v any) error {
+	data, err := readAll(f)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("error parsing %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+func readXML(f *zip.File, v any) error {
+	data, err := readAll(f)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("error parsing %s: %w", f.Name, err)
+	}
+	return nil
+}
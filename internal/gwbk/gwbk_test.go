@@ -0,0 +1,190 @@
+package gwbk
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+)
+
+func TestOpen_SampleBackup(t *testing.T) {
+	b, err := Open("../../assets/ignition.gwbk")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	if b.Version == "" {
+		t.Error("Expected the gateway version to be read from backupinfo.xml")
+	}
+
+	providers, err := Resources[client.TagProviderConfig](b, "ignition", "tag-provider")
+	if err != nil {
+		t.Fatalf("Resources failed: %v", err)
+	}
+	if len(providers) != 2 {
+		t.Fatalf("Expected 2 tag providers, got %d", len(providers))
+	}
+	if providers[0].Name != "System" || providers[1].Name != "default" {
+		t.Errorf("Unexpected tag providers: %q, %q", providers[0].Name, providers[1].Name)
+	}
+	if providers[1].Config.Profile.Type != "STANDARD" || providers[1].Description != "Default tag provider" {
+		t.Errorf("Unexpected default tag provider: %+v", providers[1])
+	}
+
+	idp, ok := b.Find("ignition", "identity-provider", "default")
+	if !ok {
+		t.Fatal("Expected the default identity provider")
+	}
+	if idp.Signature == "" || idp.Collection != "core" {
+		t.Errorf("Unexpected identity provider metadata: %+v", idp)
+	}
+	idpConfig, err := Decode[client.IdentityProviderConfig](idp)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	internal, ok := idpConfig.Config.Config.(map[string]any)
+	if idpConfig.Config.Type != "internal" || !ok || internal["userSource"] != "default" {
+		t.Errorf("Expected the stored identity provider to be converted to the API shape, got %+v", idpConfig.Config)
+	}
+
+	settings, ok := b.Find("ignition", "gateway-network-settings", "gateway-network-settings")
+	if !ok {
+		t.Fatal("Expected the singleton gateway network settings to be named after their type")
+	}
+	gan, err := Decode[client.GanGeneralSettingsConfig](settings)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if !gan.Config.RequireSSL || gan.Config.SecurityPolicy != "ApprovedOnly" {
+		t.Errorf("Unexpected gateway network settings: %+v", gan.Config)
+	}
+
+	if _, ok := b.Find("ignition", "tag-group", "default/Default"); !ok {
+		t.Error("Expected nested resource names to keep their path")
+	}
+
+	if b.Redundancy == nil {
+		t.Fatal("Expected redundancy settings")
+	}
+	if b.Redundancy.Role != "Independent" || b.Redundancy.JoinWaitTime != 30000 {
+		t.Errorf("Unexpected redundancy settings: %+v", b.Redundancy)
+	}
+	if b.Redundancy.GatewayNetworkSetup == nil || b.Redundancy.GatewayNetworkSetup.Port != 8060 {
+		t.Errorf("Unexpected redundancy gateway network settings: %+v", b.Redundancy.GatewayNetworkSetup)
+	}
+}
+
+func TestRead_CollectionsAndProjects(t *testing.T) {
+	files := map[string]string{
+		// Entries are written in map order, so resolution must not depend on archive order
+		"config/resources/local/ignition/database-connection/mes/resource.json":      `{"attributes": {"enabled": false}}`,
+		"config/resources/local/ignition/database-connection/mes/config.json":        `{"driver": "MariaDB", "connectURL": "jdbc:mariadb://local/mes"}`,
+		"config/resources/local/config-mode.json":                                    `{"parent": "core"}`,
+		"config/resources/core/config-mode.json":                                     `{"parent": "external"}`,
+		"config/resources/core/ignition/database-connection/mes/resource.json":       `{"description": "MES"}`,
+		"config/resources/core/ignition/database-connection/mes/config.json":         `{"driver": "MariaDB", "connectURL": "jdbc:mariadb://core/mes"}`,
+		"projects/hmi/project.json":                                                  `{"title": "HMI", "parent": "global", "enabled": true}`,
+		"projects/global/project.json":                                               `{"inheritable": true, "enabled": true}`,
+		"projects/hmi/com.inductiveautomation.perspective/page-config/resource.json": `{}`,
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		_, _ = w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to write archive: %v", err)
+	}
+
+	b, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+
+	dbs, err := Resources[client.DatabaseConfig](b, "ignition", "database-connection")
+	if err != nil {
+		t.Fatalf("Resources failed: %v", err)
+	}
+	if len(dbs) != 1 {
+		t.Fatalf("Expected overrides to be resolved to 1 connection, got %d", len(dbs))
+	}
+	if dbs[0].Config.ConnectURL != "jdbc:mariadb://local/mes" {
+		t.Errorf("Expected the local collection to win, got %q", dbs[0].Config.ConnectURL)
+	}
+	if dbs[0].Enabled == nil || *dbs[0].Enabled {
+		t.Error("Expected the local resource to be disabled")
+	}
+
+	if len(b.Projects) != 2 {
+		t.Fatalf("Expected 2 projects, got %d", len(b.Projects))
+	}
+	if b.Projects[0].Name != "global" || !b.Projects[0].Inheritable {
+		t.Errorf("Unexpected project: %+v", b.Projects[0])
+	}
+	if b.Projects[1].Name != "hmi" || b.Projects[1].Parent != "global" || b.Projects[1].Title != "HMI" {
+		t.Errorf("Unexpected project: %+v", b.Projects[1])
+	}
+	if b.Redundancy != nil {
+		t.Error("Expected no redundancy settings")
+	}
+}
+
+func TestExtract(t *testing.T) {
+	dir := t.TempDir()
+	if err := Extract("../../assets/ignition.gwbk", dir); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	c, err := client.NewFilesystemClient(dir)
+	if err != nil {
+		t.Fatalf("NewFilesystemClient failed: %v", err)
+	}
+	providers, err := c.ListResourcesWithModule(context.Background(), "ignition", "tag-provider")
+	if err != nil {
+		t.Fatalf("ListResourcesWithModule failed: %v", err)
+	}
+	if len(providers) != 2 {
+		t.Errorf("Expected 2 tag providers, got %d", len(providers))
+	}
+	if _, err := c.GetRedundancyConfig(context.Background()); err != nil {
+		t.Errorf("Expected redundancy.xml to be extracted: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "user-lib")); !os.IsNotExist(err) {
+		t.Error("Expected only the configuration to be extracted")
+	}
+}
+
+func TestExtract_RejectsEntriesOutsideDir(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "evil.gwbk")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("projects/../../escaped.json")
+	if err != nil {
+		t.Fatalf("Failed to create entry: %v", err)
+	}
+	_, _ = w.Write([]byte(`{}`))
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to write archive: %v", err)
+	}
+	_ = f.Close()
+
+	dir := t.TempDir()
+	if err := Extract(name, filepath.Join(dir, "data")); err == nil {
+		t.Error("Expected an entry outside the data directory to be rejected")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escaped.json")); !os.IsNotExist(err) {
+		t.Error("Expected nothing to be written outside the data directory")
+	}
+}
@@ -0,0 +1,78 @@
+package base
+
+import (
+	"context"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// CollectionResourceModel is embedded, alongside BaseResourceModel, by the
+// models of resources stored in a config collection. GenericIgnitionResource
+// directs every request for such a resource to its collection.
+type CollectionResourceModel struct {
+	Collection types.String `tfsdk:"collection"`
+}
+
+func (m *CollectionResourceModel) collectionModel() *CollectionResourceModel {
+	return m
+}
+
+// collectionModel is implemented by models that embed CollectionResourceModel
+type collectionModel interface {
+	collectionModel() *CollectionResourceModel
+}
+
+// CollectionAttribute returns the schema of the collection attribute of
+// CollectionResourceModel
+func CollectionAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "The config collection the resource is stored in: `core`, or the name of a deployment mode " +
+			"(see `ignition_deployment_mode`) whose configuration it overrides. Defaults to `core`.",
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(client.DefaultCollection),
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// WithCollection returns a context that directs the client requests made with
+// it to the collection held in data. Models without a collection, or with none
+// known yet (e.g., while importing), leave the choice to the gateway.
+func WithCollection(ctx context.Context, data any) context.Context {
+	m, ok := data.(collectionModel)
+	if !ok {
+		return ctx
+	}
+
+	collection := m.collectionModel().Collection
+	if collection.IsNull() || collection.IsUnknown() || collection.ValueString() == "" {
+		return ctx
+	}
+	return client.WithCollection(ctx, collection.ValueString())
+}
+
+// SetCollection records the collection a resource was read from in data, when
+// its model has a collection and none is known yet. Gateways that do not report
+// the collection are assumed to have used core.
+func SetCollection(data any, collection string) {
+	m, ok := data.(collectionModel)
+	if !ok {
+		return
+	}
+
+	model := m.collectionModel()
+	if !model.Collection.IsNull() && !model.Collection.IsUnknown() && model.Collection.ValueString() != "" {
+		return
+	}
+	if collection == "" {
+		collection = client.DefaultCollection
+	}
+	model.Collection = types.StringValue(collection)
+}
@@ -2,6 +2,7 @@ package base
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -37,6 +38,16 @@ type GenericIgnitionResource[T any, M any] struct {
 	GetFunc    func(context.Context, string) (*client.ResourceResponse[T], error)
 	UpdateFunc func(context.Context, client.ResourceResponse[T]) (*client.ResourceResponse[T], error)
 	DeleteFunc func(context.Context, string, string) error
+	// ListFunc returns the names of every resource of this type. When nil, the
+	// generic resource-listing endpoint is used for Module and ResourceType.
+	ListFunc func(context.Context) ([]string, error)
+	// RenameFunc renames a resource in place. When nil, the generic
+	// resource-rename endpoint is used for Module and ResourceType.
+	RenameFunc func(ctx context.Context, name, newName, signature string) error
+	// References is set when the provider is configured with validate_references
+	References *ReferenceValidator
+	// Schemas is set when the provider is configured with validate_schemas
+	Schemas *SchemaValidator
 }
 
 func (r *GenericIgnitionResource[T, M]) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse, data *M, baseModel *BaseResourceModel) {
@@ -44,6 +55,7 @@ func (r *GenericIgnitionResource[T, M]) Create(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = WithCollection(ctx, data)
 
 	config, err := r.Handler.MapPlanToClient(ctx, data)
 	if err != nil {
@@ -68,9 +80,11 @@ func (r *GenericIgnitionResource[T, M]) Create(ctx context.Context, req resource
 		resp.Diagnostics.AddError("Error creating resource", err.Error())
 		return
 	}
+	r.References.Forget(r.Module, r.ResourceType)
 
 	baseModel.Signature = types.StringValue(created.Signature)
 	baseModel.Id = types.StringValue(created.Name)
+	SetCollection(data, created.Collection)
 	if baseModel.Name.IsNull() || baseModel.Name.IsUnknown() || baseModel.Name.ValueString() == "" {
 		baseModel.Name = types.StringValue(created.Name)
 	}
@@ -90,6 +104,7 @@ func (r *GenericIgnitionResource[T, M]) Create(ctx context.Context, req resource
 		return
 	}
 
+	resp.Diagnostics.Append(r.SetIdentity(ctx, resp.Identity, baseModel.Name.ValueString())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
 
@@ -98,6 +113,7 @@ func (r *GenericIgnitionResource[T, M]) Read(ctx context.Context, req resource.R
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = WithCollection(ctx, data)
 
 	if baseModel.Name.ValueString() == "" {
 		return
@@ -114,6 +130,7 @@ func (r *GenericIgnitionResource[T, M]) Read(ctx context.Context, req resource.R
 	if baseModel.Name.IsNull() || baseModel.Name.IsUnknown() || baseModel.Name.ValueString() == "" {
 		baseModel.Name = types.StringValue(res.Name)
 	}
+	SetCollection(data, res.Collection)
 	if res.Enabled != nil {
 		baseModel.Enabled = types.BoolValue(*res.Enabled)
 	} else {
@@ -131,6 +148,7 @@ func (r *GenericIgnitionResource[T, M]) Read(ctx context.Context, req resource.R
 		return
 	}
 
+	resp.Diagnostics.Append(r.SetIdentity(ctx, resp.Identity, baseModel.Name.ValueString())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
 
@@ -139,6 +157,7 @@ func (r *GenericIgnitionResource[T, M]) Update(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = WithCollection(ctx, data)
 
 	// Retrieve existing signature from state
 	var sig types.String
@@ -147,6 +166,12 @@ func (r *GenericIgnitionResource[T, M]) Update(ctx context.Context, req resource
 		return
 	}
 
+	sig, diags := r.Rename(ctx, req, resp, baseModel.Name.ValueString(), sig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	config, err := r.Handler.MapPlanToClient(ctx, data)
 	if err != nil {
 		resp.Diagnostics.AddError("Error mapping plan to client", err.Error())
@@ -208,6 +233,7 @@ func (r *GenericIgnitionResource[T, M]) Update(ctx context.Context, req resource
 		return
 	}
 
+	resp.Diagnostics.Append(r.SetIdentity(ctx, resp.Identity, baseModel.Name.ValueString())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
 
@@ -216,12 +242,14 @@ func (r *GenericIgnitionResource[T, M]) Delete(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = WithCollection(ctx, data)
 
 	err := r.DeleteFunc(ctx, baseModel.Name.ValueString(), baseModel.Signature.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting resource", err.Error())
 		return
 	}
+	r.References.Forget(r.Module, r.ResourceType)
 }
 
 // StringToNullableString returns a types.StringNull if the input is empty,
@@ -236,3 +264,51 @@ func StringToNullableString(s string) types.String {
 func BoolPtr(b bool) *bool {
 	return &b
 }
+
+// EncodeSettings encodes the typed settings v into the free-form settings map
+// of a config, which must not be nil, overwriting the keys they have in common
+func EncodeSettings(settings map[string]any, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, &settings)
+}
+
+// DecodeSettings decodes the free-form settings map of a config into the typed
+// settings v. Fields without a key in settings are left as they are, so v can
+// hold the gateway's defaults.
+func DecodeSettings(settings map[string]any, v any) error {
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// Int64Pointer returns a pointer to the value of v, or nil when v is null or
+// unknown, so that the gateway keeps its default
+func Int64Pointer(v types.Int64) *int64 {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return v.ValueInt64Pointer()
+}
+
+// StringPointer returns a pointer to the value of v, or nil when v is null or
+// unknown, so that the gateway keeps its default
+func StringPointer(v types.String) *string {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return v.ValueStringPointer()
+}
+
+// BoolPointer returns a pointer to the value of v, or nil when v is null or
+// unknown, so that the gateway keeps its default
+func BoolPointer(v types.Bool) *bool {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return v.ValueBoolPointer()
+}
@@ -0,0 +1,101 @@
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ResourceIdentityModel describes the identity shared by every Ignition resource:
+// the gateway module, the resource type within that module and the resource name.
+type ResourceIdentityModel struct {
+	Module types.String `tfsdk:"module"`
+	Type   types.String `tfsdk:"type"`
+	Name   types.String `tfsdk:"name"`
+}
+
+// ResourceIdentitySchema returns the identity schema used by all Ignition resources
+func ResourceIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"module": identityschema.StringAttribute{
+				Description:       "The gateway module that owns the resource (e.g., ignition).",
+				RequiredForImport: true,
+			},
+			"type": identityschema.StringAttribute{
+				Description:       "The resource type within the module (e.g., database-connection).",
+				RequiredForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "The name of the resource, or the fixed key for singleton resources.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// SetIdentity records the identity of the named resource. It is a no-op when
+// the caller does not support identity (e.g., older Terraform versions).
+func (r *GenericIgnitionResource[T, M]) SetIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, name string) diag.Diagnostics {
+	return SetResourceIdentity(ctx, identity, r.Module, r.ResourceType, name)
+}
+
+// SetResourceIdentity records the identity of a resource of the given module
+// and type. It is a no-op when the caller does not support identity.
+func SetResourceIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, module, resourceType, name string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+
+	return identity.Set(ctx, ResourceIdentityModel{
+		Module: types.StringValue(module),
+		Type:   types.StringValue(resourceType),
+		Name:   types.StringValue(name),
+	})
+}
+
+// ImportName resolves the name of the resource being imported, either from the
+// import ID or from the identity of an import block. Identities that belong to a
+// different module or resource type are rejected.
+func (r *GenericIgnitionResource[T, M]) ImportName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) (string, bool) {
+	if req.ID != "" {
+		return req.ID, true
+	}
+
+	if req.Identity == nil {
+		resp.Diagnostics.AddError("Missing Import Identifier", "Either an import ID or a resource identity must be supplied.")
+		return "", false
+	}
+
+	var identity ResourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return "", false
+	}
+
+	if identity.Module.ValueString() != r.Module {
+		resp.Diagnostics.AddError(
+			"Unexpected Identity Module",
+			fmt.Sprintf("Expected module %q, got: %q.", r.Module, identity.Module.ValueString()),
+		)
+	}
+	if identity.Type.ValueString() != r.ResourceType {
+		resp.Diagnostics.AddError(
+			"Unexpected Identity Type",
+			fmt.Sprintf("Expected resource type %q, got: %q.", r.ResourceType, identity.Type.ValueString()),
+		)
+	}
+	if identity.Name.ValueString() == "" {
+		resp.Diagnostics.AddError("Missing Identity Name", "The resource identity must include a name.")
+	}
+	if resp.Diagnostics.HasError() {
+		return "", false
+	}
+
+	return identity.Name.ValueString(), true
+}
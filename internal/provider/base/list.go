@@ -0,0 +1,97 @@
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ListResourceConfigSchema returns the (empty) list configuration schema shared by all Ignition list resources
+func ListResourceConfigSchema(description string) listschema.Schema {
+	return listschema.Schema{
+		Description: description,
+	}
+}
+
+// ListNames returns the names of every resource of this type on the gateway
+func (r *GenericIgnitionResource[T, M]) ListNames(ctx context.Context) ([]string, error) {
+	if r.ListFunc != nil {
+		return r.ListFunc(ctx)
+	}
+
+	items, err := r.Client.ListResourcesWithModule(ctx, r.Module, r.ResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	return names, nil
+}
+
+// List streams a list result for every resource of this type. When the full
+// resource is requested, each item is read through GetFunc and newModel is called
+// with the response to build a fresh model and return its embedded BaseResourceModel.
+func (r *GenericIgnitionResource[T, M]) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream, newModel func(*client.ResourceResponse[T]) (*M, *BaseResourceModel)) {
+	names, err := r.ListNames(ctx)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error listing resources", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, name := range names {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = name
+			result.Diagnostics.Append(r.SetIdentity(ctx, result.Identity, name)...)
+
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				r.listResource(ctx, name, &result, newModel)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+func (r *GenericIgnitionResource[T, M]) listResource(ctx context.Context, name string, result *list.ListResult, newModel func(*client.ResourceResponse[T]) (*M, *BaseResourceModel)) {
+	res, err := r.GetFunc(ctx, name)
+	if err != nil {
+		result.Diagnostics.AddError("Error reading resource", fmt.Sprintf("Unable to read %q: %s", name, err))
+		return
+	}
+
+	data, baseModel := newModel(res)
+	SetCollection(data, res.Collection)
+	baseModel.Signature = types.StringValue(res.Signature)
+	baseModel.Id = types.StringValue(name)
+	baseModel.Name = types.StringValue(name)
+	if res.Enabled != nil {
+		baseModel.Enabled = types.BoolValue(*res.Enabled)
+	} else {
+		baseModel.Enabled = types.BoolValue(true)
+	}
+	baseModel.Description = StringToNullableString(res.Description)
+
+	if err := r.Handler.MapClientToState(ctx, name, &res.Config, data); err != nil {
+		result.Diagnostics.AddError("Error mapping client to state", err.Error())
+		return
+	}
+
+	result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+}
@@ -0,0 +1,171 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ReferenceValidator wraps the API client when the provider is configured with
+// validate_references. It checks that names referenced by other resources exist
+// on the gateway, or are planned for creation by the same run.
+//
+// Terraform only plans a resource before those that refer to it through an
+// expression; a literal name creates no dependency, so its target may not have
+// been planned yet. Unresolved references are therefore reported as warnings.
+type ReferenceValidator struct {
+	client.IgnitionClient
+
+	mu       sync.Mutex
+	planned  map[string]bool
+	existing map[string][]string
+}
+
+// NewReferenceValidator returns a ReferenceValidator backed by the given client
+func NewReferenceValidator(c client.IgnitionClient) *ReferenceValidator {
+	return &ReferenceValidator{
+		IgnitionClient: c,
+		planned:        make(map[string]bool),
+		existing:       make(map[string][]string),
+	}
+}
+
+// ReferenceValidatorFrom returns the ReferenceValidator held in provider data,
+// or nil when reference validation is disabled.
+func ReferenceValidatorFrom(providerData any) *ReferenceValidator {
+	v, _ := providerData.(*ReferenceValidator)
+	return v
+}
+
+// Reference describes an attribute that holds the name of another gateway resource
+type Reference struct {
+	Path         path.Path
+	Kind         string
+	Module       string
+	ResourceType string
+}
+
+// ResourceWithReferences is implemented by resources with attributes that hold
+// the names of other gateway resources
+type ResourceWithReferences interface {
+	ReferenceAttributes() []Reference
+}
+
+// Plan records that the named resource will be created or renamed by the current run
+func (v *ReferenceValidator) Plan(module, resourceType, name string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.planned[module+"/"+resourceType+"/"+name] = true
+}
+
+// Forget drops the cached listing of a resource type, so that the next check
+// lists it again. It is called whenever the provider creates, renames or
+// deletes a resource of that type, and is a no-op when v is nil.
+func (v *ReferenceValidator) Forget(module, resourceType string) {
+	if v == nil {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	delete(v.existing, module+"/"+resourceType)
+}
+
+// Exists reports whether the named resource exists on the gateway or is planned
+// for creation. Gateway listings are cached until Forget is called for their type.
+func (v *ReferenceValidator) Exists(ctx context.Context, module, resourceType, name string) (bool, error) {
+	key := module + "/" + resourceType
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.planned[key+"/"+name] {
+		return true, nil
+	}
+
+	names, ok := v.existing[key]
+	if !ok {
+		items, err := v.ListResourcesWithModule(ctx, module, resourceType)
+		if err != nil {
+			return false, err
+		}
+		for _, item := range items {
+			names = append(names, item.Name)
+		}
+		v.existing[key] = names
+	}
+
+	return slices.Contains(names, name), nil
+}
+
+// CheckReferences records resources planned for creation or rename and checks that every
+// changed reference names an existing or planned resource. It is a no-op when
+// reference validation is disabled.
+func (r *GenericIgnitionResource[T, M]) CheckReferences(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, refs ...Reference) {
+	if r.References == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Record created and renamed resources so references to them are accepted
+	var name, priorName types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &priorName)...)
+	}
+	if !name.IsNull() && !name.IsUnknown() && !name.Equal(priorName) {
+		r.References.Plan(r.Module, r.ResourceType, name.ValueString())
+	}
+
+	r.References.Check(ctx, req, resp, refs...)
+}
+
+// Check checks that every changed reference of the planned resource names an
+// existing or planned resource. It is a no-op when v is nil.
+func (v *ReferenceValidator) Check(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, refs ...Reference) {
+	if v == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	for _, ref := range refs {
+		var value types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, ref.Path, &value)...)
+		if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+			continue
+		}
+
+		if !req.State.Raw.IsNull() {
+			var prior types.String
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, ref.Path, &prior)...)
+			if prior.Equal(value) {
+				continue
+			}
+		}
+
+		exists, err := v.Exists(ctx, ref.Module, ref.ResourceType, value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				ref.Path,
+				"Unable to Validate Reference",
+				fmt.Sprintf("Could not list %s resources on the gateway: %s", ref.Kind, err),
+			)
+			continue
+		}
+		if !exists {
+			resp.Diagnostics.AddAttributeWarning(
+				ref.Path,
+				"Unknown Reference",
+				fmt.Sprintf("No %s named %q exists on the gateway or is planned for creation. "+
+					"If it is created by this run, refer to it through an expression (e.g., its name attribute) "+
+					"so that Terraform plans it first.", ref.Kind, value.ValueString()),
+			)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package base
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// AllowRenames marks the identity of a resource that is renamed in place as
+// mutable. The name is part of the identity of every Ignition resource (see
+// ResourceIdentitySchema), so a rename changes it, and Terraform rejects an
+// identity that changes unless the resource declares it may.
+func AllowRenames(resp *resource.MetadataResponse) {
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+// Rename renames the resource in place when the planned name differs from the
+// prior state. It returns the signature to use for the subsequent update, which
+// is the prior signature when no rename was needed.
+//
+// The new name and signature are written to resp's state as soon as the rename
+// succeeds, so that state follows the gateway even if the update that follows
+// fails.
+func (r *GenericIgnitionResource[T, M]) Rename(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse, name string, signature types.String) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var priorName types.String
+	diags.Append(req.State.GetAttribute(ctx, path.Root("name"), &priorName)...)
+	if diags.HasError() || priorName.IsNull() || priorName.ValueString() == name {
+		return signature, diags
+	}
+
+	var err error
+	if r.RenameFunc != nil {
+		err = r.RenameFunc(ctx, priorName.ValueString(), name, signature.ValueString())
+	} else {
+		err = r.Client.RenameResourceWithModule(ctx, r.Module, r.ResourceType, priorName.ValueString(), name, signature.ValueString())
+	}
+	if err != nil {
+		diags.AddError("Error renaming resource", err.Error())
+		return signature, diags
+	}
+	r.References.Forget(r.Module, r.ResourceType)
+
+	diags.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	diags.Append(resp.State.SetAttribute(ctx, path.Root("id"), name)...)
+	diags.Append(r.SetIdentity(ctx, resp.Identity, name)...)
+
+	// Renaming changes the signature, so fetch the current one for the update
+	renamed, err := r.GetFunc(ctx, name)
+	if err != nil {
+		diags.AddError("Error reading renamed resource", err.Error())
+		return signature, diags
+	}
+	diags.Append(resp.State.SetAttribute(ctx, path.Root("signature"), renamed.Signature)...)
+
+	return types.StringValue(renamed.Signature), diags
+}
+
+// UseNameForID returns a plan modifier that plans the id as the planned name,
+// since the id of an Ignition resource always follows its name.
+func UseNameForID() planmodifier.String {
+	return useNameForIDModifier{}
+}
+
+type useNameForIDModifier struct{}
+
+func (m useNameForIDModifier) Description(ctx context.Context) string {
+	return "The id follows the planned name."
+}
+
+func (m useNameForIDModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useNameForIDModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var name types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if name.IsNull() || name.IsUnknown() {
+		return
+	}
+
+	resp.PlanValue = name
+}
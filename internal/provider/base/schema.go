@@ -0,0 +1,174 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// SchemaValidator wraps the API client when the provider is configured with
+// validate_schemas. It checks planned resource configs against the resource
+// type schemas published in the gateway's OpenAPI document.
+type SchemaValidator struct {
+	client.IgnitionClient
+}
+
+// NewSchemaValidator returns a SchemaValidator backed by the given client
+func NewSchemaValidator(c client.IgnitionClient) *SchemaValidator {
+	return &SchemaValidator{IgnitionClient: c}
+}
+
+// SchemaValidatorFrom returns the SchemaValidator held in provider data, or nil
+// when schema validation is disabled.
+func SchemaValidatorFrom(providerData any) *SchemaValidator {
+	if r, ok := providerData.(*ReferenceValidator); ok {
+		providerData = r.IgnitionClient
+	}
+	v, _ := providerData.(*SchemaValidator)
+	return v
+}
+
+type planningKey struct{}
+
+// IsPlanning reports whether a config is being mapped for validation at plan
+// time, when mapping should not have side effects or reach external services.
+func IsPlanning(ctx context.Context) bool {
+	planning, _ := ctx.Value(planningKey{}).(bool)
+	return planning
+}
+
+// EncryptSecret returns a placeholder secret while a config is mapped for
+// validation, so that secrets are not sent to the gateway at plan time.
+func (v *SchemaValidator) EncryptSecret(ctx context.Context, plaintext string) (*client.IgnitionSecret, error) {
+	if IsPlanning(ctx) {
+		return &client.IgnitionSecret{Type: "Embedded"}, nil
+	}
+	return v.IgnitionClient.EncryptSecret(ctx, plaintext)
+}
+
+// Check validates config against the schema of the given resource type, adding
+// an error for every violation at the attribute returned by attributePath for
+// the path of the offending config value. Missing keys are only reported when
+// complete is set, as config may leave out values that are not known yet or
+// that the gateway fills in. Resource types the gateway does not describe are
+// not checked.
+func (v *SchemaValidator) Check(ctx context.Context, module, resourceType string, config any, complete bool, attributePath func(configPath []string) path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	schema, err := v.GetResourceTypeSchema(ctx, module, resourceType)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to Validate Config",
+			fmt.Sprintf("Could not read the schema of %s/%s resources from the gateway: %s", module, resourceType, err),
+		)
+		return diags
+	}
+	if schema == nil {
+		return diags
+	}
+
+	violations, err := schema.Validate(config)
+	if err != nil {
+		diags.AddError("Unable to Validate Config", err.Error())
+		return diags
+	}
+
+	for _, violation := range violations {
+		if violation.Missing && !complete {
+			continue
+		}
+		detail := fmt.Sprintf("The config does not conform to the gateway's schema for %s/%s resources: %s", module, resourceType, violation)
+		if p := attributePath(violation.Path); len(p.Steps()) > 0 {
+			diags.AddAttributeError(p, "Invalid Resource Config", detail)
+		} else {
+			diags.AddError("Invalid Resource Config", detail)
+		}
+	}
+	return diags
+}
+
+// CheckSchema validates the config mapped from the planned values against the
+// gateway's schema for the resource type. It is a no-op when schema validation
+// is disabled.
+func (r *GenericIgnitionResource[T, M]) CheckSchema(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.Schemas == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Missing keys are not reported while the configuration refers to values
+	// that are not known yet
+	complete := req.Config.Raw.IsFullyKnown()
+	plan, err := knownPlan(req.Plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Validate Config", err.Error())
+		return
+	}
+
+	var data M
+	resp.Diagnostics.Append(plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, err := r.Handler.MapPlanToClient(context.WithValue(ctx, planningKey{}, true), &data)
+	if err != nil {
+		// Mapping errors are reported when the plan is applied
+		return
+	}
+
+	attributes := req.Plan.Schema.GetAttributes()
+	resp.Diagnostics.Append(r.Schemas.Check(ctx, r.Module, r.ResourceType, config, complete, func(configPath []string) path.Path {
+		return ConfigAttributePath(configPath, func(name string) bool {
+			_, ok := attributes[name]
+			return ok
+		})
+	})...)
+}
+
+// knownPlan returns a copy of plan with its unknown values replaced by nulls,
+// so that they are mapped as if they were unset
+func knownPlan(plan tfsdk.Plan) (tfsdk.Plan, error) {
+	raw, err := tftypes.Transform(plan.Raw, func(_ *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if v.IsKnown() {
+			return v, nil
+		}
+		return tftypes.NewValue(v.Type(), nil), nil
+	})
+	if err != nil {
+		return plan, err
+	}
+	return tfsdk.Plan{Schema: plan.Schema, Raw: raw}, nil
+}
+
+// ConfigAttributePath returns the path of the top-level attribute that most
+// likely configured the config value at configPath: the snake_case form of the
+// innermost key that names an attribute. It returns an empty path when none does.
+func ConfigAttributePath(configPath []string, isAttribute func(name string) bool) path.Path {
+	for i := len(configPath) - 1; i >= 0; i-- {
+		if _, err := strconv.Atoi(configPath[i]); err == nil {
+			continue
+		}
+		if name := snakeCase(configPath[i]); isAttribute(name) {
+			return path.Root(name)
+		}
+	}
+	return path.Empty()
+}
+
+var wordBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])|([A-Z])([A-Z][a-z])`)
+
+// snakeCase converts a camelCase config key (e.g., connectURL) to the form of
+// an attribute name (e.g., connect_url)
+func snakeCase(key string) string {
+	key = wordBoundary.ReplaceAllString(key, "${1}${3}_${2}${4}")
+	return strings.ToLower(strings.ReplaceAll(key, "-", "_"))
+}
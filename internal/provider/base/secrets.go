@@ -0,0 +1,49 @@
+package base
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// Secret describes a sensitive attribute and where the gateway keeps its
+// encrypted value in the resource's config
+type Secret struct {
+	Path path.Path
+	// Key is the dot-separated location of the secret in the config, in the
+	// shape the REST API uses (e.g., settings.authentication.password)
+	Key string
+}
+
+// ResourceWithSecrets is implemented by resources with sensitive attributes.
+// The gateway never returns secrets in plain text, so they cannot be read back
+// into state; the export uses the keys to tell which secrets a resource has.
+type ResourceWithSecrets interface {
+	SecretAttributes() []Secret
+}
+
+// IsSet reports whether the secret has a value in the raw config
+func (s Secret) IsSet(config json.RawMessage) bool {
+	var v any
+	if err := json.Unmarshal(config, &v); err != nil {
+		return false
+	}
+
+	for _, key := range strings.Split(s.Key, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return false
+		}
+		v = obj[key]
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	default:
+		return true
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -12,11 +13,14 @@ import (
 
 // TestProvider is a minimal implementation of provider.Provider for unit testing.
 type TestProvider struct {
-	ResourceFactory     func() resource.Resource
-	ResourceFactories   []func() resource.Resource
-	DataSourceFactory   func() datasource.DataSource
-	DataSourceFactories []func() datasource.DataSource
-	Client              client.IgnitionClient
+	ResourceFactory       func() resource.Resource
+	ResourceFactories     []func() resource.Resource
+	DataSourceFactory     func() datasource.DataSource
+	DataSourceFactories   []func() datasource.DataSource
+	ListResourceFactories []func() list.ListResource
+	Client                client.IgnitionClient
+	ValidateReferences    bool
+	ValidateSchemas       bool
 }
 
 func (p *TestProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -33,8 +37,17 @@ func (p *TestProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 }
 
 func (p *TestProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
-	resp.DataSourceData = p.Client
-	resp.ResourceData = p.Client
+	var c client.IgnitionClient = p.Client
+	if p.ValidateSchemas {
+		c = NewSchemaValidator(c)
+	}
+	if p.ValidateReferences {
+		c = NewReferenceValidator(c)
+	}
+
+	resp.DataSourceData = c
+	resp.ResourceData = c
+	resp.ListResourceData = c
 }
 
 func (p *TestProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -54,3 +67,7 @@ func (p *TestProvider) DataSources(_ context.Context) []func() datasource.DataSo
 	factories = append(factories, p.DataSourceFactories...)
 	return factories
 }
+
+func (p *TestProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return p.ListResourceFactories
+}
@@ -0,0 +1,157 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RawResourceDataSource{}
+
+func NewRawResourceDataSource() datasource.DataSource {
+	return &RawResourceDataSource{}
+}
+
+// RawResourceDataSource reads a gateway resource of any module and type as
+// raw JSON.
+type RawResourceDataSource struct {
+	client client.IgnitionClient
+}
+
+// RawResourceDataSourceModel describes the data source data model.
+type RawResourceDataSourceModel struct {
+	Id          types.String         `tfsdk:"id"`
+	Module      types.String         `tfsdk:"module"`
+	Type        types.String         `tfsdk:"type"`
+	Name        types.String         `tfsdk:"name"`
+	Collection  types.String         `tfsdk:"collection"`
+	Description types.String         `tfsdk:"description"`
+	Enabled     types.Bool           `tfsdk:"enabled"`
+	Config      jsontypes.Normalized `tfsdk:"config"`
+	Signature   types.String         `tfsdk:"signature"`
+}
+
+func (d *RawResourceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource"
+}
+
+func (d *RawResourceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a gateway resource of any module and type as raw JSON. Use it to reference resource types " +
+			"that have no dedicated data source, such as historian providers or the settings of third-party modules.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"module": schema.StringAttribute{
+				Description: "The gateway module that owns the resource (e.g., ignition, com.inductiveautomation.historian).",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The resource type within the module (e.g., database-connection).",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the resource.",
+				Required:    true,
+			},
+			"collection": schema.StringAttribute{
+				Description: "The config collection to read the resource from. When unset, the resource is read as the " +
+					"gateway currently sees it, and this is set to the collection it was found in.",
+				Optional: true,
+				Computed: true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the resource.",
+				Computed:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the resource is enabled.",
+				Computed:    true,
+			},
+			"config": schema.StringAttribute{
+				Description: "The JSON config of the resource, as the gateway's REST API represents it. " +
+					"Use `jsondecode` to reference its settings.",
+				Computed:   true,
+				CustomType: jsontypes.NormalizedType{},
+			},
+			"signature": schema.StringAttribute{
+				Description: "The signature of the resource.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *RawResourceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.IgnitionClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.IgnitionClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RawResourceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RawResourceDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Collection.IsNull() && !data.Collection.IsUnknown() && data.Collection.ValueString() != "" {
+		ctx = client.WithCollection(ctx, data.Collection.ValueString())
+	}
+
+	var res client.ResourceResponse[json.RawMessage]
+	if err := d.client.GetResourceWithModule(ctx, data.Module.ValueString(), data.Type.ValueString(), data.Name.ValueString(), &res); err != nil {
+		resp.Diagnostics.AddError("Error reading resource", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(fmt.Sprintf("%s/%s/%s", data.Module.ValueString(), data.Type.ValueString(), res.Name))
+	data.Signature = types.StringValue(res.Signature)
+	if res.Enabled != nil {
+		data.Enabled = types.BoolValue(*res.Enabled)
+	} else {
+		data.Enabled = types.BoolValue(true)
+	}
+
+	if res.Description != "" {
+		data.Description = types.StringValue(res.Description)
+	} else {
+		data.Description = types.StringNull()
+	}
+
+	collection := res.Collection
+	if collection == "" {
+		collection = client.CollectionFrom(ctx)
+	}
+	if collection == "" {
+		collection = client.DefaultCollection
+	}
+	data.Collection = types.StringValue(collection)
+
+	config := string(res.Config)
+	if len(res.Config) == 0 || config == "null" {
+		config = "{}"
+	}
+	data.Config = jsontypes.NewNormalizedValue(config)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,69 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnitRawResourceDataSource(t *testing.T) {
+	mockClient := &client.MockClient{
+		GetResourceWithModuleFunc: func(ctx context.Context, module, resourceType, name string, dest any) error {
+			if module != "com.inductiveautomation.historian" || resourceType != "historian-provider" {
+				return fmt.Errorf("unexpected resource type %s/%s", module, resourceType)
+			}
+			raw, _ := json.Marshal(client.ResourceResponse[json.RawMessage]{
+				Name:        name,
+				Collection:  "core",
+				Description: "Plant historian",
+				Signature:   "sig-1",
+				Config:      json.RawMessage(`{"profile":{"type":"CoreHistorian"},"settings":{"retentionDays":90}}`),
+			})
+			return json.Unmarshal(raw, dest)
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			DataSourceFactory: NewRawResourceDataSource,
+			Client:            mockClient,
+		}),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					data "ignition_resource" "test" {
+						module = "com.inductiveautomation.historian"
+						type   = "historian-provider"
+						name   = "Core Historian"
+					}
+					output "retention" {
+						value = jsondecode(data.ignition_resource.test.config).settings.retentionDays
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ignition_resource.test", "id", "com.inductiveautomation.historian/historian-provider/Core Historian"),
+					resource.TestCheckResourceAttr("data.ignition_resource.test", "collection", "core"),
+					resource.TestCheckResourceAttr("data.ignition_resource.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("data.ignition_resource.test", "description", "Plant historian"),
+					resource.TestCheckResourceAttr("data.ignition_resource.test", "signature", "sig-1"),
+					resource.TestCheckOutput("retention", "90"),
+				),
+			},
+		},
+	})
+}
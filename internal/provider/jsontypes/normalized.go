@@ -0,0 +1,241 @@
+// Package jsontypes provides custom attribute types for free-form JSON strings
+// that are compared semantically rather than byte-for-byte.
+package jsontypes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = NormalizedType{}
+var _ basetypes.StringValuableWithSemanticEquals = Normalized{}
+var _ xattr.ValidateableAttribute = Normalized{}
+
+// NormalizedType is a string attribute type holding a JSON document. Values are
+// semantically equal when they differ only in key ordering, whitespace or
+// numeric formatting.
+//
+// When IgnoreDefaults is set, keys that the gateway adds to objects (e.g.,
+// server-populated defaults) are also ignored, as long as every key present in
+// the configured value matches. Removing a key from the configuration will then
+// not be detected as a change.
+type NormalizedType struct {
+	basetypes.StringType
+	IgnoreDefaults bool
+}
+
+func (t NormalizedType) String() string {
+	return "jsontypes.NormalizedType"
+}
+
+func (t NormalizedType) ValueType(ctx context.Context) attr.Value {
+	return Normalized{ignoreDefaults: t.IgnoreDefaults}
+}
+
+func (t NormalizedType) Equal(o attr.Type) bool {
+	other, ok := o.(NormalizedType)
+	if !ok {
+		return false
+	}
+
+	return t.IgnoreDefaults == other.IgnoreDefaults && t.StringType.Equal(other.StringType)
+}
+
+func (t NormalizedType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return Normalized{StringValue: in, ignoreDefaults: t.IgnoreDefaults}, nil
+}
+
+func (t NormalizedType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+// Normalized is the value type of NormalizedType.
+type Normalized struct {
+	basetypes.StringValue
+	ignoreDefaults bool
+}
+
+// NewNormalizedValue returns a known Normalized value holding the given JSON document
+func NewNormalizedValue(value string) Normalized {
+	return Normalized{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewNormalizedNull returns a null Normalized value
+func NewNormalizedNull() Normalized {
+	return Normalized{StringValue: basetypes.NewStringNull()}
+}
+
+// NewNormalizedUnknown returns an unknown Normalized value
+func NewNormalizedUnknown() Normalized {
+	return Normalized{StringValue: basetypes.NewStringUnknown()}
+}
+
+func (v Normalized) Type(ctx context.Context) attr.Type {
+	return NormalizedType{IgnoreDefaults: v.ignoreDefaults}
+}
+
+func (v Normalized) Equal(o attr.Value) bool {
+	other, ok := o.(Normalized)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether the prior value (usually the configured
+// JSON) is semantically equal to this value (usually the JSON returned by the
+// gateway).
+func (v Normalized) StringSemanticEquals(ctx context.Context, priorValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	prior, ok := priorValuable.(Normalized)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this to the provider developers.", v, priorValuable),
+		)
+		return false, diags
+	}
+
+	equal, err := SemanticallyEqual(prior.ValueString(), v.ValueString(), v.ignoreDefaults)
+	if err != nil {
+		diags.AddError("Semantic Equality Check Error", err.Error())
+		return false, diags
+	}
+
+	return equal, diags
+}
+
+func (v Normalized) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := decode(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON String Value",
+			fmt.Sprintf("A string value was provided that is not valid JSON: %s", err),
+		)
+	}
+}
+
+// Unmarshal decodes the JSON document into target
+func (v Normalized) Unmarshal(target any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if v.IsNull() || v.IsUnknown() {
+		diags.AddError("JSON Unmarshal Error", "Cannot unmarshal a null or unknown JSON value.")
+		return diags
+	}
+
+	if err := json.Unmarshal([]byte(v.ValueString()), target); err != nil {
+		diags.AddError("JSON Unmarshal Error", err.Error())
+	}
+
+	return diags
+}
+
+// SemanticallyEqual reports whether the JSON documents want and got are equal,
+// ignoring key ordering, whitespace and numeric formatting. When ignoreExtra is
+// set, object keys present in got but not in want are ignored.
+func SemanticallyEqual(want, got string, ignoreExtra bool) (bool, error) {
+	wantValue, err := decode(want)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	gotValue, err := decode(got)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	return equalValues(wantValue, gotValue, ignoreExtra), nil
+}
+
+func decode(s string) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.UseNumber()
+
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after top-level value")
+	}
+
+	return value, nil
+}
+
+func equalValues(want, got any, ignoreExtra bool) bool {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			return false
+		}
+		if !ignoreExtra && len(w) != len(g) {
+			return false
+		}
+		for k, wv := range w {
+			gv, ok := g[k]
+			if !ok || !equalValues(wv, gv, ignoreExtra) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		g, ok := got.([]any)
+		if !ok || len(w) != len(g) {
+			return false
+		}
+		for i := range w {
+			if !equalValues(w[i], g[i], ignoreExtra) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		g, ok := got.(json.Number)
+		if !ok {
+			return false
+		}
+		wf, _, err := big.ParseFloat(w.String(), 10, 256, big.ToNearestEven)
+		if err != nil {
+			return false
+		}
+		gf, _, err := big.ParseFloat(g.String(), 10, 256, big.ToNearestEven)
+		if err != nil {
+			return false
+		}
+		return wf.Cmp(gf) == 0
+	default:
+		return want == got
+	}
+}
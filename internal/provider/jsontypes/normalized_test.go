@@ -0,0 +1,71 @@
+package jsontypes
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSemanticallyEqual(t *testing.T) {
+	tests := []struct {
+		name        string
+		want        string
+		got         string
+		ignoreExtra bool
+		expected    bool
+	}{
+		{name: "identical", want: `{"a":1}`, got: `{"a":1}`, expected: true},
+		{name: "whitespace", want: `{ "a" : 1 }`, got: `{"a":1}`, expected: true},
+		{name: "key order", want: `{"a":1,"b":2}`, got: `{"b":2,"a":1}`, expected: true},
+		{name: "numeric formatting", want: `{"a":1000}`, got: `{"a":1.0e3}`, expected: true},
+		{name: "nested key order", want: `{"a":{"x":true,"y":[1,2]}}`, got: `{"a":{"y":[1,2],"x":true}}`, expected: true},
+		{name: "different value", want: `{"a":1}`, got: `{"a":2}`, expected: false},
+		{name: "array order matters", want: `[1,2]`, got: `[2,1]`, expected: false},
+		{name: "extra key", want: `{"a":1}`, got: `{"a":1,"b":2}`, expected: false},
+		{name: "extra key ignored", want: `{"a":1}`, got: `{"a":1,"b":2}`, ignoreExtra: true, expected: true},
+		{name: "nested extra key ignored", want: `{"a":{"x":1}}`, got: `{"a":{"x":1,"y":2}}`, ignoreExtra: true, expected: true},
+		{name: "missing key not ignored", want: `{"a":1,"b":2}`, got: `{"a":1}`, ignoreExtra: true, expected: false},
+		{name: "type mismatch", want: `{"a":"1"}`, got: `{"a":1}`, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, err := SemanticallyEqual(tt.want, tt.got, tt.ignoreExtra)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if equal != tt.expected {
+				t.Errorf("SemanticallyEqual(%s, %s) = %v, want %v", tt.want, tt.got, equal, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSemanticallyEqual_InvalidJSON(t *testing.T) {
+	if _, err := SemanticallyEqual(`{"a":`, `{}`, false); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	if _, err := SemanticallyEqual(`{} {}`, `{}`, false); err == nil {
+		t.Error("expected error for trailing data")
+	}
+}
+
+func TestNormalized_StringSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+	typ := NormalizedType{IgnoreDefaults: true}
+
+	prior, _ := typ.ValueFromString(ctx, NewNormalizedValue(`{"baseRate": 1000}`).StringValue)
+	current, _ := typ.ValueFromString(ctx, NewNormalizedValue(`{"baseRate":1000,"extra":"default"}`).StringValue)
+
+	equal, diags := current.(Normalized).StringSemanticEquals(ctx, prior)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !equal {
+		t.Error("expected values to be semantically equal")
+	}
+
+	equal, _ = NewNormalizedValue(`{"baseRate":1000,"extra":"default"}`).StringSemanticEquals(ctx, NewNormalizedValue(`{"baseRate": 1000}`))
+	if equal {
+		t.Error("expected extra keys to be significant without IgnoreDefaults")
+	}
+}
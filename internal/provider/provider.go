@@ -2,21 +2,27 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"os"
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/datasources"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/resources"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure IgnitionProvider satisfies various provider interfaces.
 var _ provider.Provider = &IgnitionProvider{}
+var _ provider.ProviderWithListResources = &IgnitionProvider{}
 
 // IgnitionProvider defines the provider implementation.
 type IgnitionProvider struct {
@@ -25,15 +31,29 @@ type IgnitionProvider struct {
 	// testing.
 	version string
 	client  client.IgnitionClient
+	// transport wraps the HTTP transport of the API client, e.g. to record
+	// or replay its requests in tests
+	transport func(http.RoundTripper) http.RoundTripper
 }
 
 // IgnitionProviderModel describes the provider data model.
 type IgnitionProviderModel struct {
-	Host             types.String `tfsdk:"host"`
-	Token            types.String `tfsdk:"token"`
-	AllowInsecureTLS types.Bool   `tfsdk:"allow_insecure_tls"`
+	Host               types.String `tfsdk:"host"`
+	Token              types.String `tfsdk:"token"`
+	AllowInsecureTLS   types.Bool   `tfsdk:"allow_insecure_tls"`
+	ValidateReferences types.Bool   `tfsdk:"validate_references"`
+	ValidateSchemas    types.Bool   `tfsdk:"validate_schemas"`
+	Mode               types.String `tfsdk:"mode"`
+	DataDir            types.String `tfsdk:"data_dir"`
 }
 
+const (
+	// modeAPI manages a running gateway through its REST API
+	modeAPI = "api"
+	// modeFilesystem writes configuration files into a gateway's data directory
+	modeFilesystem = "filesystem"
+)
+
 func (p *IgnitionProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "ignition"
 	resp.Version = p.version
@@ -56,6 +76,29 @@ func (p *IgnitionProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Description: "Whether to allow insecure TLS connections (e.g., self-signed certs).",
 				Optional:    true,
 			},
+			"validate_references": schema.BoolAttribute{
+				Description: "Whether to check at plan time that resources referenced by name (e.g., a project's default_db) " +
+					"exist on the gateway or are created in the same plan, and to warn about those that do not.",
+				Optional: true,
+			},
+			"validate_schemas": schema.BoolAttribute{
+				Description: "Whether to check at plan time that the config of each resource conforms to the schema of its " +
+					"resource type published in the gateway's OpenAPI document (e.g., to catch misspelled device parameters).",
+				Optional: true,
+			},
+			"mode": schema.StringAttribute{
+				Description: "How the provider manages the gateway: \"api\" (default) uses the REST API of a running gateway, " +
+					"\"filesystem\" reads and writes the configuration files in the gateway's data directory, " +
+					"so that configuration can be rendered into a volume or image without a running gateway.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(modeAPI, modeFilesystem),
+				},
+			},
+			"data_dir": schema.StringAttribute{
+				Description: "The gateway's data directory (e.g., /usr/local/bin/ignition/data). Required when mode is \"filesystem\".",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -69,6 +112,46 @@ func (p *IgnitionProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	var apiClient client.IgnitionClient
+	var err error
+
+	switch {
+	case p.client != nil:
+		apiClient = p.client
+	case data.Mode.ValueString() == modeFilesystem:
+		apiClient, err = p.newFilesystemClient(data, resp)
+	default:
+		apiClient, err = p.newAPIClient(data, resp)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Ignition API Client",
+			"An unexpected error occurred when creating the Ignition API client. "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"Ignition Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	if data.ValidateSchemas.ValueBool() {
+		apiClient = base.NewSchemaValidator(apiClient)
+	}
+	if data.ValidateReferences.ValueBool() {
+		apiClient = base.NewReferenceValidator(apiClient)
+	}
+
+	resp.DataSourceData = apiClient
+	resp.ResourceData = apiClient
+	resp.ListResourceData = apiClient
+}
+
+// newAPIClient returns a client for the REST API of the configured gateway
+func (p *IgnitionProvider) newAPIClient(data IgnitionProviderModel, resp *provider.ConfigureResponse) (client.IgnitionClient, error) {
 	if data.Host.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("host"),
@@ -86,7 +169,7 @@ func (p *IgnitionProvider) Configure(ctx context.Context, req provider.Configure
 	}
 
 	if resp.Diagnostics.HasError() {
-		return
+		return nil, nil
 	}
 
 	host := data.Host.ValueString()
@@ -118,36 +201,49 @@ func (p *IgnitionProvider) Configure(ctx context.Context, req provider.Configure
 	}
 
 	if resp.Diagnostics.HasError() {
-		return
+		return nil, nil
 	}
 
-	var apiClient client.IgnitionClient
-	var err error
+	c, err := client.NewClient(host, token, allowInsecure)
+	if err != nil || p.transport == nil {
+		return c, err
+	}
+	c.HTTPClient.HTTPClient.Transport = p.transport(c.HTTPClient.HTTPClient.Transport)
+	return c, nil
+}
 
-	if p.client != nil {
-		apiClient = p.client
-	} else {
-		apiClient, err = client.NewClient(host, token, allowInsecure)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Create Ignition API Client",
-				"An unexpected error occurred when creating the Ignition API client. "+
-					"If the error is not clear, please contact the provider developers.\n\n"+
-					"Ignition Client Error: "+err.Error(),
-			)
-			return
-		}
+// newFilesystemClient returns a client for the configured data directory
+func (p *IgnitionProvider) newFilesystemClient(data IgnitionProviderModel, resp *provider.ConfigureResponse) (client.IgnitionClient, error) {
+	if data.DataDir.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("data_dir"),
+			"Unknown Data Directory",
+			"The provider data directory cannot be unknown. Please verify your Terraform configuration.",
+		)
+		return nil, nil
 	}
 
-	resp.DataSourceData = apiClient
-	resp.ResourceData = apiClient
+	if data.DataDir.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("data_dir"),
+			"Missing Data Directory",
+			"The gateway's data directory must be configured via the 'data_dir' attribute when mode is \"filesystem\".",
+		)
+		return nil, nil
+	}
+
+	return client.NewFilesystemClient(data.DataDir.ValueString())
 }
 
 func (p *IgnitionProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		resources.NewDatabaseConnectionResource,
+		resources.NewJDBCDriverResource,
+		resources.NewDatabaseTranslatorResource,
 		resources.NewTagProviderResource,
 		resources.NewUserSourceResource,
+		resources.NewUserSourceRoleResource,
+		resources.NewUserSourceUserResource,
 		resources.NewProjectResource,
 		resources.NewAuditProfileResource,
 		resources.NewAlarmNotificationProfileResource,
@@ -160,6 +256,31 @@ func (p *IgnitionProvider) Resources(ctx context.Context) []func() resource.Reso
 		resources.NewRedundancyResource,
 		resources.NewGanGeneralSettingsResource,
 		resources.NewDeviceResource,
+		resources.NewDeploymentModeResource,
+		resources.NewRawResource,
+	}
+}
+
+func (p *IgnitionProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		resources.NewDatabaseConnectionListResource,
+		resources.NewJDBCDriverListResource,
+		resources.NewDatabaseTranslatorListResource,
+		resources.NewTagProviderListResource,
+		resources.NewUserSourceListResource,
+		resources.NewProjectListResource,
+		resources.NewAuditProfileListResource,
+		resources.NewAlarmNotificationProfileListResource,
+		resources.NewOpcUaConnectionListResource,
+		resources.NewAlarmJournalListResource,
+		resources.NewSMTPProfileListResource,
+		resources.NewStoreAndForwardListResource,
+		resources.NewIdentityProviderListResource,
+		resources.NewGanOutgoingListResource,
+		resources.NewRedundancyListResource,
+		resources.NewGanGeneralSettingsListResource,
+		resources.NewDeviceListResource,
+		resources.NewDeploymentModeListResource,
 	}
 }
 
@@ -171,6 +292,7 @@ func (p *IgnitionProvider) DataSources(ctx context.Context) []func() datasource.
 		datasources.NewTagProviderDataSource,
 		datasources.NewSMTPProfileDataSource,
 		datasources.NewStoreAndForwardDataSource,
+		datasources.NewRawResourceDataSource,
 	}
 }
 
@@ -181,3 +303,15 @@ func New(version string) func() provider.Provider {
 		}
 	}
 }
+
+// NewWithTransport returns a provider whose API client sends its requests
+// through the HTTP transport returned by transport, e.g. to record or replay
+// them in tests
+func NewWithTransport(version string, transport func(http.RoundTripper) http.RoundTripper) func() provider.Provider {
+	return func() provider.Provider {
+		return &IgnitionProvider{
+			version:   version,
+			transport: transport,
+		}
+	}
+}
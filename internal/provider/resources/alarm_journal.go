@@ -7,11 +7,12 @@ import (
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -19,11 +20,19 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AlarmJournalResource{}
 var _ resource.ResourceWithImportState = &AlarmJournalResource{}
+var _ resource.ResourceWithIdentity = &AlarmJournalResource{}
+var _ resource.ResourceWithModifyPlan = &AlarmJournalResource{}
+var _ base.ResourceWithReferences = &AlarmJournalResource{}
+var _ list.ListResourceWithConfigure = &AlarmJournalResource{}
 
 func NewAlarmJournalResource() resource.Resource {
 	return &AlarmJournalResource{}
 }
 
+func NewAlarmJournalListResource() list.ListResource {
+	return &AlarmJournalResource{}
+}
+
 // AlarmJournalResource defines the resource implementation.
 type AlarmJournalResource struct {
 	client  client.IgnitionClient
@@ -33,6 +42,7 @@ type AlarmJournalResource struct {
 // AlarmJournalResourceModel describes the resource data model.
 type AlarmJournalResourceModel struct {
 	base.BaseResourceModel
+	base.CollectionResourceModel
 	Type          types.String `tfsdk:"type"`
 	Datasource    types.String `tfsdk:"datasource"`
 	TableName     types.String `tfsdk:"table_name"`
@@ -43,6 +53,7 @@ type AlarmJournalResourceModel struct {
 
 func (r *AlarmJournalResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_alarm_journal"
+	base.AllowRenames(resp)
 }
 
 func (r *AlarmJournalResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -52,15 +63,12 @@ func (r *AlarmJournalResource) Schema(ctx context.Context, req resource.SchemaRe
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the alarm journal.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Description: "The description of the alarm journal.",
@@ -104,6 +112,7 @@ func (r *AlarmJournalResource) Schema(ctx context.Context, req resource.SchemaRe
 				Description: "The alarm journal on the remote gateway (for REMOTE type).",
 				Optional:    true,
 			},
+			"collection": base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Description: "The signature of the resource, used for updates and deletes.",
 				Computed:    true,
@@ -130,11 +139,14 @@ func (r *AlarmJournalResource) Configure(ctx context.Context, req resource.Confi
 	r.generic = base.GenericIgnitionResource[client.AlarmJournalConfig, AlarmJournalResourceModel]{
 		Client:       c,
 		Handler:      r,
-		ResourceType: "ignition/alarm-journal",
+		Module:       "ignition",
+		ResourceType: "alarm-journal",
 		CreateFunc:   c.CreateAlarmJournal,
 		GetFunc:      c.GetAlarmJournal,
 		UpdateFunc:   c.UpdateAlarmJournal,
 		DeleteFunc:   c.DeleteAlarmJournal,
+		References:   base.ReferenceValidatorFrom(req.ProviderData),
+		Schemas:      base.SchemaValidatorFrom(req.ProviderData),
 	}
 }
 
@@ -237,11 +249,42 @@ func (r *AlarmJournalResource) Delete(ctx context.Context, req resource.DeleteRe
 	r.generic.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
 
+func (r *AlarmJournalResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.generic.CheckReferences(ctx, req, resp, r.ReferenceAttributes()...)
+	r.generic.CheckSchema(ctx, req, resp)
+}
+
+func (r *AlarmJournalResource) ReferenceAttributes() []base.Reference {
+	return []base.Reference{
+		{Path: path.Root("datasource"), Kind: "database connection", Module: "ignition", ResourceType: "database-connection"},
+	}
+}
+
+func (r *AlarmJournalResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
 func (r *AlarmJournalResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.generic.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &AlarmJournalResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
 	})...)
 }
+
+func (r *AlarmJournalResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists Alarm Journals configured on the gateway.")
+}
+
+func (r *AlarmJournalResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.generic.List(ctx, req, stream, func(_ *client.ResourceResponse[client.AlarmJournalConfig]) (*AlarmJournalResourceModel, *base.BaseResourceModel) {
+		var data AlarmJournalResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
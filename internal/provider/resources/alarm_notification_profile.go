@@ -7,11 +7,12 @@ import (
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -19,11 +20,20 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AlarmNotificationProfileResource{}
 var _ resource.ResourceWithImportState = &AlarmNotificationProfileResource{}
+var _ resource.ResourceWithIdentity = &AlarmNotificationProfileResource{}
+var _ resource.ResourceWithModifyPlan = &AlarmNotificationProfileResource{}
+var _ base.ResourceWithReferences = &AlarmNotificationProfileResource{}
+var _ base.ResourceWithSecrets = &AlarmNotificationProfileResource{}
+var _ list.ListResourceWithConfigure = &AlarmNotificationProfileResource{}
 
 func NewAlarmNotificationProfileResource() resource.Resource {
 	return &AlarmNotificationProfileResource{}
 }
 
+func NewAlarmNotificationProfileListResource() list.ListResource {
+	return &AlarmNotificationProfileResource{}
+}
+
 // AlarmNotificationProfileResource defines the resource implementation.
 type AlarmNotificationProfileResource struct {
 	base.GenericIgnitionResource[client.AlarmNotificationProfileConfig, AlarmNotificationProfileResourceModel]
@@ -32,6 +42,7 @@ type AlarmNotificationProfileResource struct {
 // AlarmNotificationProfileResourceModel describes the resource data model.
 type AlarmNotificationProfileResourceModel struct {
 	base.BaseResourceModel
+	base.CollectionResourceModel
 	Type        types.String                        `tfsdk:"type"`
 	EmailConfig *AlarmNotificationProfileEmailModel `tfsdk:"email_config"`
 }
@@ -48,6 +59,7 @@ type AlarmNotificationProfileEmailModel struct {
 
 func (r *AlarmNotificationProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_alarm_notification_profile"
+	base.AllowRenames(resp)
 }
 
 func (r *AlarmNotificationProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -57,15 +69,12 @@ func (r *AlarmNotificationProfileResource) Schema(ctx context.Context, req resou
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the alarm notification profile.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Description: "The description of the alarm notification profile.",
@@ -86,6 +95,7 @@ func (r *AlarmNotificationProfileResource) Schema(ctx context.Context, req resou
 					),
 				},
 			},
+			"collection": base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Description: "The signature of the resource, used for updates and deletes.",
 				Computed:    true,
@@ -155,6 +165,8 @@ func (r *AlarmNotificationProfileResource) Configure(ctx context.Context, req re
 	r.GetFunc = apiClient.GetAlarmNotificationProfile
 	r.UpdateFunc = apiClient.UpdateAlarmNotificationProfile
 	r.DeleteFunc = apiClient.DeleteAlarmNotificationProfile
+	r.References = base.ReferenceValidatorFrom(req.ProviderData)
+	r.Schemas = base.SchemaValidatorFrom(req.ProviderData)
 }
 
 func (r *AlarmNotificationProfileResource) MapPlanToClient(ctx context.Context, model *AlarmNotificationProfileResourceModel) (client.AlarmNotificationProfileConfig, error) {
@@ -272,11 +284,48 @@ func (r *AlarmNotificationProfileResource) Delete(ctx context.Context, req resou
 	r.GenericIgnitionResource.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
 
+func (r *AlarmNotificationProfileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.CheckReferences(ctx, req, resp, r.ReferenceAttributes()...)
+	r.CheckSchema(ctx, req, resp)
+}
+
+func (r *AlarmNotificationProfileResource) ReferenceAttributes() []base.Reference {
+	return []base.Reference{
+		{Path: path.Root("email_config").AtName("email_profile"), Kind: "SMTP profile", Module: "ignition", ResourceType: "email-profile"},
+	}
+}
+
+func (r *AlarmNotificationProfileResource) SecretAttributes() []base.Secret {
+	return []base.Secret{
+		{Path: path.Root("email_config").AtName("password"), Key: "settings.settings.password"},
+	}
+}
+
+func (r *AlarmNotificationProfileResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
 func (r *AlarmNotificationProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.GenericIgnitionResource.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &AlarmNotificationProfileResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
 	})...)
 }
+
+func (r *AlarmNotificationProfileResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists Alarm Notification Profiles configured on the gateway.")
+}
+
+func (r *AlarmNotificationProfileResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.GenericIgnitionResource.List(ctx, req, stream, func(_ *client.ResourceResponse[client.AlarmNotificationProfileConfig]) (*AlarmNotificationProfileResourceModel, *base.BaseResourceModel) {
+		var data AlarmNotificationProfileResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
@@ -5,8 +5,7 @@ import (
 	"os"
 	"testing"
 
-	"github.com/apollogeddon/ignition-tfpl/internal/provider"
-	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/apollogeddon/ignition-tfpl/internal/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -16,10 +15,10 @@ func TestAccAlarmNotificationProfileResource(t *testing.T) {
 		t.Skip("Skipping acceptance test: IGNITION_HOST and/or IGNITION_TOKEN not set")
 	}
 
-	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	rName := acctest.Name(t)
 
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: provider.TestAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: acctest.ProviderFactories(t),
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
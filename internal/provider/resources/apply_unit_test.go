@@ -0,0 +1,209 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testServer returns a configured provider server for p and its schemas
+func testServer(t *testing.T, p *base.TestProvider) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(p)()
+	if err != nil {
+		t.Fatalf("failed to create provider server: %v", err)
+	}
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %v", err)
+	}
+	checkDiagnostics(t, "GetProviderSchema", schemas.Diagnostics)
+
+	providerType := schemas.Provider.ValueType()
+	providerConfig := dynamicValue(t, providerType, tftypes.NewValue(providerType, map[string]tftypes.Value{
+		"host":  tftypes.NewValue(tftypes.String, "http://mock-host"),
+		"token": tftypes.NewValue(tftypes.String, "mock-token"),
+	}))
+	configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: providerConfig})
+	if err != nil {
+		t.Fatalf("ConfigureProvider: %v", err)
+	}
+	checkDiagnostics(t, "ConfigureProvider", configured.Diagnostics)
+
+	return server, schemas
+}
+
+// identityType returns the type of the identity of a resource type, if it has
+// one
+func identityType(t *testing.T, server tfprotov6.ProviderServer, typeName string) (tftypes.Type, bool) {
+	t.Helper()
+	identities, err := server.GetResourceIdentitySchemas(context.Background(), &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatalf("GetResourceIdentitySchemas: %v", err)
+	}
+	checkDiagnostics(t, "GetResourceIdentitySchemas", identities.Diagnostics)
+	identity, ok := identities.IdentitySchemas[typeName]
+	if !ok {
+		return nil, false
+	}
+	return identity.ValueType(), true
+}
+
+// applyResource plans and applies the creation of a resource directly against
+// the provider server and performs the consistency checks Terraform makes on
+// the result: no unknown values may remain after apply and every known planned
+// value must be kept. Attributes missing from config are null. It returns the
+// new state and, for resources with an identity, the new identity.
+func applyResource(t *testing.T, p *base.TestProvider, typeName string, config map[string]tftypes.Value) (tftypes.Value, tftypes.Value) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, schemas := testServer(t, p)
+	schema, ok := schemas.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("resource type %s not found", typeName)
+	}
+	typ := schema.ValueType().(tftypes.Object)
+
+	values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, v := range config {
+		if _, ok := values[name]; !ok {
+			t.Fatalf("resource type %s has no attribute %s", typeName, name)
+		}
+		values[name] = v
+	}
+	configValue := dynamicValue(t, typ, tftypes.NewValue(typ, values))
+	priorState := dynamicValue(t, typ, tftypes.NewValue(typ, nil))
+
+	validated, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: typeName,
+		Config:   configValue,
+	})
+	if err != nil {
+		t.Fatalf("ValidateResourceConfig: %v", err)
+	}
+	checkDiagnostics(t, "ValidateResourceConfig", validated.Diagnostics)
+
+	planned, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       priorState,
+		ProposedNewState: configValue,
+		Config:           configValue,
+	})
+	if err != nil {
+		t.Fatalf("PlanResourceChange: %v", err)
+	}
+	checkDiagnostics(t, "PlanResourceChange", planned.Diagnostics)
+
+	applied, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     typeName,
+		PriorState:   priorState,
+		PlannedState: planned.PlannedState,
+		Config:       configValue,
+	})
+	if err != nil {
+		t.Fatalf("ApplyResourceChange: %v", err)
+	}
+	checkDiagnostics(t, "ApplyResourceChange", applied.Diagnostics)
+
+	plannedValue, err := planned.PlannedState.Unmarshal(typ)
+	if err != nil {
+		t.Fatalf("failed to decode planned state: %v", err)
+	}
+	newState, err := applied.NewState.Unmarshal(typ)
+	if err != nil {
+		t.Fatalf("failed to decode new state: %v", err)
+	}
+
+	_ = tftypes.Walk(newState, func(p *tftypes.AttributePath, v tftypes.Value) (bool, error) {
+		if !v.IsKnown() {
+			t.Errorf("provider returned unknown value after apply for %s", p)
+		}
+		return true, nil
+	})
+	_ = tftypes.Walk(plannedValue, func(p *tftypes.AttributePath, v tftypes.Value) (bool, error) {
+		if !v.IsFullyKnown() {
+			return v.IsKnown(), nil
+		}
+		got, _, err := tftypes.WalkAttributePath(newState, p)
+		if err != nil {
+			t.Errorf("planned value for %s is missing after apply: %v", p, err)
+			return false, nil
+		}
+		if !v.Equal(got.(tftypes.Value)) {
+			t.Errorf("provider produced inconsistent result after apply for %s: planned %s, got %s", p, v, got)
+		}
+		return false, nil
+	})
+
+	var identity tftypes.Value
+	if typ, ok := identityType(t, server, typeName); ok {
+		if applied.NewIdentity == nil {
+			t.Fatalf("provider returned no identity after apply for %s", typeName)
+		}
+		if identity, err = applied.NewIdentity.IdentityData.Unmarshal(typ); err != nil {
+			t.Fatalf("failed to decode new identity: %v", err)
+		}
+	}
+
+	return newState, identity
+}
+
+// importResource imports a resource by its identity and returns the imported
+// state, before it is read
+func importResource(t *testing.T, p *base.TestProvider, typeName string, identity map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	server, schemas := testServer(t, p)
+	typ, ok := identityType(t, server, typeName)
+	if !ok {
+		t.Fatalf("resource type %s has no identity", typeName)
+	}
+	imported, err := server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+		TypeName: typeName,
+		Identity: &tfprotov6.ResourceIdentityData{
+			IdentityData: dynamicValue(t, typ, tftypes.NewValue(typ, identity)),
+		},
+	})
+	if err != nil {
+		t.Fatalf("ImportResourceState: %v", err)
+	}
+	checkDiagnostics(t, "ImportResourceState", imported.Diagnostics)
+	if len(imported.ImportedResources) != 1 {
+		t.Fatalf("expected one imported resource, got %d", len(imported.ImportedResources))
+	}
+
+	state, err := imported.ImportedResources[0].State.Unmarshal(schemas.ResourceSchemas[typeName].ValueType())
+	if err != nil {
+		t.Fatalf("failed to decode imported state: %v", err)
+	}
+	return state
+}
+
+func dynamicValue(t *testing.T, typ tftypes.Type, v tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+	dv, err := tfprotov6.NewDynamicValue(typ, v)
+	if err != nil {
+		t.Fatalf("failed to encode value: %v", err)
+	}
+	return &dv
+}
+
+func checkDiagnostics(t *testing.T, rpc string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s: %s", rpc, d.Summary, d.Detail)
+		}
+	}
+}
@@ -7,11 +7,12 @@ import (
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -19,11 +20,19 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AuditProfileResource{}
 var _ resource.ResourceWithImportState = &AuditProfileResource{}
+var _ resource.ResourceWithIdentity = &AuditProfileResource{}
+var _ resource.ResourceWithModifyPlan = &AuditProfileResource{}
+var _ base.ResourceWithReferences = &AuditProfileResource{}
+var _ list.ListResourceWithConfigure = &AuditProfileResource{}
 
 func NewAuditProfileResource() resource.Resource {
 	return &AuditProfileResource{}
 }
 
+func NewAuditProfileListResource() list.ListResource {
+	return &AuditProfileResource{}
+}
+
 // AuditProfileResource defines the resource implementation.
 type AuditProfileResource struct {
 	base.GenericIgnitionResource[client.AuditProfileConfig, AuditProfileResourceModel]
@@ -32,6 +41,7 @@ type AuditProfileResource struct {
 // AuditProfileResourceModel describes the resource data model.
 type AuditProfileResourceModel struct {
 	base.BaseResourceModel
+	base.CollectionResourceModel
 	Type                  types.String `tfsdk:"type"`
 	RetentionDays         types.Int64  `tfsdk:"retention_days"`
 	Database              types.String `tfsdk:"database"`
@@ -45,6 +55,7 @@ type AuditProfileResourceModel struct {
 
 func (r *AuditProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_audit_profile"
+	base.AllowRenames(resp)
 }
 
 func (r *AuditProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -54,15 +65,12 @@ func (r *AuditProfileResource) Schema(ctx context.Context, req resource.SchemaRe
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the audit profile.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Description: "The description of the audit profile.",
@@ -119,6 +127,7 @@ func (r *AuditProfileResource) Schema(ctx context.Context, req resource.SchemaRe
 				Optional:    true,
 				Computed:    true,
 			},
+			"collection": base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Description: "The signature of the resource, used for updates and deletes.",
 				Computed:    true,
@@ -149,6 +158,8 @@ func (r *AuditProfileResource) Configure(ctx context.Context, req resource.Confi
 	r.GetFunc = apiClient.GetAuditProfile
 	r.UpdateFunc = apiClient.UpdateAuditProfile
 	r.DeleteFunc = apiClient.DeleteAuditProfile
+	r.References = base.ReferenceValidatorFrom(req.ProviderData)
+	r.Schemas = base.SchemaValidatorFrom(req.ProviderData)
 }
 
 func (r *AuditProfileResource) MapPlanToClient(ctx context.Context, model *AuditProfileResourceModel) (client.AuditProfileConfig, error) {
@@ -242,11 +253,42 @@ func (r *AuditProfileResource) Delete(ctx context.Context, req resource.DeleteRe
 	r.GenericIgnitionResource.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
 
+func (r *AuditProfileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.CheckReferences(ctx, req, resp, r.ReferenceAttributes()...)
+	r.CheckSchema(ctx, req, resp)
+}
+
+func (r *AuditProfileResource) ReferenceAttributes() []base.Reference {
+	return []base.Reference{
+		{Path: path.Root("database"), Kind: "database connection", Module: "ignition", ResourceType: "database-connection"},
+	}
+}
+
+func (r *AuditProfileResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
 func (r *AuditProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.GenericIgnitionResource.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &AuditProfileResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
 	})...)
 }
+
+func (r *AuditProfileResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists Audit Profiles configured on the gateway.")
+}
+
+func (r *AuditProfileResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.GenericIgnitionResource.List(ctx, req, stream, func(_ *client.ResourceResponse[client.AuditProfileConfig]) (*AuditProfileResourceModel, *base.BaseResourceModel) {
+		var data AuditProfileResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
@@ -3,13 +3,24 @@ package resources
 import (
 	"context"
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -19,11 +30,20 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &DatabaseConnectionResource{}
 var _ resource.ResourceWithImportState = &DatabaseConnectionResource{}
+var _ resource.ResourceWithIdentity = &DatabaseConnectionResource{}
+var _ resource.ResourceWithModifyPlan = &DatabaseConnectionResource{}
+var _ base.ResourceWithReferences = &DatabaseConnectionResource{}
+var _ base.ResourceWithSecrets = &DatabaseConnectionResource{}
+var _ list.ListResourceWithConfigure = &DatabaseConnectionResource{}
 
 func NewDatabaseConnectionResource() resource.Resource {
 	return &DatabaseConnectionResource{}
 }
 
+func NewDatabaseConnectionListResource() list.ListResource {
+	return &DatabaseConnectionResource{}
+}
+
 // DatabaseConnectionResource defines the resource implementation.
 type DatabaseConnectionResource struct {
 	base.GenericIgnitionResource[client.DatabaseConfig, DatabaseConnectionResourceModel]
@@ -32,15 +52,65 @@ type DatabaseConnectionResource struct {
 // DatabaseConnectionResourceModel describes the resource data model.
 type DatabaseConnectionResourceModel struct {
 	base.BaseResourceModel
+	base.CollectionResourceModel
 	Type       types.String `tfsdk:"type"`
 	Translator types.String `tfsdk:"translator"`
 	ConnectURL types.String `tfsdk:"connect_url"`
 	Username   types.String `tfsdk:"username"`
 	Password   types.String `tfsdk:"password"`
+	// Advanced settings, which keep the gateway's defaults when left out
+	ConnectionProperties    types.Map    `tfsdk:"connection_properties"`
+	DefaultTransactionLevel types.String `tfsdk:"default_transaction_level"`
+	PoolInitSize            types.Int64  `tfsdk:"pool_init_size"`
+	PoolMaxActive           types.Int64  `tfsdk:"pool_max_active"`
+	PoolMaxIdle             types.Int64  `tfsdk:"pool_max_idle"`
+	PoolMinIdle             types.Int64  `tfsdk:"pool_min_idle"`
+	PoolMaxWait             types.Int64  `tfsdk:"pool_max_wait"`
+	ValidationQuery         types.String `tfsdk:"validation_query"`
+	ValidationInterval      types.Int64  `tfsdk:"validation_interval"`
+	TestOnBorrow            types.Bool   `tfsdk:"test_on_borrow"`
+	TestOnReturn            types.Bool   `tfsdk:"test_on_return"`
+	TestWhileIdle           types.Bool   `tfsdk:"test_while_idle"`
+	EvictionRate            types.Int64  `tfsdk:"eviction_rate"`
+	EvictionTests           types.Int64  `tfsdk:"eviction_tests"`
+	EvictionTime            types.Int64  `tfsdk:"eviction_time"`
+	SlowQueryThreshold      types.Int64  `tfsdk:"slow_query_threshold"`
+	FailoverDatasource      types.String `tfsdk:"failover_datasource"`
+	FailoverMode            types.String `tfsdk:"failover_mode"`
+}
+
+// gatewayDefaultInt64 is an advanced setting that keeps the gateway's default,
+// or its current value, when left out
+func gatewayDefaultInt64(description string, min int64) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description: description,
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.UseStateForUnknown(),
+		},
+		Validators: []validator.Int64{
+			int64validator.AtLeast(min),
+		},
+	}
+}
+
+// gatewayDefaultBool is an advanced setting that keeps the gateway's default,
+// or its current value, when left out
+func gatewayDefaultBool(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: description,
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: []planmodifier.Bool{
+			boolplanmodifier.UseStateForUnknown(),
+		},
+	}
 }
 
 func (r *DatabaseConnectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_database_connection"
+	base.AllowRenames(resp)
 }
 
 func (r *DatabaseConnectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -50,15 +120,12 @@ func (r *DatabaseConnectionResource) Schema(ctx context.Context, req resource.Sc
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the database connection.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Description: "The description of the database connection.",
@@ -71,21 +138,17 @@ func (r *DatabaseConnectionResource) Schema(ctx context.Context, req resource.Sc
 				Default:     booldefault.StaticBool(true),
 			},
 			"type": schema.StringAttribute{
-				Description: "The type of the database connection (e.g., MariaDB, PostgreSQL). Maps to 'driver' in Ignition.",
-				Required:    true,
+				Description: "The name of the JDBC driver of the connection (e.g., MariaDB, PostgreSQL), either built in or " +
+					"managed with ignition_jdbc_driver. Maps to 'driver' in Ignition.",
+				Required: true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(
-						"MariaDB",
-						"PostgreSQL",
-						"MySQL",
-						"SQLServer",
-						"Oracle",
-					),
+					stringvalidator.LengthAtLeast(1),
 				},
 			},
 			"translator": schema.StringAttribute{
-				Description: "The SQL translator used to negotiate variances in syntax (e.g., MYSQL, POSTGRESQL).",
-				Required:    true,
+				Description: "The SQL translator used to negotiate variances in syntax (e.g., MYSQL, POSTGRESQL), either " +
+					"built in or managed with ignition_database_translator.",
+				Required: true,
 			},
 			"connect_url": schema.StringAttribute{
 				Description: "The JDBC connection URL.",
@@ -100,6 +163,78 @@ func (r *DatabaseConnectionResource) Schema(ctx context.Context, req resource.Sc
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"connection_properties": schema.MapAttribute{
+				Description: "Extra JDBC connection properties, passed to the driver. The gateway stores them as " +
+					"key=value pairs separated by semicolons, so keys cannot contain ';' or '=', values cannot contain ';', " +
+					"and neither can have leading or trailing whitespace.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[^;=\s]([^;=]*[^;=\s])?$`),
+						"must be non-empty, must not contain ';' or '=' and must not have leading or trailing whitespace",
+					)),
+					mapvalidator.ValueStringsAre(stringvalidator.RegexMatches(
+						regexp.MustCompile(`^([^;\s]([^;]*[^;\s])?)?$`),
+						"must not contain ';' and must not have leading or trailing whitespace",
+					)),
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_transaction_level": schema.StringAttribute{
+				Description: "The transaction isolation level of connections (DEFAULT, NONE, READ_COMMITTED, " +
+					"READ_UNCOMMITTED, REPEATABLE_READ, SERIALIZABLE).",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("DEFAULT", "NONE", "READ_COMMITTED", "READ_UNCOMMITTED", "REPEATABLE_READ", "SERIALIZABLE"),
+				},
+			},
+			"pool_init_size":  gatewayDefaultInt64("The number of connections opened when the pool starts.", 0),
+			"pool_max_active": gatewayDefaultInt64("The maximum number of open connections, or -1 for no limit.", -1),
+			"pool_max_idle":   gatewayDefaultInt64("The maximum number of idle connections, or -1 for no limit.", -1),
+			"pool_min_idle":   gatewayDefaultInt64("The minimum number of idle connections.", 0),
+			"pool_max_wait":   gatewayDefaultInt64("The maximum milliseconds to wait for a free connection, or -1 to wait indefinitely.", -1),
+			"validation_query": schema.StringAttribute{
+				Description: "The query that validates connections (e.g., SELECT 1).",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"validation_interval":  gatewayDefaultInt64("The milliseconds between checks of whether a faulted connection is valid again.", 1),
+			"test_on_borrow":       gatewayDefaultBool("Whether connections are validated before they are borrowed from the pool."),
+			"test_on_return":       gatewayDefaultBool("Whether connections are validated when they are returned to the pool."),
+			"test_while_idle":      gatewayDefaultBool("Whether idle connections are validated by the evictor."),
+			"eviction_rate":        gatewayDefaultInt64("The milliseconds between runs of the idle connection evictor, or -1 to disable it.", -1),
+			"eviction_tests":       gatewayDefaultInt64("The number of connections checked by each run of the evictor.", 0),
+			"eviction_time":        gatewayDefaultInt64("The milliseconds a connection may be idle before it can be evicted.", 0),
+			"slow_query_threshold": gatewayDefaultInt64("The milliseconds after which queries are logged as slow.", 0),
+			"failover_datasource": schema.StringAttribute{
+				Description: "The name of the database connection used when this one is faulted.",
+				Optional:    true,
+			},
+			"failover_mode": schema.StringAttribute{
+				Description: "How the connection fails over (STANDARD, which stays on the failover connection until " +
+					"the gateway restarts or the connection is edited, or OPPORTUNISTIC, which returns as soon as " +
+					"this connection is available).",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("STANDARD", "OPPORTUNISTIC"),
+				},
+			},
+			"collection": base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Description: "The signature of the resource, used for updates and deletes.",
 				Computed:    true,
@@ -130,6 +265,8 @@ func (r *DatabaseConnectionResource) Configure(ctx context.Context, req resource
 	r.GetFunc = apiClient.GetDatabaseConnection
 	r.UpdateFunc = apiClient.UpdateDatabaseConnection
 	r.DeleteFunc = apiClient.DeleteDatabaseConnection
+	r.References = base.ReferenceValidatorFrom(req.ProviderData)
+	r.Schemas = base.SchemaValidatorFrom(req.ProviderData)
 }
 
 func (r *DatabaseConnectionResource) MapPlanToClient(ctx context.Context, model *DatabaseConnectionResourceModel) (client.DatabaseConfig, error) {
@@ -150,6 +287,32 @@ func (r *DatabaseConnectionResource) MapPlanToClient(ctx context.Context, model
 		config.Password = encrypted
 	}
 
+	if !model.ConnectionProperties.IsNull() && !model.ConnectionProperties.IsUnknown() {
+		var properties map[string]string
+		if diags := model.ConnectionProperties.ElementsAs(ctx, &properties, false); diags.HasError() {
+			return client.DatabaseConfig{}, fmt.Errorf("invalid connection_properties: %v", diags)
+		}
+		encoded := encodeConnectionProperties(properties)
+		config.ConnectionProps = &encoded
+	}
+	config.DefaultTransactionLevel = base.StringPointer(model.DefaultTransactionLevel)
+	config.PoolInitSize = base.Int64Pointer(model.PoolInitSize)
+	config.PoolMaxActive = base.Int64Pointer(model.PoolMaxActive)
+	config.PoolMaxIdle = base.Int64Pointer(model.PoolMaxIdle)
+	config.PoolMinIdle = base.Int64Pointer(model.PoolMinIdle)
+	config.PoolMaxWait = base.Int64Pointer(model.PoolMaxWait)
+	config.ValidationQuery = base.StringPointer(model.ValidationQuery)
+	config.ValidationSleepTime = base.Int64Pointer(model.ValidationInterval)
+	config.TestOnBorrow = base.BoolPointer(model.TestOnBorrow)
+	config.TestOnReturn = base.BoolPointer(model.TestOnReturn)
+	config.TestWhileIdle = base.BoolPointer(model.TestWhileIdle)
+	config.EvictionRate = base.Int64Pointer(model.EvictionRate)
+	config.EvictionTests = base.Int64Pointer(model.EvictionTests)
+	config.EvictionTime = base.Int64Pointer(model.EvictionTime)
+	config.SlowQueryLogThreshold = base.Int64Pointer(model.SlowQueryThreshold)
+	config.FailoverProfile = model.FailoverDatasource.ValueString()
+	config.FailoverMode = base.StringPointer(model.FailoverMode)
+
 	return config, nil
 }
 
@@ -171,11 +334,60 @@ func (r *DatabaseConnectionResource) MapClientToState(ctx context.Context, name
 		model.Username = types.StringNull()
 	}
 
+	model.ConnectionProperties = types.MapNull(types.StringType)
+	if config.ConnectionProps != nil {
+		properties, diags := types.MapValueFrom(ctx, types.StringType, decodeConnectionProperties(*config.ConnectionProps))
+		if diags.HasError() {
+			return fmt.Errorf("invalid connection properties: %v", diags)
+		}
+		model.ConnectionProperties = properties
+	}
+	model.DefaultTransactionLevel = types.StringPointerValue(config.DefaultTransactionLevel)
+	model.PoolInitSize = types.Int64PointerValue(config.PoolInitSize)
+	model.PoolMaxActive = types.Int64PointerValue(config.PoolMaxActive)
+	model.PoolMaxIdle = types.Int64PointerValue(config.PoolMaxIdle)
+	model.PoolMinIdle = types.Int64PointerValue(config.PoolMinIdle)
+	model.PoolMaxWait = types.Int64PointerValue(config.PoolMaxWait)
+	model.ValidationQuery = types.StringPointerValue(config.ValidationQuery)
+	model.ValidationInterval = types.Int64PointerValue(config.ValidationSleepTime)
+	model.TestOnBorrow = types.BoolPointerValue(config.TestOnBorrow)
+	model.TestOnReturn = types.BoolPointerValue(config.TestOnReturn)
+	model.TestWhileIdle = types.BoolPointerValue(config.TestWhileIdle)
+	model.EvictionRate = types.Int64PointerValue(config.EvictionRate)
+	model.EvictionTests = types.Int64PointerValue(config.EvictionTests)
+	model.EvictionTime = types.Int64PointerValue(config.EvictionTime)
+	model.SlowQueryThreshold = types.Int64PointerValue(config.SlowQueryLogThreshold)
+	model.FailoverDatasource = base.StringToNullableString(config.FailoverProfile)
+	model.FailoverMode = types.StringPointerValue(config.FailoverMode)
+
 	// Ensure signature is preserved
 	// The signature is handled by the generic base if provided in the response
 	return nil
 }
 
+// encodeConnectionProperties encodes JDBC connection properties as the gateway
+// stores them, sorted by key so that the encoding is stable
+func encodeConnectionProperties(properties map[string]string) string {
+	pairs := make([]string, 0, len(properties))
+	for _, key := range slices.Sorted(maps.Keys(properties)) {
+		pairs = append(pairs, key+"="+properties[key])
+	}
+	return strings.Join(pairs, ";")
+}
+
+// decodeConnectionProperties decodes JDBC connection properties, skipping
+// empty pairs such as those of a trailing semicolon
+func decodeConnectionProperties(encoded string) map[string]string {
+	properties := map[string]string{}
+	for _, pair := range strings.Split(encoded, ";") {
+		key, value, _ := strings.Cut(pair, "=")
+		if key = strings.TrimSpace(key); key != "" {
+			properties[key] = strings.TrimSpace(value)
+		}
+	}
+	return properties
+}
+
 func (r *DatabaseConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data DatabaseConnectionResourceModel
 	r.GenericIgnitionResource.Create(ctx, req, resp, &data, &data.BaseResourceModel)
@@ -196,11 +408,51 @@ func (r *DatabaseConnectionResource) Delete(ctx context.Context, req resource.De
 	r.GenericIgnitionResource.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
 
+func (r *DatabaseConnectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.CheckReferences(ctx, req, resp, r.ReferenceAttributes()...)
+	r.CheckSchema(ctx, req, resp)
+}
+
+func (r *DatabaseConnectionResource) ReferenceAttributes() []base.Reference {
+	return []base.Reference{
+		{Path: path.Root("type"), Kind: "JDBC driver", Module: "ignition", ResourceType: "database-driver"},
+		{Path: path.Root("translator"), Kind: "database translator", Module: "ignition", ResourceType: "database-translator"},
+		{Path: path.Root("failover_datasource"), Kind: "database connection", Module: "ignition", ResourceType: "database-connection"},
+	}
+}
+
+func (r *DatabaseConnectionResource) SecretAttributes() []base.Secret {
+	return []base.Secret{
+		{Path: path.Root("password"), Key: "password"},
+	}
+}
+
+func (r *DatabaseConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
 func (r *DatabaseConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.GenericIgnitionResource.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &DatabaseConnectionResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
+		ConnectionProperties: types.MapNull(types.StringType),
 	})...)
 }
+
+func (r *DatabaseConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists Database Connections configured on the gateway.")
+}
+
+func (r *DatabaseConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.GenericIgnitionResource.List(ctx, req, stream, func(_ *client.ResourceResponse[client.DatabaseConfig]) (*DatabaseConnectionResourceModel, *base.BaseResourceModel) {
+		var data DatabaseConnectionResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
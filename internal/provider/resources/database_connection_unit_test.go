@@ -6,7 +6,12 @@ import (
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
@@ -76,3 +81,104 @@ func TestUnitDatabaseConnectionResource(t *testing.T) {
 		},
 	})
 }
+
+func TestUnitDatabaseConnectionAdvancedSettings(t *testing.T) {
+	ctx := context.Background()
+	r := &DatabaseConnectionResource{}
+
+	properties, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"ssl": "true", "connectTimeout": "10"})
+	model := DatabaseConnectionResourceModel{
+		Type:                 types.StringValue("PostgreSQL"),
+		ConnectURL:           types.StringValue("jdbc:postgresql://localhost:5432/test"),
+		Username:             types.StringNull(),
+		Password:             types.StringNull(),
+		ConnectionProperties: properties,
+		PoolMaxActive:        types.Int64Value(16),
+		PoolMaxWait:          types.Int64Unknown(),
+		TestOnBorrow:         types.BoolValue(false),
+		FailoverDatasource:   types.StringValue("Backup"),
+		FailoverMode:         types.StringValue("OPPORTUNISTIC"),
+	}
+	config, err := r.MapPlanToClient(ctx, &model)
+	if err != nil {
+		t.Fatalf("MapPlanToClient failed: %v", err)
+	}
+	if config.ConnectionProps == nil || *config.ConnectionProps != "connectTimeout=10;ssl=true" {
+		t.Errorf("Expected sorted connection properties, got %v", config.ConnectionProps)
+	}
+	if config.PoolMaxActive == nil || *config.PoolMaxActive != 16 || config.TestOnBorrow == nil || *config.TestOnBorrow {
+		t.Errorf("Unexpected pool settings: %+v", config)
+	}
+	// Settings left out keep the gateway's defaults
+	if config.PoolMaxWait != nil || config.PoolInitSize != nil || config.ValidationQuery != nil {
+		t.Errorf("Expected unset settings to be omitted, got %+v", config)
+	}
+	if config.FailoverProfile != "Backup" || *config.FailoverMode != "OPPORTUNISTIC" {
+		t.Errorf("Unexpected failover settings: %+v", config)
+	}
+
+	props, maxWait := "ssl=true; connectTimeout=10;", int64(5000)
+	gateway := client.DatabaseConfig{
+		Driver:          "PostgreSQL",
+		ConnectionProps: &props,
+		PoolMaxWait:     &maxWait,
+		TestOnBorrow:    base.BoolPtr(true),
+	}
+	if err := r.MapClientToState(ctx, "TestDB", &gateway, &model); err != nil {
+		t.Fatalf("MapClientToState failed: %v", err)
+	}
+	var state map[string]string
+	model.ConnectionProperties.ElementsAs(ctx, &state, false)
+	if len(state) != 2 || state["ssl"] != "true" || state["connectTimeout"] != "10" {
+		t.Errorf("Unexpected connection properties: %v", state)
+	}
+	if model.PoolMaxWait.ValueInt64() != 5000 || !model.TestOnBorrow.ValueBool() || !model.PoolMaxActive.IsNull() {
+		t.Errorf("Unexpected pool state: %+v", model)
+	}
+	if !model.FailoverDatasource.IsNull() || !model.FailoverMode.IsNull() {
+		t.Errorf("Expected no failover, got %v and %v", model.FailoverDatasource, model.FailoverMode)
+	}
+}
+
+func TestUnitDatabaseConnectionPropertiesValidation(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp fwresource.SchemaResponse
+	(&DatabaseConnectionResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	attribute := schemaResp.Schema.Attributes["connection_properties"].(schema.MapAttribute)
+
+	validate := func(properties map[string]string) bool {
+		value, _ := types.MapValueFrom(ctx, types.StringType, properties)
+		req := validator.MapRequest{Path: path.Root("connection_properties"), ConfigValue: value}
+		var resp validator.MapResponse
+		for _, v := range attribute.MapValidators() {
+			v.ValidateMap(ctx, req, &resp)
+		}
+		return !resp.Diagnostics.HasError()
+	}
+
+	// Properties that survive the gateway's key=value;... encoding
+	for _, valid := range []map[string]string{
+		{"ssl": "true", "connectTimeout": "10"},
+		{"options": "-c search_path=app"},
+		{"empty": ""},
+	} {
+		if !validate(valid) {
+			t.Errorf("Expected %v to be valid", valid)
+		}
+	}
+
+	for _, invalid := range []map[string]string{
+		{"a;b": "1"},
+		{"a=b": "1"},
+		{" ssl": "true"},
+		{"ssl ": "true"},
+		{"": "true"},
+		{"ssl": "true;x=1"},
+		{"ssl": " true"},
+		{"ssl": "true\n"},
+	} {
+		if validate(invalid) {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DatabaseTranslatorResource{}
+var _ resource.ResourceWithImportState = &DatabaseTranslatorResource{}
+var _ resource.ResourceWithIdentity = &DatabaseTranslatorResource{}
+var _ resource.ResourceWithModifyPlan = &DatabaseTranslatorResource{}
+var _ list.ListResourceWithConfigure = &DatabaseTranslatorResource{}
+
+func NewDatabaseTranslatorResource() resource.Resource {
+	return &DatabaseTranslatorResource{}
+}
+
+func NewDatabaseTranslatorListResource() list.ListResource {
+	return &DatabaseTranslatorResource{}
+}
+
+// DatabaseTranslatorResource defines the resource implementation.
+type DatabaseTranslatorResource struct {
+	client  client.IgnitionClient
+	generic base.GenericIgnitionResource[client.DatabaseTranslatorConfig, DatabaseTranslatorResourceModel]
+}
+
+// DatabaseTranslatorResourceModel describes the resource data model.
+type DatabaseTranslatorResourceModel struct {
+	base.BaseResourceModel
+	base.CollectionResourceModel
+	AlterTable            types.String `tfsdk:"alter_table"`
+	AlterTableColumnDef   types.String `tfsdk:"alter_table_column_def"`
+	AutoIncTypeDef        types.String `tfsdk:"auto_inc_type_def"`
+	BlobType              types.String `tfsdk:"blob_type"`
+	BoolType              types.String `tfsdk:"bool_type"`
+	ColumnQuoteChar       types.String `tfsdk:"column_quote_char"`
+	CreateAutoIncSequence types.String `tfsdk:"create_auto_inc_sequence"`
+	CreateAutoIncTrigger  types.String `tfsdk:"create_auto_inc_trigger"`
+	CreateIndex           types.String `tfsdk:"create_index"`
+	CreateTable           types.String `tfsdk:"create_table"`
+	CurrentTimeQuery      types.String `tfsdk:"current_time_query"`
+	DatetimeType          types.String `tfsdk:"datetime_type"`
+	FetchKeyQuery         types.String `tfsdk:"fetch_key_query"`
+	I1Type                types.String `tfsdk:"i1_type"`
+	I2Type                types.String `tfsdk:"i2_type"`
+	I4Type                types.String `tfsdk:"i4_type"`
+	I8Type                types.String `tfsdk:"i8_type"`
+	Limit                 types.String `tfsdk:"limit"`
+	LimitClausePosition   types.String `tfsdk:"limit_clause_position"`
+	PrimaryKeyDef         types.String `tfsdk:"primary_key_def"`
+	R4Type                types.String `tfsdk:"r4_type"`
+	R8Type                types.String `tfsdk:"r8_type"`
+	StringType            types.String `tfsdk:"string_type"`
+	SupportsRGK           types.Bool   `tfsdk:"supports_rgk"`
+	TableListFilter       types.String `tfsdk:"table_list_filter"`
+	TextType              types.String `tfsdk:"text_type"`
+}
+
+func (r *DatabaseTranslatorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database_translator"
+	base.AllowRenames(resp)
+}
+
+func (r *DatabaseTranslatorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a database translator in Ignition.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					base.UseNameForID(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the database translator.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the database translator.",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the database translator is enabled.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"alter_table": schema.StringAttribute{
+				Description: "The ALTER TABLE statement, with {tablename} and {alterdef} placeholders.",
+				Optional:    true,
+			},
+			"alter_table_column_def": schema.StringAttribute{
+				Description: "The definition that adds a column in an ALTER TABLE statement, with {columnname} and {type} placeholders.",
+				Optional:    true,
+			},
+			"auto_inc_type_def": schema.StringAttribute{
+				Description: "The definition of an auto-incrementing column, with a {type} placeholder.",
+				Optional:    true,
+			},
+			"blob_type": schema.StringAttribute{
+				Description: "The SQL type of binary columns.",
+				Optional:    true,
+			},
+			"bool_type": schema.StringAttribute{
+				Description: "The SQL type of boolean columns.",
+				Optional:    true,
+			},
+			"column_quote_char": schema.StringAttribute{
+				Description: "The character that quotes column names.",
+				Optional:    true,
+			},
+			"create_auto_inc_sequence": schema.StringAttribute{
+				Description: "The statement that creates the sequence of an auto-incrementing column, with a {tablename} placeholder, for databases without auto-incrementing columns.",
+				Optional:    true,
+			},
+			"create_auto_inc_trigger": schema.StringAttribute{
+				Description: "The statement that creates the trigger of an auto-incrementing column, with {tablename} and {columnname} placeholders, for databases without auto-incrementing columns.",
+				Optional:    true,
+			},
+			"create_index": schema.StringAttribute{
+				Description: "The CREATE INDEX statement, with {indexname}, {tablename} and {columnname} placeholders.",
+				Optional:    true,
+			},
+			"create_table": schema.StringAttribute{
+				Description: "The CREATE TABLE statement, with {tablename}, {creationdef} and {primarykeydef} placeholders.",
+				Required:    true,
+			},
+			"current_time_query": schema.StringAttribute{
+				Description: "The query that returns the current time of the database.",
+				Optional:    true,
+			},
+			"datetime_type": schema.StringAttribute{
+				Description: "The SQL type of date columns.",
+				Optional:    true,
+			},
+			"fetch_key_query": schema.StringAttribute{
+				Description: "The query that returns the last generated key, for databases that do not return generated keys.",
+				Optional:    true,
+			},
+			"i1_type": schema.StringAttribute{
+				Description: "The SQL type of 1-byte integer columns.",
+				Optional:    true,
+			},
+			"i2_type": schema.StringAttribute{
+				Description: "The SQL type of 2-byte integer columns.",
+				Optional:    true,
+			},
+			"i4_type": schema.StringAttribute{
+				Description: "The SQL type of 4-byte integer columns.",
+				Optional:    true,
+			},
+			"i8_type": schema.StringAttribute{
+				Description: "The SQL type of 8-byte integer columns.",
+				Optional:    true,
+			},
+			"limit": schema.StringAttribute{
+				Description: "The clause that limits the number of rows returned, with a {limit} placeholder.",
+				Optional:    true,
+			},
+			"limit_clause_position": schema.StringAttribute{
+				Description: "Where the limit clause is placed in a query. One of Back, Front, Wrap. Defaults to Back.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("Back"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("Back", "Front", "Wrap"),
+				},
+			},
+			"primary_key_def": schema.StringAttribute{
+				Description: "The primary key definition, with a {columnname} placeholder.",
+				Optional:    true,
+			},
+			"r4_type": schema.StringAttribute{
+				Description: "The SQL type of 4-byte float columns.",
+				Optional:    true,
+			},
+			"r8_type": schema.StringAttribute{
+				Description: "The SQL type of 8-byte float columns.",
+				Optional:    true,
+			},
+			"string_type": schema.StringAttribute{
+				Description: "The SQL type of string columns.",
+				Optional:    true,
+			},
+			"supports_rgk": schema.BoolAttribute{
+				Description: "Whether the database returns generated keys. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"table_list_filter": schema.StringAttribute{
+				Description: "Patterns of table names left out of table listings, separated by semicolons (e.g., SYS_*).",
+				Optional:    true,
+			},
+			"text_type": schema.StringAttribute{
+				Description: "The SQL type of text columns.",
+				Optional:    true,
+			},
+			"collection": base.CollectionAttribute(),
+			"signature": schema.StringAttribute{
+				Description: "The signature of the resource.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *DatabaseTranslatorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(client.IgnitionClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.IgnitionClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = c
+	r.generic = base.GenericIgnitionResource[client.DatabaseTranslatorConfig, DatabaseTranslatorResourceModel]{
+		Client:       c,
+		Handler:      r,
+		Module:       "ignition",
+		ResourceType: "database-translator",
+		CreateFunc:   c.CreateDatabaseTranslator,
+		GetFunc:      c.GetDatabaseTranslator,
+		UpdateFunc:   c.UpdateDatabaseTranslator,
+		DeleteFunc:   c.DeleteDatabaseTranslator,
+		Schemas:      base.SchemaValidatorFrom(req.ProviderData),
+	}
+}
+
+func (r *DatabaseTranslatorResource) MapPlanToClient(ctx context.Context, model *DatabaseTranslatorResourceModel) (client.DatabaseTranslatorConfig, error) {
+	return client.DatabaseTranslatorConfig{
+		AlterTable:            model.AlterTable.ValueString(),
+		AlterTableColumnDef:   model.AlterTableColumnDef.ValueString(),
+		AutoIncTypeDef:        model.AutoIncTypeDef.ValueString(),
+		BlobType:              model.BlobType.ValueString(),
+		BoolType:              model.BoolType.ValueString(),
+		ColumnQuoteChar:       model.ColumnQuoteChar.ValueString(),
+		CreateAutoIncSequence: model.CreateAutoIncSequence.ValueString(),
+		CreateAutoIncTrigger:  model.CreateAutoIncTrigger.ValueString(),
+		CreateIndex:           model.CreateIndex.ValueString(),
+		CreateTable:           model.CreateTable.ValueString(),
+		CurrentTimeQuery:      model.CurrentTimeQuery.ValueString(),
+		DatetimeType:          model.DatetimeType.ValueString(),
+		FetchKeyQuery:         model.FetchKeyQuery.ValueString(),
+		I1Type:                model.I1Type.ValueString(),
+		I2Type:                model.I2Type.ValueString(),
+		I4Type:                model.I4Type.ValueString(),
+		I8Type:                model.I8Type.ValueString(),
+		Limit:                 model.Limit.ValueString(),
+		LimitClausePosition:   model.LimitClausePosition.ValueString(),
+		PrimaryKeyDef:         model.PrimaryKeyDef.ValueString(),
+		R4Type:                model.R4Type.ValueString(),
+		R8Type:                model.R8Type.ValueString(),
+		StringType:            model.StringType.ValueString(),
+		SupportsRGK:           model.SupportsRGK.ValueBool(),
+		TableListFilter:       model.TableListFilter.ValueString(),
+		TextType:              model.TextType.ValueString(),
+	}, nil
+}
+
+func (r *DatabaseTranslatorResource) MapClientToState(ctx context.Context, name string, config *client.DatabaseTranslatorConfig, model *DatabaseTranslatorResourceModel) error {
+	model.Name = types.StringValue(name)
+	model.AlterTable = base.StringToNullableString(config.AlterTable)
+	model.AlterTableColumnDef = base.StringToNullableString(config.AlterTableColumnDef)
+	model.AutoIncTypeDef = base.StringToNullableString(config.AutoIncTypeDef)
+	model.BlobType = base.StringToNullableString(config.BlobType)
+	model.BoolType = base.StringToNullableString(config.BoolType)
+	model.ColumnQuoteChar = base.StringToNullableString(config.ColumnQuoteChar)
+	model.CreateAutoIncSequence = base.StringToNullableString(config.CreateAutoIncSequence)
+	model.CreateAutoIncTrigger = base.StringToNullableString(config.CreateAutoIncTrigger)
+	model.CreateIndex = base.StringToNullableString(config.CreateIndex)
+	model.CreateTable = types.StringValue(config.CreateTable)
+	model.CurrentTimeQuery = base.StringToNullableString(config.CurrentTimeQuery)
+	model.DatetimeType = base.StringToNullableString(config.DatetimeType)
+	model.FetchKeyQuery = base.StringToNullableString(config.FetchKeyQuery)
+	model.I1Type = base.StringToNullableString(config.I1Type)
+	model.I2Type = base.StringToNullableString(config.I2Type)
+	model.I4Type = base.StringToNullableString(config.I4Type)
+	model.I8Type = base.StringToNullableString(config.I8Type)
+	model.Limit = base.StringToNullableString(config.Limit)
+	model.LimitClausePosition = types.StringValue(config.LimitClausePosition)
+	model.PrimaryKeyDef = base.StringToNullableString(config.PrimaryKeyDef)
+	model.R4Type = base.StringToNullableString(config.R4Type)
+	model.R8Type = base.StringToNullableString(config.R8Type)
+	model.StringType = base.StringToNullableString(config.StringType)
+	model.SupportsRGK = types.BoolValue(config.SupportsRGK)
+	model.TableListFilter = base.StringToNullableString(config.TableListFilter)
+	model.TextType = base.StringToNullableString(config.TextType)
+	return nil
+}
+
+func (r *DatabaseTranslatorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DatabaseTranslatorResourceModel
+	r.generic.Create(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *DatabaseTranslatorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DatabaseTranslatorResourceModel
+	r.generic.Read(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *DatabaseTranslatorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DatabaseTranslatorResourceModel
+	r.generic.Update(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *DatabaseTranslatorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DatabaseTranslatorResourceModel
+	r.generic.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *DatabaseTranslatorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.generic.CheckSchema(ctx, req, resp)
+}
+
+func (r *DatabaseTranslatorResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
+func (r *DatabaseTranslatorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.generic.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &DatabaseTranslatorResourceModel{
+		BaseResourceModel: base.BaseResourceModel{
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
+		},
+	})...)
+}
+
+func (r *DatabaseTranslatorResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists the database translator resources configured on the gateway.")
+}
+
+func (r *DatabaseTranslatorResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.generic.List(ctx, req, stream, func(_ *client.ResourceResponse[client.DatabaseTranslatorConfig]) (*DatabaseTranslatorResourceModel, *base.BaseResourceModel) {
+		var data DatabaseTranslatorResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
@@ -0,0 +1,116 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnitDatabaseTranslatorResource(t *testing.T) {
+	var stored *client.ResourceResponse[client.DatabaseTranslatorConfig]
+	mockClient := &client.MockClient{
+		GeneratedMockFuncs: client.GeneratedMockFuncs{
+			CreateDatabaseTranslatorFunc: func(ctx context.Context, item client.ResourceResponse[client.DatabaseTranslatorConfig]) (*client.ResourceResponse[client.DatabaseTranslatorConfig], error) {
+				item.Signature = "sig-1"
+				stored = &item
+				return &item, nil
+			},
+			GetDatabaseTranslatorFunc: func(ctx context.Context, name string) (*client.ResourceResponse[client.DatabaseTranslatorConfig], error) {
+				return stored, nil
+			},
+			DeleteDatabaseTranslatorFunc: func(ctx context.Context, name, signature string) error {
+				return nil
+			},
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			ResourceFactory: NewDatabaseTranslatorResource,
+			Client:          mockClient,
+		}),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_database_translator" "test" {
+						name              = "SNOWFLAKE"
+						create_table      = "CREATE TABLE {tablename} ({creationdef}{primarykeydef})"
+						column_quote_char = "\""
+						limit             = "LIMIT {limit}"
+						i8_type           = "BIGINT"
+						string_type       = "VARCHAR"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_database_translator.test", "name", "SNOWFLAKE"),
+					resource.TestCheckResourceAttr("ignition_database_translator.test", "i8_type", "BIGINT"),
+					resource.TestCheckResourceAttr("ignition_database_translator.test", "limit_clause_position", "Back"),
+					resource.TestCheckResourceAttr("ignition_database_translator.test", "supports_rgk", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDatabaseTranslatorMapping(t *testing.T) {
+	ctx := context.Background()
+	r := &DatabaseTranslatorResource{}
+
+	model := DatabaseTranslatorResourceModel{
+		CreateTable:         types.StringValue("CREATE TABLE {tablename} ({creationdef}{primarykeydef})"),
+		R8Type:              types.StringValue("DOUBLE PRECISION"),
+		LimitClausePosition: types.StringValue("Back"),
+		SupportsRGK:         types.BoolValue(true),
+	}
+	config, err := r.MapPlanToClient(ctx, &model)
+	if err != nil {
+		t.Fatalf("MapPlanToClient failed: %v", err)
+	}
+	if config.R8Type != "DOUBLE PRECISION" || !config.SupportsRGK {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+
+	// The gateway stores unset statements as empty strings
+	if err := r.MapClientToState(ctx, "TIMESCALE", &config, &model); err != nil {
+		t.Fatalf("MapClientToState failed: %v", err)
+	}
+	if !model.FetchKeyQuery.IsNull() || model.Name.ValueString() != "TIMESCALE" {
+		t.Errorf("Unexpected state: %+v", model)
+	}
+}
+
+func TestUnitDatabaseTranslatorBackupRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c := backupClient(t)
+	r := &DatabaseTranslatorResource{}
+
+	for _, name := range []string{"MYSQL", "SQLITE", "ORACLE"} {
+		res, err := c.GetDatabaseTranslator(ctx, name)
+		if err != nil {
+			t.Fatalf("GetDatabaseTranslator failed: %v", err)
+		}
+		var model DatabaseTranslatorResourceModel
+		if err := r.MapClientToState(ctx, name, &res.Config, &model); err != nil {
+			t.Fatalf("MapClientToState failed: %v", err)
+		}
+		config, err := r.MapPlanToClient(ctx, &model)
+		if err != nil {
+			t.Fatalf("MapPlanToClient failed: %v", err)
+		}
+		assertRoundTrip(t, c, "database-translator", name, config)
+	}
+}
@@ -0,0 +1,223 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeploymentModeResource{}
+var _ resource.ResourceWithImportState = &DeploymentModeResource{}
+var _ resource.ResourceWithIdentity = &DeploymentModeResource{}
+var _ list.ListResourceWithConfigure = &DeploymentModeResource{}
+
+func NewDeploymentModeResource() resource.Resource {
+	return &DeploymentModeResource{}
+}
+
+func NewDeploymentModeListResource() list.ListResource {
+	return &DeploymentModeResource{}
+}
+
+// DeploymentModeResource defines the resource implementation.
+type DeploymentModeResource struct {
+	base.GenericIgnitionResource[client.DeploymentMode, DeploymentModeResourceModel]
+}
+
+// DeploymentModeResourceModel describes the resource data model.
+type DeploymentModeResourceModel struct {
+	base.BaseResourceModel
+	Title types.String `tfsdk:"title"`
+}
+
+func (r *DeploymentModeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment_mode"
+}
+
+func (r *DeploymentModeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Deployment Mode in Ignition. A deployment mode is a config collection whose resources " +
+			"override those of the core collection while the gateway runs in that mode. Resources are placed in it " +
+			"through their `collection` attribute.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					base.UseNameForID(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the deployment mode, which is also the name of its collection.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Description: "The title of the deployment mode.",
+				Optional:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the deployment mode.",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the deployment mode is enabled.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"signature": schema.StringAttribute{
+				Description: "The signature of the resource.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *DeploymentModeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClient, ok := req.ProviderData.(client.IgnitionClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.IgnitionClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	// Deployment modes have no signature of their own, so their name stands in for it
+	response := func(m *client.DeploymentMode) *client.ResourceResponse[client.DeploymentMode] {
+		return &client.ResourceResponse[client.DeploymentMode]{
+			Name:        m.Name,
+			Enabled:     &m.Enabled,
+			Description: m.Description,
+			Signature:   m.Name,
+			Config:      *m,
+		}
+	}
+
+	r.Client = apiClient
+	r.Handler = r
+	r.Module = "ignition"
+	r.ResourceType = "deployment-mode"
+	r.CreateFunc = func(ctx context.Context, res client.ResourceResponse[client.DeploymentMode]) (*client.ResourceResponse[client.DeploymentMode], error) {
+		m, err := apiClient.CreateDeploymentMode(ctx, res.Config)
+		if err != nil {
+			return nil, err
+		}
+		return response(m), nil
+	}
+	r.GetFunc = func(ctx context.Context, name string) (*client.ResourceResponse[client.DeploymentMode], error) {
+		m, err := apiClient.GetDeploymentMode(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		return response(m), nil
+	}
+	r.UpdateFunc = func(ctx context.Context, res client.ResourceResponse[client.DeploymentMode]) (*client.ResourceResponse[client.DeploymentMode], error) {
+		m, err := apiClient.UpdateDeploymentMode(ctx, res.Config)
+		if err != nil {
+			return nil, err
+		}
+		return response(m), nil
+	}
+	r.DeleteFunc = func(ctx context.Context, name, signature string) error {
+		return apiClient.DeleteDeploymentMode(ctx, name)
+	}
+	r.ListFunc = func(ctx context.Context) ([]string, error) {
+		modes, err := apiClient.ListDeploymentModes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(modes))
+		for _, m := range modes {
+			names = append(names, m.Name)
+		}
+		return names, nil
+	}
+}
+
+func (r *DeploymentModeResource) MapPlanToClient(ctx context.Context, model *DeploymentModeResourceModel) (client.DeploymentMode, error) {
+	m := client.DeploymentMode{
+		Name:    model.Name.ValueString(),
+		Enabled: model.Enabled.ValueBool(),
+	}
+	if !model.Title.IsNull() {
+		m.Title = model.Title.ValueString()
+	}
+	if !model.Description.IsNull() {
+		m.Description = model.Description.ValueString()
+	}
+	return m, nil
+}
+
+func (r *DeploymentModeResource) MapClientToState(ctx context.Context, name string, m *client.DeploymentMode, model *DeploymentModeResourceModel) error {
+	model.Name = types.StringValue(name)
+	model.Title = base.StringToNullableString(m.Title)
+	model.Description = base.StringToNullableString(m.Description)
+	model.Enabled = types.BoolValue(m.Enabled)
+	return nil
+}
+
+func (r *DeploymentModeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DeploymentModeResourceModel
+	r.GenericIgnitionResource.Create(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *DeploymentModeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DeploymentModeResourceModel
+	r.GenericIgnitionResource.Read(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *DeploymentModeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DeploymentModeResourceModel
+	r.GenericIgnitionResource.Update(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *DeploymentModeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DeploymentModeResourceModel
+	r.GenericIgnitionResource.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *DeploymentModeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
+func (r *DeploymentModeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.GenericIgnitionResource.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &DeploymentModeResourceModel{
+		BaseResourceModel: base.BaseResourceModel{
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
+		},
+	})...)
+}
+
+func (r *DeploymentModeResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists Deployment Modes configured on the gateway.")
+}
+
+func (r *DeploymentModeResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.GenericIgnitionResource.List(ctx, req, stream, func(_ *client.ResourceResponse[client.DeploymentMode]) (*DeploymentModeResourceModel, *base.BaseResourceModel) {
+		var data DeploymentModeResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
@@ -0,0 +1,142 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnitDeploymentModeResource(t *testing.T) {
+	var mockMode *client.DeploymentMode
+
+	mockClient := &client.MockClient{
+		CreateDeploymentModeFunc: func(ctx context.Context, m client.DeploymentMode) (*client.DeploymentMode, error) {
+			mockMode = &m
+			return mockMode, nil
+		},
+		GetDeploymentModeFunc: func(ctx context.Context, name string) (*client.DeploymentMode, error) {
+			return mockMode, nil
+		},
+		UpdateDeploymentModeFunc: func(ctx context.Context, m client.DeploymentMode) (*client.DeploymentMode, error) {
+			mockMode = &m
+			return mockMode, nil
+		},
+		DeleteDeploymentModeFunc: func(ctx context.Context, name string) error {
+			return nil
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			ResourceFactory: NewDeploymentModeResource,
+			Client:          mockClient,
+		}),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_deployment_mode" "test" {
+						name  = "dev"
+						title = "Development"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_deployment_mode.test", "name", "dev"),
+					resource.TestCheckResourceAttr("ignition_deployment_mode.test", "title", "Development"),
+					resource.TestCheckResourceAttr("ignition_deployment_mode.test", "enabled", "true"),
+				),
+			},
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_deployment_mode" "test" {
+						name        = "dev"
+						title       = "Development"
+						description = "Overrides for the development gateways"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_deployment_mode.test", "description", "Overrides for the development gateways"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitResourceCollection(t *testing.T) {
+	var mockTP *client.ResourceResponse[client.TagProviderConfig]
+
+	// Every request for the tag provider must be directed to its collection
+	checkCollection := func(ctx context.Context) error {
+		if collection := client.CollectionFrom(ctx); collection != "dev" {
+			return fmt.Errorf("expected a request to the dev collection, got %q", collection)
+		}
+		return nil
+	}
+
+	mockClient := &client.MockClient{
+		CreateTagProviderFunc: func(ctx context.Context, tp client.ResourceResponse[client.TagProviderConfig]) (*client.ResourceResponse[client.TagProviderConfig], error) {
+			if err := checkCollection(ctx); err != nil {
+				return nil, err
+			}
+			tp.Signature = "sig-1"
+			tp.Collection = "dev"
+			mockTP = &tp
+			return mockTP, nil
+		},
+		GetTagProviderFunc: func(ctx context.Context, name string) (*client.ResourceResponse[client.TagProviderConfig], error) {
+			if err := checkCollection(ctx); err != nil {
+				return nil, err
+			}
+			return mockTP, nil
+		},
+		DeleteTagProviderFunc: func(ctx context.Context, name, signature string) error {
+			return checkCollection(ctx)
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			ResourceFactory: NewTagProviderResource,
+			Client:          mockClient,
+		}),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_tag_provider" "test" {
+						name       = "plant"
+						type       = "STANDARD"
+						collection = "dev"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "collection", "dev"),
+				),
+			},
+		},
+	})
+}
@@ -7,6 +7,9 @@ import (
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -17,23 +20,32 @@ import (
 
 var _ resource.Resource = &DeviceResource{}
 var _ resource.ResourceWithImportState = &DeviceResource{}
+var _ resource.ResourceWithIdentity = &DeviceResource{}
+var _ resource.ResourceWithModifyPlan = &DeviceResource{}
+var _ list.ListResourceWithConfigure = &DeviceResource{}
 
 func NewDeviceResource() resource.Resource {
 	return &DeviceResource{}
 }
 
+func NewDeviceListResource() list.ListResource {
+	return &DeviceResource{}
+}
+
 type DeviceResource struct {
 	Res base.GenericIgnitionResource[client.DeviceConfig, DeviceResourceModel]
 }
 
 type DeviceResourceModel struct {
 	base.BaseResourceModel
-	Type       types.String `tfsdk:"type"`
-	Parameters types.String `tfsdk:"parameters"`
+	base.CollectionResourceModel
+	Type       types.String         `tfsdk:"type"`
+	Parameters jsontypes.Normalized `tfsdk:"parameters"`
 }
 
 func (r *DeviceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_device"
+	base.AllowRenames(resp)
 }
 
 func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -43,15 +55,12 @@ func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the device.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Description: "The description of the device.",
@@ -71,9 +80,12 @@ func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"parameters": schema.StringAttribute{
-				Description: "The JSON configuration parameters for the device. These vary by device type.",
-				Required:    true,
+				Description: "The JSON configuration parameters for the device. These vary by device type. " +
+					"Parameters populated with defaults by the gateway do not need to be specified.",
+				Required:   true,
+				CustomType: jsontypes.NormalizedType{IgnoreDefaults: true},
 			},
+			"collection": base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Description: "The signature of the resource, used for updates and deletes.",
 				Computed:    true,
@@ -104,6 +116,7 @@ func (r *DeviceResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.Res.GetFunc = client.GetDevice
 	r.Res.UpdateFunc = client.UpdateDevice
 	r.Res.DeleteFunc = client.DeleteDevice
+	r.Res.Schemas = base.SchemaValidatorFrom(req.ProviderData)
 }
 
 func (r *DeviceResource) MapPlanToClient(ctx context.Context, model *DeviceResourceModel) (client.DeviceConfig, error) {
@@ -120,27 +133,13 @@ func (r *DeviceResource) MapPlanToClient(ctx context.Context, model *DeviceResou
 func (r *DeviceResource) MapClientToState(ctx context.Context, name string, config *client.DeviceConfig, model *DeviceResourceModel) error {
 	model.Name = types.StringValue(name)
 
-	// Marshal the API response to canonical JSON
+	// Differences in formatting and server-populated defaults are reconciled
+	// by the semantic equality of the parameters type.
 	b, err := json.Marshal(config)
 	if err != nil {
 		return fmt.Errorf("failed to marshal parameters to JSON: %w", err)
 	}
-	canonicalAPI := string(b)
-
-	if !model.Parameters.IsNull() && !model.Parameters.IsUnknown() {
-		var modelConfig client.DeviceConfig
-		if err := json.Unmarshal([]byte(model.Parameters.ValueString()), &modelConfig); err == nil {
-			bModel, _ := json.Marshal(modelConfig)
-			if string(bModel) == canonicalAPI {
-				// They are semantically equal (ignoring whitespace), so keep the Plan value
-				// to satisfy Terraform's consistency check.
-				return nil
-			}
-		}
-	}
-
-	// If they differ (or model is null/unknown), accept the API's canonical value
-	model.Parameters = types.StringValue(canonicalAPI)
+	model.Parameters = jsontypes.NewNormalizedValue(string(b))
 
 	return nil
 }
@@ -152,6 +151,7 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = base.WithCollection(ctx, &data)
 
 	config, err := r.MapPlanToClient(ctx, &data)
 	if err != nil {
@@ -179,6 +179,7 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	data.Signature = types.StringValue(created.Signature)
 	data.Id = types.StringValue(created.Name)
+	base.SetCollection(&data, created.Collection)
 	if data.Name.IsNull() || data.Name.IsUnknown() || data.Name.ValueString() == "" {
 		data.Name = types.StringValue(created.Name)
 	}
@@ -201,6 +202,7 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(r.Res.SetIdentity(ctx, resp.Identity, data.Name.ValueString())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
 
@@ -216,6 +218,7 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = base.WithCollection(ctx, &data)
 
 	// Retrieve existing signature from state
 	var stateModel DeviceResourceModel
@@ -224,6 +227,12 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	signature, diags := r.Res.Rename(ctx, req, resp, data.Name.ValueString(), stateModel.Signature)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	config, err := r.MapPlanToClient(ctx, &data)
 	if err != nil {
 		resp.Diagnostics.AddError("Error mapping plan to client", err.Error())
@@ -235,7 +244,7 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		Type:      data.Type.ValueString(), // Use the driver type from the plan
 		Name:      data.Name.ValueString(),
 		Enabled:   base.BoolPtr(data.Enabled.ValueBool()),
-		Signature: stateModel.Signature.ValueString(),
+		Signature: signature.ValueString(),
 		Config:    config,
 	}
 
@@ -256,8 +265,8 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		if err == nil && fresh.Signature != "" {
 			data.Signature = types.StringValue(fresh.Signature)
 			updated = fresh
-		} else if !stateModel.Signature.IsNull() && !stateModel.Signature.IsUnknown() {
-			data.Signature = stateModel.Signature
+		} else if !signature.IsNull() && !signature.IsUnknown() {
+			data.Signature = signature
 			resp.Diagnostics.AddWarning("Missing Signature on Update",
 				"The API returned an empty signature after update and refresh failed. Preserving the existing signature.")
 		}
@@ -287,6 +296,7 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(r.Res.SetIdentity(ctx, resp.Identity, data.Name.ValueString())...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
 
@@ -295,11 +305,54 @@ func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	r.Res.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
 
+// ModifyPlan checks the parameters against the schema of the device's driver,
+// which is the resource type of a device
+func (r *DeviceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.Res.Schemas == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data DeviceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Type.IsUnknown() || data.Parameters.IsUnknown() {
+		return
+	}
+
+	config, err := r.MapPlanToClient(ctx, &data)
+	if err != nil {
+		return
+	}
+	resp.Diagnostics.Append(r.Res.Schemas.Check(ctx, r.Res.Module, data.Type.ValueString(), config, false, func([]string) path.Path {
+		return path.Root("parameters")
+	})...)
+}
+
+func (r *DeviceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
 func (r *DeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.Res.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &DeviceResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
 	})...)
 }
+
+func (r *DeviceResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists Devices configured on the gateway.")
+}
+
+func (r *DeviceResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.Res.List(ctx, req, stream, func(res *client.ResourceResponse[client.DeviceConfig]) (*DeviceResourceModel, *base.BaseResourceModel) {
+		// The API returns the Driver Type in the Type field
+		data := DeviceResourceModel{Type: types.StringValue(res.Type)}
+		return &data, &data.BaseResourceModel
+	})
+}
@@ -25,7 +25,8 @@ func TestUnitDeviceResource(t *testing.T) {
 				Type:      "ProgrammableSimulatorDevice",
 				Enabled:   base.BoolPtr(true),
 				Signature: "sig-123",
-				Config:    client.DeviceConfig{"baseRate": currentRate},
+				// The gateway fills in defaults the configuration did not specify
+				Config: client.DeviceConfig{"baseRate": currentRate, "repeat": true},
 			}, nil
 		},
 		UpdateDeviceFunc: func(ctx context.Context, item client.ResourceResponse[client.DeviceConfig]) (*client.ResourceResponse[client.DeviceConfig], error) {
@@ -6,24 +6,31 @@ import (
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GanOutgoingResource{}
 var _ resource.ResourceWithImportState = &GanOutgoingResource{}
+var _ resource.ResourceWithIdentity = &GanOutgoingResource{}
+var _ resource.ResourceWithModifyPlan = &GanOutgoingResource{}
+var _ list.ListResourceWithConfigure = &GanOutgoingResource{}
 
 func NewGanOutgoingResource() resource.Resource {
 	return &GanOutgoingResource{}
 }
 
+func NewGanOutgoingListResource() list.ListResource {
+	return &GanOutgoingResource{}
+}
+
 // GanOutgoingResource defines the resource implementation.
 type GanOutgoingResource struct {
 	client  client.IgnitionClient
@@ -33,6 +40,7 @@ type GanOutgoingResource struct {
 // GanOutgoingResourceModel describes the resource data model.
 type GanOutgoingResourceModel struct {
 	base.BaseResourceModel
+	base.CollectionResourceModel
 	Host                     types.String  `tfsdk:"host"`
 	Port                     types.Int64   `tfsdk:"port"`
 	UseSSL                   types.Bool    `tfsdk:"use_ssl"`
@@ -48,6 +56,7 @@ type GanOutgoingResourceModel struct {
 
 func (r *GanOutgoingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_gan_outgoing"
+	base.AllowRenames(resp)
 }
 
 func (r *GanOutgoingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -57,15 +66,12 @@ func (r *GanOutgoingResource) Schema(ctx context.Context, req resource.SchemaReq
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the connection.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Description: "The description of the connection.",
@@ -133,6 +139,7 @@ func (r *GanOutgoingResource) Schema(ctx context.Context, req resource.SchemaReq
 				Computed: true,
 				Default:  float64default.StaticFloat64(1),
 			},
+			"collection": base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Description: "The signature of the resource.",
 				Computed:    true,
@@ -165,6 +172,7 @@ func (r *GanOutgoingResource) Configure(ctx context.Context, req resource.Config
 		GetFunc:      c.GetGanOutgoing,
 		UpdateFunc:   c.UpdateGanOutgoing,
 		DeleteFunc:   c.DeleteGanOutgoing,
+		Schemas:      base.SchemaValidatorFrom(req.ProviderData),
 	}
 }
 
@@ -220,11 +228,35 @@ func (r *GanOutgoingResource) Delete(ctx context.Context, req resource.DeleteReq
 	r.generic.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
 
+func (r *GanOutgoingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.generic.CheckSchema(ctx, req, resp)
+}
+
+func (r *GanOutgoingResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
 func (r *GanOutgoingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.generic.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &GanOutgoingResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
 	})...)
 }
+
+func (r *GanOutgoingResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists outgoing Gateway Network connections configured on the gateway.")
+}
+
+func (r *GanOutgoingResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.generic.List(ctx, req, stream, func(_ *client.ResourceResponse[client.GanOutgoingConfig]) (*GanOutgoingResourceModel, *base.BaseResourceModel) {
+		var data GanOutgoingResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -18,11 +19,19 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GanGeneralSettingsResource{}
+var _ resource.ResourceWithImportState = &GanGeneralSettingsResource{}
+var _ resource.ResourceWithIdentity = &GanGeneralSettingsResource{}
+var _ resource.ResourceWithModifyPlan = &GanGeneralSettingsResource{}
+var _ list.ListResourceWithConfigure = &GanGeneralSettingsResource{}
 
 func NewGanGeneralSettingsResource() resource.Resource {
 	return &GanGeneralSettingsResource{}
 }
 
+func NewGanGeneralSettingsListResource() list.ListResource {
+	return &GanGeneralSettingsResource{}
+}
+
 // GanGeneralSettingsResource defines the resource implementation.
 type GanGeneralSettingsResource struct {
 	client  client.IgnitionClient
@@ -32,6 +41,7 @@ type GanGeneralSettingsResource struct {
 // GanGeneralSettingsResourceModel describes the resource data model.
 type GanGeneralSettingsResourceModel struct {
 	base.BaseResourceModel
+	base.CollectionResourceModel
 	RequireSSL                  types.Bool    `tfsdk:"require_ssl"`
 	RequireTwoWayAuth           types.Bool    `tfsdk:"require_two_way_auth"`
 	AllowIncoming               types.Bool    `tfsdk:"allow_incoming"`
@@ -113,6 +123,7 @@ func (r *GanGeneralSettingsResource) Schema(ctx context.Context, req resource.Sc
 				Computed: true,
 				Default:  float64default.StaticFloat64(24),
 			},
+			"collection": base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Computed: true,
 			},
@@ -148,6 +159,10 @@ func (r *GanGeneralSettingsResource) Configure(ctx context.Context, req resource
 		DeleteFunc: func(ctx context.Context, name, signature string) error {
 			return nil
 		},
+		ListFunc: func(ctx context.Context) ([]string, error) {
+			return []string{"gateway-network-settings"}, nil
+		},
+		Schemas: base.SchemaValidatorFrom(req.ProviderData),
 	}
 }
 
@@ -198,3 +213,45 @@ func (r *GanGeneralSettingsResource) Delete(ctx context.Context, req resource.De
 	var data GanGeneralSettingsResourceModel
 	r.generic.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
+
+func (r *GanGeneralSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.generic.CheckSchema(ctx, req, resp)
+}
+
+func (r *GanGeneralSettingsResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
+func (r *GanGeneralSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.generic.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
+	// Singleton resources can only be imported by their fixed key
+	if name != "gateway-network-settings" {
+		resp.Diagnostics.AddError(
+			"Invalid Import Identifier",
+			fmt.Sprintf("Expected the singleton key %q, got: %q.", "gateway-network-settings", name),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &GanGeneralSettingsResourceModel{
+		BaseResourceModel: base.BaseResourceModel{
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
+		},
+	})...)
+}
+
+func (r *GanGeneralSettingsResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists the General Gateway Network Settings configured on the gateway.")
+}
+
+func (r *GanGeneralSettingsResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.generic.List(ctx, req, stream, func(_ *client.ResourceResponse[client.GanGeneralSettingsConfig]) (*GanGeneralSettingsResourceModel, *base.BaseResourceModel) {
+		var data GanGeneralSettingsResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
@@ -1,13 +1,23 @@
 package resources
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -22,11 +32,20 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &IdentityProviderResource{}
 var _ resource.ResourceWithImportState = &IdentityProviderResource{}
+var _ resource.ResourceWithIdentity = &IdentityProviderResource{}
+var _ resource.ResourceWithModifyPlan = &IdentityProviderResource{}
+var _ base.ResourceWithReferences = &IdentityProviderResource{}
+var _ base.ResourceWithSecrets = &IdentityProviderResource{}
+var _ list.ListResourceWithConfigure = &IdentityProviderResource{}
 
 func NewIdentityProviderResource() resource.Resource {
 	return &IdentityProviderResource{}
 }
 
+func NewIdentityProviderListResource() list.ListResource {
+	return &IdentityProviderResource{}
+}
+
 // IdentityProviderResource defines the resource implementation.
 type IdentityProviderResource struct {
 	client  client.IgnitionClient
@@ -36,20 +55,30 @@ type IdentityProviderResource struct {
 // IdentityProviderResourceModel describes the resource data model.
 type IdentityProviderResourceModel struct {
 	base.BaseResourceModel
-	Type                     types.String  `tfsdk:"type"`
-	UserSource               types.String  `tfsdk:"user_source"`
-	SessionInactivityTimeout types.Float64 `tfsdk:"session_inactivity_timeout"`
-	SessionExp               types.Float64 `tfsdk:"session_expiration"`
-	RememberMeExp            types.Float64 `tfsdk:"remember_me_expiration"`
-	ClientId                 types.String  `tfsdk:"client_id"`
-	ClientSecret             types.String  `tfsdk:"client_secret"`
-	ProviderId               types.String  `tfsdk:"provider_id"`
-	AuthorizationEndpoint    types.String  `tfsdk:"authorization_endpoint"`
-	TokenEndpoint            types.String  `tfsdk:"token_endpoint"`
-	JwkEndpoint              types.String  `tfsdk:"jwk_endpoint"`
-	JwkEndpointEnabled       types.Bool    `tfsdk:"jwk_endpoint_enabled"`
-	UserInfoEndpoint         types.String  `tfsdk:"user_info_endpoint"`
-	LogoutEndpoint           types.String  `tfsdk:"logout_endpoint"`
+	base.CollectionResourceModel
+	Type                     types.String         `tfsdk:"type"`
+	UserSource               types.String         `tfsdk:"user_source"`
+	SessionInactivityTimeout types.Float64        `tfsdk:"session_inactivity_timeout"`
+	SessionExp               types.Float64        `tfsdk:"session_expiration"`
+	RememberMeExp            types.Float64        `tfsdk:"remember_me_expiration"`
+	AuthMethods              types.List           `tfsdk:"auth_methods"`
+	ClientId                 types.String         `tfsdk:"client_id"`
+	ClientSecret             types.String         `tfsdk:"client_secret"`
+	ProviderId               types.String         `tfsdk:"provider_id"`
+	AuthorizationEndpoint    types.String         `tfsdk:"authorization_endpoint"`
+	TokenEndpoint            types.String         `tfsdk:"token_endpoint"`
+	JwkEndpoint              types.String         `tfsdk:"jwk_endpoint"`
+	JwkEndpointEnabled       types.Bool           `tfsdk:"jwk_endpoint_enabled"`
+	UserInfoEndpoint         types.String         `tfsdk:"user_info_endpoint"`
+	LogoutEndpoint           types.String         `tfsdk:"logout_endpoint"`
+	Scopes                   types.List           `tfsdk:"scopes"`
+	TokenEndpointAuthMethod  types.String         `tfsdk:"token_endpoint_auth_method"`
+	PkceEnabled              types.Bool           `tfsdk:"pkce_enabled"`
+	Prompt                   types.String         `tfsdk:"prompt"`
+	MaxAge                   types.Int64          `tfsdk:"max_age"`
+	IdTokenSigningAlgorithms types.List           `tfsdk:"id_token_signing_algorithms"`
+	PreferUserInfoClaims     types.Bool           `tfsdk:"prefer_user_info_claims"`
+	DiscoveryDocument        jsontypes.Normalized `tfsdk:"discovery_document"`
 	// SAML fields
 	IdpEntityId                 types.String      `tfsdk:"idp_entity_id"`
 	SpEntityId                  types.String      `tfsdk:"sp_entity_id"`
@@ -62,6 +91,15 @@ type IdentityProviderResourceModel struct {
 	AssertionSignaturesRequired types.Bool        `tfsdk:"assertion_signatures_required"`
 	IdpMetadataUrl              types.String      `tfsdk:"idp_metadata_url"`
 	IdpMetadataUrlEnabled       types.Bool        `tfsdk:"idp_metadata_url_enabled"`
+	IdpMetadataFile             types.String      `tfsdk:"idp_metadata_file"`
+	ImportMetadataCertificates  types.Bool        `tfsdk:"import_metadata_certificates"`
+	SignatureVerifyingCerts     types.List        `tfsdk:"signature_verifying_certificates"`
+	SpSigningKey                *SamlKeyPair      `tfsdk:"sp_signing_key"`
+	SpEncryptionKey             *SamlKeyPair      `tfsdk:"sp_encryption_key"`
+	// Mapping rules, shared by every type
+	UserAttributeMapping types.Map                                `tfsdk:"user_attribute_mapping"`
+	RoleMapping          []IdentityProviderRoleMappingModel       `tfsdk:"role_mapping"`
+	SecurityLevelRules   []IdentityProviderSecurityLevelRuleModel `tfsdk:"security_level_rules"`
 }
 
 type SsoServiceConfig struct {
@@ -69,8 +107,40 @@ type SsoServiceConfig struct {
 	Binding types.String `tfsdk:"binding"`
 }
 
+// IdentityProviderAuthMethodModel is a way for users of an internal identity
+// provider to authenticate. Config is kept as JSON, so that methods the
+// provider does not know about round-trip unchanged.
+type IdentityProviderAuthMethodModel struct {
+	Type   types.String         `tfsdk:"type"`
+	Config jsontypes.Normalized `tfsdk:"config"`
+}
+
+var identityProviderAuthMethodType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"type":   types.StringType,
+	"config": jsontypes.NormalizedType{IgnoreDefaults: true},
+}}
+
+// SamlKeyPair is a certificate of the gateway as a SAML service provider, and
+// its private key
+type SamlKeyPair struct {
+	Certificate types.String `tfsdk:"certificate"`
+	PrivateKey  types.String `tfsdk:"private_key"`
+}
+
+type IdentityProviderRoleMappingModel struct {
+	Role       types.String `tfsdk:"role"`
+	Expression types.String `tfsdk:"expression"`
+}
+
+type IdentityProviderSecurityLevelRuleModel struct {
+	Expression types.String `tfsdk:"expression"`
+	Roles      types.Set    `tfsdk:"roles"`
+	Zones      types.Set    `tfsdk:"zones"`
+}
+
 func (r *IdentityProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_identity_provider"
+	base.AllowRenames(resp)
 }
 
 func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -80,15 +150,12 @@ func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.Sche
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the identity provider.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Description: "The description of the identity provider.",
@@ -130,6 +197,32 @@ func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.Sche
 				Computed:    true,
 				Default:     float64default.StaticFloat64(0),
 			},
+			"auth_methods": schema.ListNestedAttribute{
+				Description: "The ordered methods users authenticate with (for 'internal' type), such as " +
+					"`basic` (username and password), `badge` or `totp` (a time-based one-time password as a second " +
+					"factor). Defaults to `basic` alone. Methods are kept in the order the gateway returns them, " +
+					"including types this provider does not know about.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "The type of the authentication method (e.g., basic, badge, totp).",
+							Required:    true,
+						},
+						"config": schema.StringAttribute{
+							Description: "The JSON config of the method, as the gateway's REST API represents it. " +
+								"Settings populated with defaults by the gateway do not need to be specified.",
+							Optional:   true,
+							Computed:   true,
+							CustomType: jsontypes.NormalizedType{IgnoreDefaults: true},
+						},
+					},
+				},
+			},
 			// OIDC Type Fields
 			"client_id": schema.StringAttribute{
 				Description: "The client identifier registered within the identity provider.",
@@ -143,18 +236,34 @@ func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.Sche
 			"provider_id": schema.StringAttribute{
 				Description: "The issuer URL of the identity provider.",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"authorization_endpoint": schema.StringAttribute{
 				Description: "URL of the OP's OAuth 2.0 Authorization Endpoint.",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"token_endpoint": schema.StringAttribute{
 				Description: "URL of the OP's OAuth 2.0 Token Endpoint.",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"jwk_endpoint": schema.StringAttribute{
 				Description: "URL of the OP's JSON Web Key Set document.",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"jwk_endpoint_enabled": schema.BoolAttribute{
 				Description: "If true, then identity provider public keys will be automatically downloaded.",
@@ -165,10 +274,72 @@ func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.Sche
 			"user_info_endpoint": schema.StringAttribute{
 				Description: "URL to retrieve UserInfo claims from the provider.",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"logout_endpoint": schema.StringAttribute{
 				Description: "URL at the OP to which an RP can perform a redirect to request that the End-User be logged out.",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scopes": schema.ListAttribute{
+				Description: "The scopes requested from the identity provider. Defaults to `openid` for OIDC providers.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"token_endpoint_auth_method": schema.StringAttribute{
+				Description: "How the gateway authenticates to the token endpoint (client_secret_basic, client_secret_post, none). " +
+					"Defaults to `client_secret_basic` for OIDC providers.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("client_secret_basic", "client_secret_post", "none"),
+				},
+			},
+			"pkce_enabled": schema.BoolAttribute{
+				Description: "Whether authorization requests use PKCE (Proof Key for Code Exchange). Defaults to `false` for OIDC providers.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"prompt": schema.StringAttribute{
+				Description: "The prompt parameter of authorization requests (none, login, consent, select_account).",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("none", "login", "consent", "select_account"),
+				},
+			},
+			"max_age": schema.Int64Attribute{
+				Description: "The maximum seconds since the user last authenticated with the identity provider " +
+					"before they must authenticate again.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"id_token_signing_algorithms": schema.ListAttribute{
+				Description: "The algorithms accepted for ID token signatures (e.g., RS256, ES256). Defaults to `RS256` for OIDC providers.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"prefer_user_info_claims": schema.BoolAttribute{
+				Description: "Whether claims from the UserInfo endpoint take precedence over those of the ID token. " +
+					"Defaults to `false` for OIDC providers.",
+				Optional: true,
+				Computed: true,
+			},
+			"discovery_document": schema.StringAttribute{
+				Description: "The identity provider's OpenID discovery document (`.well-known/openid-configuration`) " +
+					"as JSON, e.g. from the `http` data source. It fills in `provider_id`, the endpoints and " +
+					"`id_token_signing_algorithms` where they are not set. It is not sent to the gateway.",
+				Optional:   true,
+				CustomType: jsontypes.NormalizedType{},
 			},
 			// SAML Type Fields
 			"idp_entity_id": schema.StringAttribute{
@@ -228,6 +399,112 @@ func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.Sche
 				Computed: true,
 				Default:  booldefault.StaticBool(true),
 			},
+			"idp_metadata_file": schema.StringAttribute{
+				Description: "The path of a local file holding the IdP's metadata XML, used instead of " +
+					"`idp_metadata_url` to import certificates. It is read by the provider and not sent to the gateway.",
+				Optional: true,
+			},
+			"import_metadata_certificates": schema.BoolAttribute{
+				Description: "Whether to import the IdP's signing certificates from its metadata, read from " +
+					"`idp_metadata_file` or downloaded from `idp_metadata_url`, when " +
+					"`signature_verifying_certificates` is not set. Certificates are imported again on every update.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"signature_verifying_certificates": schema.ListAttribute{
+				Description: "The PEM-encoded certificates that verify the signatures of the IdP's responses " +
+					"and assertions.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(
+						regexp.MustCompile(`-----BEGIN CERTIFICATE-----`),
+						"must be a PEM-encoded certificate",
+					)),
+				},
+			},
+			"sp_signing_key": schema.SingleNestedAttribute{
+				Description: "The key pair the gateway signs requests with as the SAML service provider.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"certificate": schema.StringAttribute{
+						Description: "The PEM-encoded certificate.",
+						Required:    true,
+					},
+					"private_key": schema.StringAttribute{
+						Description: "The PEM-encoded private key of the certificate.",
+						Required:    true,
+						Sensitive:   true,
+					},
+				},
+			},
+			"sp_encryption_key": schema.SingleNestedAttribute{
+				Description: "The key pair the gateway decrypts assertions with as the SAML service provider.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"certificate": schema.StringAttribute{
+						Description: "The PEM-encoded certificate.",
+						Required:    true,
+					},
+					"private_key": schema.StringAttribute{
+						Description: "The PEM-encoded private key of the certificate.",
+						Required:    true,
+						Sensitive:   true,
+					},
+				},
+			},
+			// Mapping rules
+			"user_attribute_mapping": schema.MapAttribute{
+				Description: "The expressions that source each Ignition user attribute (e.g., username, firstName, " +
+					"lastName, email, roles) from the IdP's response, keyed by attribute.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"role_mapping": schema.SetNestedAttribute{
+				Description: "Rules that assign an Ignition role to users for whom an expression is true.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Description: "The role to assign.",
+							Required:    true,
+						},
+						"expression": schema.StringAttribute{
+							Description: "The expression, evaluated against the IdP's response.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"security_level_rules": schema.SetNestedAttribute{
+				Description: "Rules that grant the security levels of roles and security zones to users for whom " +
+					"an expression is true.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"expression": schema.StringAttribute{
+							Description: "The expression, evaluated against the IdP's response.",
+							Required:    true,
+						},
+						"roles": schema.SetAttribute{
+							Description: "The roles whose security levels are granted.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Set{
+								setvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("zones")),
+							},
+						},
+						"zones": schema.SetAttribute{
+							Description: "The security zones whose security levels are granted.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"collection": base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Description: "The signature of the resource.",
 				Computed:    true,
@@ -254,28 +531,38 @@ func (r *IdentityProviderResource) Configure(ctx context.Context, req resource.C
 	r.generic = base.GenericIgnitionResource[client.IdentityProviderConfig, IdentityProviderResourceModel]{
 		Client:       c,
 		Handler:      r,
-		ResourceType: "ignition/identity-provider",
+		Module:       "ignition",
+		ResourceType: "identity-provider",
 		CreateFunc:   c.CreateIdentityProvider,
 		GetFunc:      c.GetIdentityProvider,
 		UpdateFunc:   c.UpdateIdentityProvider,
 		DeleteFunc:   c.DeleteIdentityProvider,
+		References:   base.ReferenceValidatorFrom(req.ProviderData),
+		Schemas:      base.SchemaValidatorFrom(req.ProviderData),
 	}
 }
 
 func (r *IdentityProviderResource) MapPlanToClient(ctx context.Context, model *IdentityProviderResourceModel) (client.IdentityProviderConfig, error) {
+	config, err := r.mapTypeConfigToClient(ctx, model)
+	if err != nil {
+		return config, err
+	}
+	return config, mapIdentityProviderRulesToClient(ctx, model, &config)
+}
+
+func (r *IdentityProviderResource) mapTypeConfigToClient(ctx context.Context, model *IdentityProviderResourceModel) (client.IdentityProviderConfig, error) {
 	if model.Type.ValueString() == "internal" {
 		internalConfig := client.IdentityProviderInternalConfig{
 			UserSource:               model.UserSource.ValueString(),
 			SessionInactivityTimeout: model.SessionInactivityTimeout.ValueFloat64(),
 			SessionExp:               model.SessionExp.ValueFloat64(),
 			RememberMeExp:            model.RememberMeExp.ValueFloat64(),
-			AuthMethods: []client.IdentityProviderAuthMethod{
-				{
-					Type:   "basic",
-					Config: map[string]any{},
-				},
-			},
 		}
+		authMethods, err := mapAuthMethodsToClient(ctx, model.AuthMethods)
+		if err != nil {
+			return client.IdentityProviderConfig{}, err
+		}
+		internalConfig.AuthMethods = authMethods
 		return client.IdentityProviderConfig{
 			Type:   "internal",
 			Config: internalConfig,
@@ -290,6 +577,17 @@ func (r *IdentityProviderResource) MapPlanToClient(ctx context.Context, model *I
 			JsonWebKeysEndpointEnabled: model.JwkEndpointEnabled.ValueBool(),
 			UserInfoEndpoint:           model.UserInfoEndpoint.ValueString(),
 			EndSessionEndpoint:         model.LogoutEndpoint.ValueString(),
+			TokenEndpointAuthMethod:    model.TokenEndpointAuthMethod.ValueString(),
+			PkceEnabled:                model.PkceEnabled.ValueBool(),
+			Prompt:                     model.Prompt.ValueString(),
+			MaxAge:                     model.MaxAge.ValueInt64(),
+			PreferUserInfoClaims:       model.PreferUserInfoClaims.ValueBool(),
+		}
+		if diags := model.Scopes.ElementsAs(ctx, &oidcConfig.Scopes, false); diags.HasError() {
+			return client.IdentityProviderConfig{}, fmt.Errorf("invalid scopes: %v", diags)
+		}
+		if diags := model.IdTokenSigningAlgorithms.ElementsAs(ctx, &oidcConfig.IdTokenSigningAlgorithms, false); diags.HasError() {
+			return client.IdentityProviderConfig{}, fmt.Errorf("invalid id_token_signing_algorithms: %v", diags)
 		}
 
 		if !model.ClientSecret.IsNull() {
@@ -306,18 +604,29 @@ func (r *IdentityProviderResource) MapPlanToClient(ctx context.Context, model *I
 		}, nil
 	} else if model.Type.ValueString() == "saml" {
 		samlConfig := client.IdentityProviderSamlConfig{
-			IdpEntityId:                    model.IdpEntityId.ValueString(),
-			SpEntityId:                     model.SpEntityId.ValueString(),
-			SpEntityIdEnabled:              model.SpEntityIdEnabled.ValueBool(),
-			AcsBinding:                     model.AcsBinding.ValueString(),
-			NameIdFormat:                   model.NameIdFormat.ValueString(),
-			ForceAuthnEnabled:              model.ForceAuthn.ValueBool(),
-			ResponseSignaturesRequired:     model.ResponseSignaturesRequired.ValueBool(),
-			AssertionSignaturesRequired:    model.AssertionSignaturesRequired.ValueBool(),
-			IdpMetadataUrl:                 model.IdpMetadataUrl.ValueString(),
-			IdpMetadataUrlEnabled:          model.IdpMetadataUrlEnabled.ValueBool(),
-			SignatureVerifyingCertificates: []string{},
-			SignatureVerifyingKeys:         []any{},
+			IdpEntityId:                 model.IdpEntityId.ValueString(),
+			SpEntityId:                  model.SpEntityId.ValueString(),
+			SpEntityIdEnabled:           model.SpEntityIdEnabled.ValueBool(),
+			AcsBinding:                  model.AcsBinding.ValueString(),
+			NameIdFormat:                model.NameIdFormat.ValueString(),
+			ForceAuthnEnabled:           model.ForceAuthn.ValueBool(),
+			ResponseSignaturesRequired:  model.ResponseSignaturesRequired.ValueBool(),
+			AssertionSignaturesRequired: model.AssertionSignaturesRequired.ValueBool(),
+			IdpMetadataUrl:              model.IdpMetadataUrl.ValueString(),
+			IdpMetadataUrlEnabled:       model.IdpMetadataUrlEnabled.ValueBool(),
+			SignatureVerifyingKeys:      []any{},
+		}
+
+		certificates, err := r.samlCertificates(ctx, model)
+		if err != nil {
+			return client.IdentityProviderConfig{}, err
+		}
+		samlConfig.SignatureVerifyingCertificates = certificates
+		if samlConfig.SpSigningKeyPair, err = r.samlKeyPair(ctx, model.SpSigningKey); err != nil {
+			return client.IdentityProviderConfig{}, err
+		}
+		if samlConfig.SpEncryptionKeyPair, err = r.samlKeyPair(ctx, model.SpEncryptionKey); err != nil {
+			return client.IdentityProviderConfig{}, err
 		}
 
 		if model.SsoServiceConfig != nil {
@@ -337,6 +646,18 @@ func (r *IdentityProviderResource) MapPlanToClient(ctx context.Context, model *I
 func (r *IdentityProviderResource) MapClientToState(ctx context.Context, name string, config *client.IdentityProviderConfig, model *IdentityProviderResourceModel) error {
 	model.Name = types.StringValue(name)
 	model.Type = types.StringValue(config.Type)
+	if err := mapIdentityProviderRulesToState(ctx, config, model); err != nil {
+		return err
+	}
+	model.AuthMethods = types.ListNull(identityProviderAuthMethodType)
+	if config.Type != "oidc" {
+		clearOidcDefaults(model)
+	}
+	priorCertificates := model.SignatureVerifyingCerts
+	model.SignatureVerifyingCerts = types.ListNull(types.StringType)
+	if model.ImportMetadataCertificates.IsNull() {
+		model.ImportMetadataCertificates = types.BoolValue(false)
+	}
 
 	configBytes, _ := json.Marshal(config.Config)
 
@@ -348,19 +669,50 @@ func (r *IdentityProviderResource) MapClientToState(ctx context.Context, name st
 			model.SessionInactivityTimeout = types.Float64Value(internalConfig.SessionInactivityTimeout)
 			model.SessionExp = types.Float64Value(internalConfig.SessionExp)
 			model.RememberMeExp = types.Float64Value(internalConfig.RememberMeExp)
+			authMethods, err := mapAuthMethodsToState(ctx, internalConfig.AuthMethods)
+			if err != nil {
+				return err
+			}
+			model.AuthMethods = authMethods
 		}
 	case "oidc":
 		var oidcConfig client.IdentityProviderOidcConfig
-		if err := json.Unmarshal(configBytes, &oidcConfig); err == nil {
-			model.ClientId = types.StringValue(oidcConfig.ClientId)
-			model.ProviderId = types.StringValue(oidcConfig.ProviderId)
-			model.AuthorizationEndpoint = base.StringToNullableString(oidcConfig.AuthorizationEndpoint)
-			model.TokenEndpoint = base.StringToNullableString(oidcConfig.TokenEndpoint)
-			model.JwkEndpoint = base.StringToNullableString(oidcConfig.JsonWebKeysEndpoint)
-			model.JwkEndpointEnabled = types.BoolValue(oidcConfig.JsonWebKeysEndpointEnabled)
-			model.UserInfoEndpoint = base.StringToNullableString(oidcConfig.UserInfoEndpoint)
-			model.LogoutEndpoint = base.StringToNullableString(oidcConfig.EndSessionEndpoint)
+		if err := json.Unmarshal(configBytes, &oidcConfig); err != nil {
+			return fmt.Errorf("invalid OIDC config: %w", err)
+		}
+		model.ClientId = types.StringValue(oidcConfig.ClientId)
+		model.ProviderId = base.StringToNullableString(oidcConfig.ProviderId)
+		model.AuthorizationEndpoint = base.StringToNullableString(oidcConfig.AuthorizationEndpoint)
+		model.TokenEndpoint = base.StringToNullableString(oidcConfig.TokenEndpoint)
+		model.JwkEndpoint = base.StringToNullableString(oidcConfig.JsonWebKeysEndpoint)
+		model.JwkEndpointEnabled = types.BoolValue(oidcConfig.JsonWebKeysEndpointEnabled)
+		model.UserInfoEndpoint = base.StringToNullableString(oidcConfig.UserInfoEndpoint)
+		model.LogoutEndpoint = base.StringToNullableString(oidcConfig.EndSessionEndpoint)
+		model.TokenEndpointAuthMethod = base.StringToNullableString(oidcConfig.TokenEndpointAuthMethod)
+		model.PkceEnabled = types.BoolValue(oidcConfig.PkceEnabled)
+		model.Prompt = base.StringToNullableString(oidcConfig.Prompt)
+		model.MaxAge = types.Int64Null()
+		if oidcConfig.MaxAge > 0 {
+			model.MaxAge = types.Int64Value(oidcConfig.MaxAge)
+		}
+		model.PreferUserInfoClaims = types.BoolValue(oidcConfig.PreferUserInfoClaims)
+
+		// Lists the gateway leaves out have their defaults
+		if len(oidcConfig.Scopes) > 0 {
+			scopes, diags := types.ListValueFrom(ctx, types.StringType, oidcConfig.Scopes)
+			if diags.HasError() {
+				return fmt.Errorf("invalid scopes: %v", diags)
+			}
+			model.Scopes = scopes
+		}
+		if len(oidcConfig.IdTokenSigningAlgorithms) > 0 {
+			algorithms, diags := types.ListValueFrom(ctx, types.StringType, oidcConfig.IdTokenSigningAlgorithms)
+			if diags.HasError() {
+				return fmt.Errorf("invalid ID token signing algorithms: %v", diags)
+			}
+			model.IdTokenSigningAlgorithms = algorithms
 		}
+		setOidcDefaults(model)
 	case "saml":
 		var samlConfig client.IdentityProviderSamlConfig
 		if err := json.Unmarshal(configBytes, &samlConfig); err == nil {
@@ -375,16 +727,376 @@ func (r *IdentityProviderResource) MapClientToState(ctx context.Context, name st
 			model.IdpMetadataUrl = base.StringToNullableString(samlConfig.IdpMetadataUrl)
 			model.IdpMetadataUrlEnabled = types.BoolValue(samlConfig.IdpMetadataUrlEnabled)
 
-			model.SsoServiceConfig = &SsoServiceConfig{
-				Uri:     types.StringValue(samlConfig.SsoServiceConfig.Uri),
-				Binding: types.StringValue(samlConfig.SsoServiceConfig.Binding),
+			// The gateway returns an empty SSO service config when none is set
+			if model.SsoServiceConfig != nil || samlConfig.SsoServiceConfig.Uri != "" {
+				model.SsoServiceConfig = &SsoServiceConfig{
+					Uri:     types.StringValue(samlConfig.SsoServiceConfig.Uri),
+					Binding: types.StringValue(samlConfig.SsoServiceConfig.Binding),
+				}
 			}
+
+			if len(samlConfig.SignatureVerifyingCertificates) > 0 {
+				certificates, diags := types.ListValueFrom(ctx, types.StringType, samlConfig.SignatureVerifyingCertificates)
+				if diags.HasError() {
+					return fmt.Errorf("invalid signature verifying certificates: %v", diags)
+				}
+				model.SignatureVerifyingCerts = certificates
+			} else if !priorCertificates.IsNull() && !priorCertificates.IsUnknown() {
+				// An empty list stays as configured
+				model.SignatureVerifyingCerts = types.ListValueMust(types.StringType, []attr.Value{})
+			}
+			model.SpSigningKey = samlKeyPairToState(samlConfig.SpSigningKeyPair, model.SpSigningKey)
+			model.SpEncryptionKey = samlKeyPairToState(samlConfig.SpEncryptionKeyPair, model.SpEncryptionKey)
 		}
 	}
 
 	return nil
 }
 
+// oidcDefaults are the values of the OIDC settings that are not configured,
+// or nil for those the gateway or the discovery document fills in. They only
+// apply to OIDC providers; for other types the settings are null.
+var oidcDefaults = []struct {
+	name        string
+	value, null attr.Value
+}{
+	{"provider_id", nil, types.StringNull()},
+	{"authorization_endpoint", nil, types.StringNull()},
+	{"token_endpoint", nil, types.StringNull()},
+	{"jwk_endpoint", nil, types.StringNull()},
+	{"user_info_endpoint", nil, types.StringNull()},
+	{"logout_endpoint", nil, types.StringNull()},
+	{"scopes", types.ListValueMust(types.StringType, []attr.Value{types.StringValue("openid")}), types.ListNull(types.StringType)},
+	{"id_token_signing_algorithms", types.ListValueMust(types.StringType, []attr.Value{types.StringValue("RS256")}), types.ListNull(types.StringType)},
+	{"token_endpoint_auth_method", types.StringValue("client_secret_basic"), types.StringNull()},
+	{"pkce_enabled", types.BoolValue(false), types.BoolNull()},
+	{"prefer_user_info_claims", types.BoolValue(false), types.BoolNull()},
+}
+
+// clearOidcDefaults nulls the computed OIDC settings, as they do not apply to
+// providers of other types
+func clearOidcDefaults(model *IdentityProviderResourceModel) {
+	model.ProviderId = types.StringNull()
+	model.AuthorizationEndpoint = types.StringNull()
+	model.TokenEndpoint = types.StringNull()
+	model.JwkEndpoint = types.StringNull()
+	model.UserInfoEndpoint = types.StringNull()
+	model.LogoutEndpoint = types.StringNull()
+	model.Scopes = types.ListNull(types.StringType)
+	model.IdTokenSigningAlgorithms = types.ListNull(types.StringType)
+	model.TokenEndpointAuthMethod = types.StringNull()
+	model.PkceEnabled = types.BoolNull()
+	model.PreferUserInfoClaims = types.BoolNull()
+}
+
+// planOidcDefaults plans the defaults of the unconfigured OIDC settings of OIDC
+// providers, and null for providers of other types, which reject them
+func planOidcDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var providerType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &providerType)...)
+	if resp.Diagnostics.HasError() || providerType.IsUnknown() {
+		return
+	}
+
+	for _, d := range oidcDefaults {
+		var configured attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(d.name), &configured)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		switch {
+		case providerType.ValueString() == "oidc":
+			if configured.IsNull() && d.value != nil {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(d.name), d.value)...)
+			}
+		case !configured.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(d.name),
+				"Invalid Attribute",
+				fmt.Sprintf("%s only applies to identity providers of type oidc.", d.name),
+			)
+		default:
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(d.name), d.null)...)
+		}
+	}
+}
+
+// setOidcDefaults sets the OIDC settings that have defaults to them where they
+// are unset, as for providers that are being imported. Lists the gateway leaves
+// out keep their planned values.
+func setOidcDefaults(model *IdentityProviderResourceModel) {
+	if model.Scopes.IsNull() || model.Scopes.IsUnknown() {
+		model.Scopes = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("openid")})
+	}
+	if model.IdTokenSigningAlgorithms.IsNull() || model.IdTokenSigningAlgorithms.IsUnknown() {
+		model.IdTokenSigningAlgorithms = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("RS256")})
+	}
+	if model.TokenEndpointAuthMethod.IsNull() || model.TokenEndpointAuthMethod.IsUnknown() {
+		model.TokenEndpointAuthMethod = types.StringValue("client_secret_basic")
+	}
+	if model.PkceEnabled.IsNull() || model.PkceEnabled.IsUnknown() {
+		model.PkceEnabled = types.BoolValue(false)
+	}
+	if model.PreferUserInfoClaims.IsNull() || model.PreferUserInfoClaims.IsUnknown() {
+		model.PreferUserInfoClaims = types.BoolValue(false)
+	}
+}
+
+// oidcDiscoveryFields are the attributes filled in from an OpenID discovery
+// document, by the key of their value in the document
+var oidcDiscoveryFields = map[string]string{
+	"issuer":                                "provider_id",
+	"authorization_endpoint":                "authorization_endpoint",
+	"token_endpoint":                        "token_endpoint",
+	"jwks_uri":                              "jwk_endpoint",
+	"userinfo_endpoint":                     "user_info_endpoint",
+	"end_session_endpoint":                  "logout_endpoint",
+	"id_token_signing_alg_values_supported": "id_token_signing_algorithms",
+}
+
+// applyDiscoveryDocument fills in the planned values of attributes that are
+// not configured from the discovery document, if any
+func applyDiscoveryDocument(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var document jsontypes.Normalized
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("discovery_document"), &document)...)
+	if resp.Diagnostics.HasError() || document.IsNull() || document.IsUnknown() {
+		return
+	}
+
+	var discovery map[string]any
+	if err := json.Unmarshal([]byte(document.ValueString()), &discovery); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("discovery_document"), "Invalid Discovery Document", err.Error())
+		return
+	}
+
+	for key, name := range oidcDiscoveryFields {
+		value, ok := discovery[key]
+		if !ok {
+			continue
+		}
+		attribute := path.Root(name)
+		var configured attr.Value
+		if name == "id_token_signing_algorithms" {
+			var list types.List
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attribute, &list)...)
+			configured = list
+		} else {
+			var str types.String
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attribute, &str)...)
+			configured = str
+		}
+		if !configured.IsNull() {
+			continue
+		}
+
+		switch v := value.(type) {
+		case string:
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attribute, v)...)
+		case []any:
+			var values []string
+			for _, e := range v {
+				if s, ok := e.(string); ok {
+					values = append(values, s)
+				}
+			}
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attribute, values)...)
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("discovery_document"),
+				"Invalid Discovery Document",
+				fmt.Sprintf("Expected %q to be a string or a list of strings.", key),
+			)
+		}
+	}
+}
+
+// mapAuthMethodsToClient converts the configured authentication methods, in
+// order. Without any, users authenticate with a username and password.
+func mapAuthMethodsToClient(ctx context.Context, list types.List) ([]client.IdentityProviderAuthMethod, error) {
+	if list.IsNull() || list.IsUnknown() {
+		return []client.IdentityProviderAuthMethod{{Type: "basic", Config: map[string]any{}}}, nil
+	}
+
+	var models []IdentityProviderAuthMethodModel
+	if diags := list.ElementsAs(ctx, &models, false); diags.HasError() {
+		return nil, fmt.Errorf("invalid auth_methods: %v", diags)
+	}
+	methods := make([]client.IdentityProviderAuthMethod, 0, len(models))
+	for _, m := range models {
+		var config any = map[string]any{}
+		if !m.Config.IsNull() && !m.Config.IsUnknown() {
+			if err := json.Unmarshal([]byte(m.Config.ValueString()), &config); err != nil {
+				return nil, fmt.Errorf("invalid config of auth method %q: %w", m.Type.ValueString(), err)
+			}
+		}
+		methods = append(methods, client.IdentityProviderAuthMethod{Type: m.Type.ValueString(), Config: config})
+	}
+	return methods, nil
+}
+
+// mapAuthMethodsToState converts the gateway's authentication methods, in
+// order and whatever their type
+func mapAuthMethodsToState(ctx context.Context, methods []client.IdentityProviderAuthMethod) (types.List, error) {
+	models := make([]IdentityProviderAuthMethodModel, 0, len(methods))
+	for _, m := range methods {
+		config := "{}"
+		if m.Config != nil {
+			b, err := json.Marshal(m.Config)
+			if err != nil {
+				return types.ListNull(identityProviderAuthMethodType), err
+			}
+			config = string(b)
+		}
+		models = append(models, IdentityProviderAuthMethodModel{
+			Type:   types.StringValue(m.Type),
+			Config: jsontypes.NewNormalizedValue(config),
+		})
+	}
+
+	list, diags := types.ListValueFrom(ctx, identityProviderAuthMethodType, models)
+	if diags.HasError() {
+		return types.ListNull(identityProviderAuthMethodType), fmt.Errorf("invalid auth methods: %v", diags)
+	}
+	return list, nil
+}
+
+// samlCertificates returns the certificates that verify the IdP's signatures:
+// those configured, or else those of the IdP's metadata when importing them is
+// enabled. Metadata is not read while planning.
+func (r *IdentityProviderResource) samlCertificates(ctx context.Context, model *IdentityProviderResourceModel) ([]string, error) {
+	certificates := []string{}
+	if !model.SignatureVerifyingCerts.IsNull() && !model.SignatureVerifyingCerts.IsUnknown() {
+		if diags := model.SignatureVerifyingCerts.ElementsAs(ctx, &certificates, false); diags.HasError() {
+			return nil, fmt.Errorf("invalid signature_verifying_certificates: %v", diags)
+		}
+		return certificates, nil
+	}
+	if !model.ImportMetadataCertificates.ValueBool() || base.IsPlanning(ctx) {
+		return certificates, nil
+	}
+
+	metadata, err := loadSAMLMetadata(ctx, model.IdpMetadataUrl.ValueString(), model.IdpMetadataFile.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	return samlMetadataCertificates(metadata)
+}
+
+// samlKeyPair converts a key pair of the model, encrypting its private key
+func (r *IdentityProviderResource) samlKeyPair(ctx context.Context, keyPair *SamlKeyPair) (*client.IdentityProviderKeyPair, error) {
+	if keyPair == nil {
+		return nil, nil
+	}
+	privateKey, err := r.client.EncryptSecret(ctx, keyPair.PrivateKey.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	return &client.IdentityProviderKeyPair{
+		Certificate: keyPair.Certificate.ValueString(),
+		PrivateKey:  privateKey,
+	}, nil
+}
+
+// samlKeyPairToState converts a key pair of the gateway. Private keys are only
+// returned encrypted, so the one of the prior state is kept.
+func samlKeyPairToState(keyPair *client.IdentityProviderKeyPair, prior *SamlKeyPair) *SamlKeyPair {
+	if keyPair == nil {
+		return nil
+	}
+	privateKey := types.StringNull()
+	if prior != nil {
+		privateKey = prior.PrivateKey
+	}
+	return &SamlKeyPair{
+		Certificate: types.StringValue(keyPair.Certificate),
+		PrivateKey:  privateKey,
+	}
+}
+
+// mapIdentityProviderRulesToClient adds the mapping rules of the model to config.
+// Rules are sorted, so that the gateway sees the same order on every apply.
+func mapIdentityProviderRulesToClient(ctx context.Context, model *IdentityProviderResourceModel, config *client.IdentityProviderConfig) error {
+	if !model.UserAttributeMapping.IsNull() && !model.UserAttributeMapping.IsUnknown() {
+		config.UserAttributeMapping = map[string]string{}
+		if diags := model.UserAttributeMapping.ElementsAs(ctx, &config.UserAttributeMapping, false); diags.HasError() {
+			return fmt.Errorf("invalid user_attribute_mapping: %v", diags)
+		}
+	}
+
+	for _, m := range model.RoleMapping {
+		config.RoleMapping = append(config.RoleMapping, client.IdentityProviderRoleMapping{
+			Role:       m.Role.ValueString(),
+			Expression: m.Expression.ValueString(),
+		})
+	}
+	slices.SortFunc(config.RoleMapping, func(a, b client.IdentityProviderRoleMapping) int {
+		return cmp.Or(cmp.Compare(a.Role, b.Role), cmp.Compare(a.Expression, b.Expression))
+	})
+
+	for _, rule := range model.SecurityLevelRules {
+		grant := client.IdentityProviderSecurityLevelRule{
+			Expression: rule.Expression.ValueString(),
+			Roles:      []string{},
+			Zones:      []string{},
+		}
+		if !rule.Roles.IsNull() {
+			if diags := rule.Roles.ElementsAs(ctx, &grant.Roles, false); diags.HasError() {
+				return fmt.Errorf("invalid security_level_rules roles: %v", diags)
+			}
+		}
+		if !rule.Zones.IsNull() {
+			if diags := rule.Zones.ElementsAs(ctx, &grant.Zones, false); diags.HasError() {
+				return fmt.Errorf("invalid security_level_rules zones: %v", diags)
+			}
+		}
+		slices.Sort(grant.Roles)
+		slices.Sort(grant.Zones)
+		config.SecurityLevelRules = append(config.SecurityLevelRules, grant)
+	}
+	slices.SortFunc(config.SecurityLevelRules, func(a, b client.IdentityProviderSecurityLevelRule) int {
+		return cmp.Compare(a.Expression, b.Expression)
+	})
+	return nil
+}
+
+// mapIdentityProviderRulesToState updates the mapping rules of the model from config
+func mapIdentityProviderRulesToState(ctx context.Context, config *client.IdentityProviderConfig, model *IdentityProviderResourceModel) error {
+	model.UserAttributeMapping = types.MapNull(types.StringType)
+	if len(config.UserAttributeMapping) > 0 {
+		mapping, diags := types.MapValueFrom(ctx, types.StringType, config.UserAttributeMapping)
+		if diags.HasError() {
+			return fmt.Errorf("invalid user attribute mapping: %v", diags)
+		}
+		model.UserAttributeMapping = mapping
+	}
+
+	model.RoleMapping = nil
+	for _, m := range config.RoleMapping {
+		model.RoleMapping = append(model.RoleMapping, IdentityProviderRoleMappingModel{
+			Role:       types.StringValue(m.Role),
+			Expression: types.StringValue(m.Expression),
+		})
+	}
+
+	model.SecurityLevelRules = nil
+	for _, rule := range config.SecurityLevelRules {
+		grant := IdentityProviderSecurityLevelRuleModel{
+			Expression: types.StringValue(rule.Expression),
+			Roles:      types.SetNull(types.StringType),
+			Zones:      types.SetNull(types.StringType),
+		}
+		if len(rule.Roles) > 0 {
+			grant.Roles, _ = types.SetValueFrom(ctx, types.StringType, rule.Roles)
+		}
+		if len(rule.Zones) > 0 {
+			grant.Zones, _ = types.SetValueFrom(ctx, types.StringType, rule.Zones)
+		}
+		model.SecurityLevelRules = append(model.SecurityLevelRules, grant)
+	}
+	return nil
+}
+
 func (r *IdentityProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data IdentityProviderResourceModel
 	r.generic.Create(ctx, req, resp, &data, &data.BaseResourceModel)
@@ -405,11 +1117,60 @@ func (r *IdentityProviderResource) Delete(ctx context.Context, req resource.Dele
 	r.generic.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
 
+func (r *IdentityProviderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.Plan.Raw.IsNull() {
+		planOidcDefaults(ctx, req, resp)
+		applyDiscoveryDocument(ctx, req, resp)
+	}
+	r.generic.CheckReferences(ctx, req, resp, r.ReferenceAttributes()...)
+	r.generic.CheckSchema(ctx, req, resp)
+}
+
+func (r *IdentityProviderResource) ReferenceAttributes() []base.Reference {
+	return []base.Reference{
+		{Path: path.Root("user_source"), Kind: "user source", Module: "ignition", ResourceType: "user-source"},
+	}
+}
+
+func (r *IdentityProviderResource) SecretAttributes() []base.Secret {
+	return []base.Secret{
+		{Path: path.Root("client_secret"), Key: "config.clientSecret"},
+		{Path: path.Root("sp_signing_key").AtName("private_key"), Key: "config.spSigningKeyPair.privateKey"},
+		{Path: path.Root("sp_encryption_key").AtName("private_key"), Key: "config.spEncryptionKeyPair.privateKey"},
+	}
+}
+
+func (r *IdentityProviderResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
 func (r *IdentityProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.generic.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &IdentityProviderResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
+		AuthMethods:                types.ListNull(identityProviderAuthMethodType),
+		Scopes:                     types.ListNull(types.StringType),
+		IdTokenSigningAlgorithms:   types.ListNull(types.StringType),
+		UserAttributeMapping:       types.MapNull(types.StringType),
+		SignatureVerifyingCerts:    types.ListNull(types.StringType),
+		ImportMetadataCertificates: types.BoolValue(false),
 	})...)
 }
+
+func (r *IdentityProviderResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists Identity Providers configured on the gateway.")
+}
+
+func (r *IdentityProviderResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.generic.List(ctx, req, stream, func(_ *client.ResourceResponse[client.IdentityProviderConfig]) (*IdentityProviderResourceModel, *base.BaseResourceModel) {
+		var data IdentityProviderResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
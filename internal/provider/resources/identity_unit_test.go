@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestUnitResourceIdentity(t *testing.T) {
+	mockClient := &client.MockClient{
+		CreateTagProviderFunc: func(ctx context.Context, tp client.ResourceResponse[client.TagProviderConfig]) (*client.ResourceResponse[client.TagProviderConfig], error) {
+			tp.Signature = "sig-123"
+			return &tp, nil
+		},
+		GetTagProviderFunc: func(ctx context.Context, name string) (*client.ResourceResponse[client.TagProviderConfig], error) {
+			if name != "test-tags" {
+				return nil, fmt.Errorf("not found")
+			}
+			return &client.ResourceResponse[client.TagProviderConfig]{
+				Name:      name,
+				Signature: "sig-123",
+				Enabled:   base.BoolPtr(true),
+				Config:    client.TagProviderConfig{Profile: client.TagProviderProfile{Type: "STANDARD"}},
+			}, nil
+		},
+		UpdateGanGeneralSettingsFunc: func(ctx context.Context, item client.ResourceResponse[client.GanGeneralSettingsConfig]) (*client.ResourceResponse[client.GanGeneralSettingsConfig], error) {
+			item.Signature = "sig-gan"
+			return &item, nil
+		},
+		GetGanGeneralSettingsFunc: func(ctx context.Context) (*client.ResourceResponse[client.GanGeneralSettingsConfig], error) {
+			return &client.ResourceResponse[client.GanGeneralSettingsConfig]{
+				Name:      "gateway-network-settings",
+				Signature: "sig-gan",
+				Enabled:   base.BoolPtr(true),
+				Config: client.GanGeneralSettingsConfig{
+					RequireSSL:                  true,
+					RequireTwoWayAuth:           true,
+					AllowIncoming:               true,
+					SecurityPolicy:              "ApprovedOnly",
+					WebsocketSessionIdleTimeout: 30000,
+					TempFilesMaxAgeHours:        24,
+				},
+			}, nil
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			Client: mockClient,
+			ResourceFactories: []func() fwresource.Resource{
+				NewTagProviderResource,
+				NewGanGeneralSettingsResource,
+			},
+		}),
+	}
+
+	config := `
+		provider "ignition" {
+			host  = "http://mock-host"
+			token = "mock-token"
+		}
+		resource "ignition_tag_provider" "test" {
+			name = "test-tags"
+			type = "STANDARD"
+		}
+		resource "ignition_gan_settings" "test" {}
+	`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("ignition_tag_provider.test", map[string]knownvalue.Check{
+						"module": knownvalue.StringExact("ignition"),
+						"type":   knownvalue.StringExact("tag-provider"),
+						"name":   knownvalue.StringExact("test-tags"),
+					}),
+					statecheck.ExpectIdentity("ignition_gan_settings.test", map[string]knownvalue.Check{
+						"module": knownvalue.StringExact("ignition"),
+						"type":   knownvalue.StringExact("gateway-network-settings"),
+						"name":   knownvalue.StringExact("gateway-network-settings"),
+					}),
+				},
+			},
+			{
+				Config:          config,
+				ResourceName:    "ignition_tag_provider.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
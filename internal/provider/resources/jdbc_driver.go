@@ -0,0 +1,441 @@
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &JDBCDriverResource{}
+var _ resource.ResourceWithImportState = &JDBCDriverResource{}
+var _ resource.ResourceWithIdentity = &JDBCDriverResource{}
+var _ resource.ResourceWithModifyPlan = &JDBCDriverResource{}
+var _ base.ResourceWithReferences = &JDBCDriverResource{}
+var _ list.ListResourceWithConfigure = &JDBCDriverResource{}
+
+func NewJDBCDriverResource() resource.Resource {
+	return &JDBCDriverResource{}
+}
+
+func NewJDBCDriverListResource() list.ListResource {
+	return &JDBCDriverResource{}
+}
+
+// JDBCDriverResource defines the resource implementation. The JAR files are
+// uploaded from local paths after the driver is saved, and again whenever
+// their content changes.
+type JDBCDriverResource struct {
+	client  client.IgnitionClient
+	generic base.GenericIgnitionResource[client.JDBCDriverConfig, JDBCDriverResourceModel]
+}
+
+// JDBCDriverResourceModel describes the resource data model.
+type JDBCDriverResourceModel struct {
+	base.BaseResourceModel
+	base.CollectionResourceModel
+	Classname               types.String `tfsdk:"classname"`
+	DefaultPropInstructions types.String `tfsdk:"default_prop_instructions"`
+	DefaultProps            types.String `tfsdk:"default_props"`
+	DefaultTranslator       types.String `tfsdk:"default_translator"`
+	DefaultValidationQuery  types.String `tfsdk:"default_validation_query"`
+	Type                    types.String `tfsdk:"type"`
+	URLFormat               types.String `tfsdk:"url_format"`
+	URLInstructions         types.String `tfsdk:"url_instructions"`
+	JarFiles                types.List   `tfsdk:"jar_files"`
+	JarChecksums            types.Map    `tfsdk:"jar_checksums"`
+}
+
+func (r *JDBCDriverResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jdbc_driver"
+	base.AllowRenames(resp)
+}
+
+func (r *JDBCDriverResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a JDBC driver in Ignition.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					base.UseNameForID(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the JDBC driver.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the JDBC driver.",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the JDBC driver is enabled.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"classname": schema.StringAttribute{
+				Description: "The fully qualified class name of the JDBC driver.",
+				Required:    true,
+			},
+			"default_prop_instructions": schema.StringAttribute{
+				Description: "Instructions for extra connection properties, as HTML.",
+				Optional:    true,
+			},
+			"default_props": schema.StringAttribute{
+				Description: "Extra connection properties used by default, separated by semicolons.",
+				Optional:    true,
+			},
+			"default_translator": schema.StringAttribute{
+				Description: "The name of the database translator used by default by connections that use the driver.",
+				Optional:    true,
+			},
+			"default_validation_query": schema.StringAttribute{
+				Description: "The query used by default to validate pooled connections.",
+				Optional:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The family of the database the driver connects to (e.g., MYSQL, ORACLE, SQLITE).",
+				Required:    true,
+			},
+			"url_format": schema.StringAttribute{
+				Description: "An example connect URL, shown when a connection is created.",
+				Optional:    true,
+			},
+			"url_instructions": schema.StringAttribute{
+				Description: "Instructions for filling in the connect URL, as HTML.",
+				Optional:    true,
+			},
+			"jar_files": schema.ListAttribute{
+				Description: "Paths to local JAR files that hold the driver. They are uploaded to the gateway under their file names. " +
+					"JAR files removed from the list, or renamed, are deleted from the gateway. Drivers that ship with the gateway have none.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"jar_checksums": schema.MapAttribute{
+				Description: "The SHA-256 checksums of the uploaded JAR files, by file name. A JAR file is uploaded again when its checksum changes.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"collection": base.CollectionAttribute(),
+			"signature": schema.StringAttribute{
+				Description: "The signature of the resource.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *JDBCDriverResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(client.IgnitionClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.IgnitionClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = c
+	r.generic = base.GenericIgnitionResource[client.JDBCDriverConfig, JDBCDriverResourceModel]{
+		Client:       c,
+		Handler:      r,
+		Module:       "ignition",
+		ResourceType: "database-driver",
+		CreateFunc:   c.CreateJDBCDriver,
+		GetFunc:      c.GetJDBCDriver,
+		UpdateFunc:   c.UpdateJDBCDriver,
+		DeleteFunc:   c.DeleteJDBCDriver,
+		References:   base.ReferenceValidatorFrom(req.ProviderData),
+		Schemas:      base.SchemaValidatorFrom(req.ProviderData),
+	}
+}
+
+func (r *JDBCDriverResource) MapPlanToClient(ctx context.Context, model *JDBCDriverResourceModel) (client.JDBCDriverConfig, error) {
+	return client.JDBCDriverConfig{
+		Classname:               model.Classname.ValueString(),
+		DefaultPropInstructions: model.DefaultPropInstructions.ValueString(),
+		DefaultProps:            model.DefaultProps.ValueString(),
+		DefaultTranslator:       model.DefaultTranslator.ValueString(),
+		DefaultValidationQuery:  model.DefaultValidationQuery.ValueString(),
+		Type:                    model.Type.ValueString(),
+		URLFormat:               model.URLFormat.ValueString(),
+		URLInstructions:         model.URLInstructions.ValueString(),
+	}, nil
+}
+
+func (r *JDBCDriverResource) MapClientToState(ctx context.Context, name string, config *client.JDBCDriverConfig, model *JDBCDriverResourceModel) error {
+	model.Name = types.StringValue(name)
+	model.Classname = types.StringValue(config.Classname)
+	model.DefaultPropInstructions = base.StringToNullableString(config.DefaultPropInstructions)
+	model.DefaultProps = base.StringToNullableString(config.DefaultProps)
+	model.DefaultTranslator = base.StringToNullableString(config.DefaultTranslator)
+	model.DefaultValidationQuery = base.StringToNullableString(config.DefaultValidationQuery)
+	model.Type = types.StringValue(config.Type)
+	model.URLFormat = base.StringToNullableString(config.URLFormat)
+	model.URLInstructions = base.StringToNullableString(config.URLInstructions)
+	// The JAR files are data files of the resource rather than part of its
+	// config, so jar_files and jar_checksums are kept as planned
+	if model.JarFiles.IsNull() {
+		model.JarFiles = types.ListNull(types.StringType)
+	}
+	if model.JarChecksums.IsNull() {
+		model.JarChecksums = types.MapNull(types.StringType)
+	}
+	return nil
+}
+
+// syncJarFiles uploads the JAR files whose planned checksums differ from
+// those in the prior state and deletes those that are no longer planned, so
+// that stale JARs do not stay on the driver's classpath. It records the
+// checksums of the files on the gateway, so that failed uploads and deletions
+// are retried by the next apply.
+func (r *JDBCDriverResource) syncJarFiles(ctx context.Context, data *JDBCDriverResourceModel, prior types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+	ctx = base.WithCollection(ctx, data)
+
+	var paths []string
+	planned := map[string]string{}
+	uploaded := map[string]string{}
+	if !data.JarFiles.IsNull() {
+		diags.Append(data.JarFiles.ElementsAs(ctx, &paths, false)...)
+		diags.Append(data.JarChecksums.ElementsAs(ctx, &planned, false)...)
+	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diags.Append(prior.ElementsAs(ctx, &uploaded, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	// Each change issues the driver a new signature
+	refresh := func() bool {
+		fresh, err := r.client.GetJDBCDriver(ctx, data.Name.ValueString())
+		if err != nil {
+			diags.AddError("Error reading resource", err.Error())
+			return false
+		}
+		data.Signature = types.StringValue(fresh.Signature)
+		return true
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(uploaded)) {
+		if _, ok := planned[name]; ok {
+			continue
+		}
+		err := r.client.DeleteResourceFile(ctx, "ignition", "database-driver", data.Name.ValueString(), name, data.Signature.ValueString())
+		if err != nil {
+			diags.AddError("Error deleting JAR file", err.Error())
+			break
+		}
+		delete(uploaded, name)
+		if !refresh() {
+			break
+		}
+	}
+
+	for _, p := range paths {
+		if diags.HasError() {
+			break
+		}
+		name := filepath.Base(p)
+		if uploaded[name] == planned[name] {
+			continue
+		}
+
+		jar, err := os.ReadFile(p)
+		if err != nil {
+			diags.AddAttributeError(path.Root("jar_files"), "Error reading JAR file", err.Error())
+			break
+		}
+		if checksum := sha256Hex(jar); checksum != planned[name] {
+			diags.AddAttributeError(path.Root("jar_files"), "JAR file changed",
+				fmt.Sprintf("%s changed after the plan was made. Plan again to upload it.", p))
+			break
+		}
+
+		err = r.client.UploadResourceFile(ctx, "ignition", "database-driver", data.Name.ValueString(), name, data.Signature.ValueString(), jar)
+		if err != nil {
+			diags.AddError("Error uploading JAR file", err.Error())
+			break
+		}
+		uploaded[name] = planned[name]
+		refresh()
+	}
+
+	if data.JarFiles.IsNull() && len(uploaded) == 0 {
+		data.JarChecksums = types.MapNull(types.StringType)
+		return diags
+	}
+	checksums, d := types.MapValueFrom(ctx, types.StringType, uploaded)
+	diags.Append(d...)
+	data.JarChecksums = checksums
+	return diags
+}
+
+// jarChecksums returns the SHA-256 checksums of the JAR files at the given
+// paths, by file name
+func jarChecksums(ctx context.Context, jarFiles types.List) (types.Map, diag.Diagnostics) {
+	if jarFiles.IsUnknown() {
+		return types.MapUnknown(types.StringType), nil
+	}
+	if jarFiles.IsNull() {
+		return types.MapNull(types.StringType), nil
+	}
+
+	var diags diag.Diagnostics
+	var paths []types.String
+	diags.Append(jarFiles.ElementsAs(ctx, &paths, false)...)
+	if diags.HasError() {
+		return types.MapNull(types.StringType), diags
+	}
+
+	checksums := map[string]string{}
+	for i, p := range paths {
+		if p.IsUnknown() {
+			return types.MapUnknown(types.StringType), diags
+		}
+		name := filepath.Base(p.ValueString())
+		if _, ok := checksums[name]; ok {
+			diags.AddAttributeError(path.Root("jar_files").AtListIndex(i), "Duplicate JAR file name",
+				fmt.Sprintf("Another JAR file is already named %s; the gateway stores them by file name.", name))
+			continue
+		}
+		jar, err := os.ReadFile(p.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("jar_files").AtListIndex(i), "Error reading JAR file", err.Error())
+			continue
+		}
+		checksums[name] = sha256Hex(jar)
+	}
+
+	m, d := types.MapValueFrom(ctx, types.StringType, checksums)
+	diags.Append(d...)
+	return m, diags
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *JDBCDriverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data JDBCDriverResourceModel
+	r.generic.Create(ctx, req, resp, &data, &data.BaseResourceModel)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.syncJarFiles(ctx, &data, types.MapNull(types.StringType))...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *JDBCDriverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data JDBCDriverResourceModel
+	r.generic.Read(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *JDBCDriverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var prior types.Map
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("jar_checksums"), &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data JDBCDriverResourceModel
+	r.generic.Update(ctx, req, resp, &data, &data.BaseResourceModel)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.syncJarFiles(ctx, &data, prior)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *JDBCDriverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data JDBCDriverResourceModel
+	r.generic.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
+}
+
+func (r *JDBCDriverResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.generic.CheckReferences(ctx, req, resp, r.ReferenceAttributes()...)
+	r.generic.CheckSchema(ctx, req, resp)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var jarFiles types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("jar_files"), &jarFiles)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checksums, diags := jarChecksums(ctx, jarFiles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("jar_checksums"), checksums)...)
+}
+
+func (r *JDBCDriverResource) ReferenceAttributes() []base.Reference {
+	return []base.Reference{
+		{Path: path.Root("default_translator"), Kind: "database translator", Module: "ignition", ResourceType: "database-translator"},
+	}
+}
+
+func (r *JDBCDriverResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
+func (r *JDBCDriverResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.generic.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &JDBCDriverResourceModel{
+		BaseResourceModel: base.BaseResourceModel{
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
+		},
+		JarFiles:     types.ListNull(types.StringType),
+		JarChecksums: types.MapNull(types.StringType),
+	})...)
+}
+
+func (r *JDBCDriverResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists the JDBC driver resources configured on the gateway.")
+}
+
+func (r *JDBCDriverResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.generic.List(ctx, req, stream, func(_ *client.ResourceResponse[client.JDBCDriverConfig]) (*JDBCDriverResourceModel, *base.BaseResourceModel) {
+		var data JDBCDriverResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
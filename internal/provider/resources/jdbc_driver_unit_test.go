@@ -0,0 +1,324 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/gwbk"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func writeTestJar(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return filepath.ToSlash(p)
+}
+
+func TestUnitJDBCDriverResource(t *testing.T) {
+	jar := writeTestJar(t, t.TempDir(), "snowflake-jdbc.jar", "driver")
+	var stored *client.ResourceResponse[client.JDBCDriverConfig]
+	uploads := map[string]string{}
+
+	mockClient := &client.MockClient{
+		GeneratedMockFuncs: client.GeneratedMockFuncs{
+			CreateJDBCDriverFunc: func(ctx context.Context, item client.ResourceResponse[client.JDBCDriverConfig]) (*client.ResourceResponse[client.JDBCDriverConfig], error) {
+				item.Signature = "sig-1"
+				stored = &item
+				return &item, nil
+			},
+			GetJDBCDriverFunc: func(ctx context.Context, name string) (*client.ResourceResponse[client.JDBCDriverConfig], error) {
+				return stored, nil
+			},
+			DeleteJDBCDriverFunc: func(ctx context.Context, name, signature string) error {
+				return nil
+			},
+		},
+		UploadResourceFileFunc: func(ctx context.Context, m, rt, n, f, s string, d []byte) error {
+			uploads[f] = string(d)
+			stored.Signature = "sig-2"
+			return nil
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			ResourceFactory: NewJDBCDriverResource,
+			Client:          mockClient,
+		}),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_jdbc_driver" "test" {
+						name      = "Snowflake"
+						classname = "net.snowflake.client.jdbc.SnowflakeDriver"
+						type      = "GENERIC"
+						jar_files = ["missing.jar"]
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Error reading JAR file`),
+			},
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_jdbc_driver" "test" {
+						name               = "Snowflake"
+						classname          = "net.snowflake.client.jdbc.SnowflakeDriver"
+						type               = "GENERIC"
+						url_format         = "jdbc:snowflake://<account>.snowflakecomputing.com"
+						default_translator = "POSTGRES"
+						jar_files          = ["` + jar + `"]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_jdbc_driver.test", "jar_files.0", jar),
+					resource.TestCheckResourceAttr("ignition_jdbc_driver.test", "jar_checksums.snowflake-jdbc.jar", sha256Hex([]byte("driver"))),
+					resource.TestCheckResourceAttr("ignition_jdbc_driver.test", "signature", "sig-2"),
+					func(s *terraform.State) error {
+						if uploads["snowflake-jdbc.jar"] != "driver" {
+							return fmt.Errorf("expected the JAR to be uploaded, got %v", uploads)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitJDBCDriverJarChecksums(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	jar := writeTestJar(t, dir, "db2jcc4.jar", "v1")
+
+	paths, _ := types.ListValueFrom(ctx, types.StringType, []string{jar})
+	checksums, diags := jarChecksums(ctx, paths)
+	if diags.HasError() {
+		t.Fatalf("jarChecksums failed: %v", diags)
+	}
+	var got map[string]string
+	checksums.ElementsAs(ctx, &got, false)
+	if len(got) != 1 || got["db2jcc4.jar"] != sha256Hex([]byte("v1")) {
+		t.Errorf("Unexpected checksums: %v", got)
+	}
+
+	// Files are stored by name, so two with the same name cannot both be uploaded
+	other := writeTestJar(t, t.TempDir(), "db2jcc4.jar", "v2")
+	paths, _ = types.ListValueFrom(ctx, types.StringType, []string{jar, other})
+	if _, diags := jarChecksums(ctx, paths); !diags.HasError() {
+		t.Error("Expected an error for duplicate JAR file names")
+	}
+
+	if checksums, _ := jarChecksums(ctx, types.ListUnknown(types.StringType)); !checksums.IsUnknown() {
+		t.Errorf("Expected unknown checksums for unknown paths, got %v", checksums)
+	}
+}
+
+func TestUnitJDBCDriverSyncJarFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	unchanged := writeTestJar(t, dir, "unchanged.jar", "same")
+	changed := writeTestJar(t, dir, "changed.jar", "new")
+
+	// Each change issues the driver a new signature, which the next change
+	// must send
+	signature := 1
+	change := func(s string) error {
+		if s != fmt.Sprintf("sig-%d", signature) {
+			t.Errorf("Expected the current signature, got %q", s)
+		}
+		signature++
+		return nil
+	}
+	var uploaded, deleted []string
+	r := &JDBCDriverResource{client: &client.MockClient{
+		UploadResourceFileFunc: func(ctx context.Context, m, rt, n, f, s string, d []byte) error {
+			uploaded = append(uploaded, f)
+			return change(s)
+		},
+		DeleteResourceFileFunc: func(ctx context.Context, m, rt, n, f, s string) error {
+			deleted = append(deleted, f)
+			return change(s)
+		},
+		GeneratedMockFuncs: client.GeneratedMockFuncs{
+			GetJDBCDriverFunc: func(ctx context.Context, name string) (*client.ResourceResponse[client.JDBCDriverConfig], error) {
+				return &client.ResourceResponse[client.JDBCDriverConfig]{Name: name, Signature: fmt.Sprintf("sig-%d", signature)}, nil
+			},
+		},
+	}}
+
+	paths, _ := types.ListValueFrom(ctx, types.StringType, []string{unchanged, changed})
+	planned, _ := jarChecksums(ctx, paths)
+	prior, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{
+		"unchanged.jar": sha256Hex([]byte("same")),
+		"changed.jar":   sha256Hex([]byte("old")),
+		"removed.jar":   sha256Hex([]byte("gone")),
+	})
+	data := JDBCDriverResourceModel{
+		BaseResourceModel: base.BaseResourceModel{Name: types.StringValue("DB2"), Signature: types.StringValue("sig-1")},
+		JarFiles:          paths,
+		JarChecksums:      planned,
+	}
+
+	if diags := r.syncJarFiles(ctx, &data, prior); diags.HasError() {
+		t.Fatalf("syncJarFiles failed: %v", diags)
+	}
+	if len(uploaded) != 1 || uploaded[0] != "changed.jar" {
+		t.Errorf("Expected only the changed JAR to be uploaded, got %v", uploaded)
+	}
+	if len(deleted) != 1 || deleted[0] != "removed.jar" {
+		t.Errorf("Expected the JAR removed from the plan to be deleted, got %v", deleted)
+	}
+	if !data.JarChecksums.Equal(planned) || data.Signature.ValueString() != "sig-3" {
+		t.Errorf("Unexpected state after upload: %v, %v", data.JarChecksums, data.Signature)
+	}
+
+	// A file changed since the plan is not uploaded, and stays pending
+	if err := os.WriteFile(changed, []byte("newer"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	uploaded = nil
+	if diags := r.syncJarFiles(ctx, &data, types.MapNull(types.StringType)); !diags.HasError() {
+		t.Error("Expected an error for a JAR file changed after the plan")
+	}
+	var got map[string]string
+	data.JarChecksums.ElementsAs(ctx, &got, false)
+	if _, ok := got["changed.jar"]; ok || got["unchanged.jar"] == "" {
+		t.Errorf("Expected only the uploaded JAR to be recorded, got %v", got)
+	}
+
+	// Removing every JAR file deletes them all
+	deleted = nil
+	data.JarFiles = types.ListNull(types.StringType)
+	data.JarChecksums = types.MapNull(types.StringType)
+	if diags := r.syncJarFiles(ctx, &data, planned); diags.HasError() {
+		t.Fatalf("syncJarFiles failed: %v", diags)
+	}
+	if len(deleted) != 2 || !data.JarChecksums.IsNull() {
+		t.Errorf("Expected both JARs to be deleted, got %v, %v", deleted, data.JarChecksums)
+	}
+
+	// A failed deletion stays recorded, so that it is retried
+	r.client = &client.MockClient{
+		DeleteResourceFileFunc: func(ctx context.Context, m, rt, n, f, s string) error {
+			return fmt.Errorf("simulated deletion failure")
+		},
+	}
+	if diags := r.syncJarFiles(ctx, &data, prior); !diags.HasError() {
+		t.Error("Expected the deletion failure to be reported")
+	}
+	if len(data.JarChecksums.Elements()) != 3 {
+		t.Errorf("Expected the JARs still on the gateway to be recorded, got %v", data.JarChecksums)
+	}
+}
+
+func TestUnitJDBCDriverMapping(t *testing.T) {
+	ctx := context.Background()
+	r := &JDBCDriverResource{}
+
+	paths, _ := types.ListValueFrom(ctx, types.StringType, []string{"drivers/timescale/postgresql.jar"})
+	model := JDBCDriverResourceModel{
+		Classname: types.StringValue("org.postgresql.Driver"),
+		Type:      types.StringValue("POSTGRES"),
+		JarFiles:  paths,
+	}
+	config, err := r.MapPlanToClient(ctx, &model)
+	if err != nil {
+		t.Fatalf("MapPlanToClient failed: %v", err)
+	}
+
+	// The gateway stores the JAR files beside the config, so the local paths are kept
+	if err := r.MapClientToState(ctx, "TimescaleDB", &config, &model); err != nil {
+		t.Fatalf("MapClientToState failed: %v", err)
+	}
+	if !model.JarFiles.Equal(paths) || !model.DefaultProps.IsNull() {
+		t.Errorf("Unexpected state: %+v", model)
+	}
+}
+
+// backupClient returns a filesystem client over the data directory of the
+// gateway backup in assets
+func backupClient(t *testing.T) *client.FilesystemClient {
+	t.Helper()
+	dir := t.TempDir()
+	if err := gwbk.Extract("../../../assets/ignition.gwbk", dir); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	c, err := client.NewFilesystemClient(dir)
+	if err != nil {
+		t.Fatalf("NewFilesystemClient failed: %v", err)
+	}
+	return c
+}
+
+// assertRoundTrip checks that config marshals to the config the gateway stores
+// for the named resource
+func assertRoundTrip(t *testing.T, c client.IgnitionClient, resourceType, name string, config any) {
+	t.Helper()
+	var res client.ResourceResponse[json.RawMessage]
+	if err := c.GetResourceWithModule(context.Background(), "ignition", resourceType, name, &res); err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want, got map[string]any
+	if err := json.Unmarshal(res.Config, &want); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s did not round-trip:\n got: %s\nwant: %s", name, data, res.Config)
+	}
+}
+
+func TestUnitJDBCDriverBackupRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c := backupClient(t)
+	r := &JDBCDriverResource{}
+
+	for _, name := range []string{"MySQL", "SQLite"} {
+		res, err := c.GetJDBCDriver(ctx, name)
+		if err != nil {
+			t.Fatalf("GetJDBCDriver failed: %v", err)
+		}
+		var model JDBCDriverResourceModel
+		if err := r.MapClientToState(ctx, name, &res.Config, &model); err != nil {
+			t.Fatalf("MapClientToState failed: %v", err)
+		}
+		config, err := r.MapPlanToClient(ctx, &model)
+		if err != nil {
+			t.Fatalf("MapPlanToClient failed: %v", err)
+		}
+		assertRoundTrip(t, c, "database-driver", name, config)
+	}
+}
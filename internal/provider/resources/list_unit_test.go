@@ -0,0 +1,94 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestUnitListResources(t *testing.T) {
+	mockClient := &client.MockClient{
+		ListResourcesWithModuleFunc: func(ctx context.Context, m, rt string) ([]client.ResourceListItem, error) {
+			if m != "ignition" || rt != "database-connection" {
+				return nil, nil
+			}
+			return []client.ResourceListItem{{Name: "historian"}, {Name: "mes"}}, nil
+		},
+		GetDatabaseConnectionFunc: func(ctx context.Context, name string) (*client.ResourceResponse[client.DatabaseConfig], error) {
+			return &client.ResourceResponse[client.DatabaseConfig]{
+				Name:      name,
+				Signature: "sig-" + name,
+				Enabled:   base.BoolPtr(true),
+				Config:    client.DatabaseConfig{Driver: "PostgreSQL", Translator: "POSTGRES", ConnectURL: "jdbc:postgresql://db/" + name},
+			}, nil
+		},
+		ListProjectsFunc: func(ctx context.Context) ([]client.Project, error) {
+			return []client.Project{{Name: "hmi"}}, nil
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			Client: mockClient,
+			ListResourceFactories: []func() list.ListResource{
+				NewDatabaseConnectionListResource,
+				NewProjectListResource,
+			},
+		}),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+				`,
+			},
+			{
+				Query: true,
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					list "ignition_database_connection" "all" {
+						provider = ignition
+					}
+					list "ignition_project" "all" {
+						provider = ignition
+					}
+				`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("ignition_database_connection.all", 2),
+					querycheck.ExpectIdentity("ignition_database_connection.all", map[string]knownvalue.Check{
+						"module": knownvalue.StringExact("ignition"),
+						"type":   knownvalue.StringExact("database-connection"),
+						"name":   knownvalue.StringExact("historian"),
+					}),
+					querycheck.ExpectLength("ignition_project.all", 1),
+					querycheck.ExpectIdentity("ignition_project.all", map[string]knownvalue.Check{
+						"module": knownvalue.StringExact("ignition"),
+						"type":   knownvalue.StringExact("project"),
+						"name":   knownvalue.StringExact("hmi"),
+					}),
+				},
+			},
+		},
+	})
+}
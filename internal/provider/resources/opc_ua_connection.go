@@ -2,11 +2,22 @@ package resources
 
 import (
 	"context"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"maps"
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -19,12 +30,22 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &OpcUaConnectionResource{}
 var _ resource.ResourceWithImportState = &OpcUaConnectionResource{}
+var _ resource.ResourceWithIdentity = &OpcUaConnectionResource{}
+var _ resource.ResourceWithModifyPlan = &OpcUaConnectionResource{}
+var _ base.ResourceWithSecrets = &OpcUaConnectionResource{}
+var _ list.ListResourceWithConfigure = &OpcUaConnectionResource{}
 
 func NewOpcUaConnectionResource() resource.Resource {
 	return &OpcUaConnectionResource{}
 }
 
-// OpcUaConnectionResource defines the resource implementation.
+func NewOpcUaConnectionListResource() list.ListResource {
+	return &OpcUaConnectionResource{}
+}
+
+// OpcUaConnectionResource defines the resource implementation. The server
+// certificate is trusted before the connection is saved, so that it can connect
+// straight away, and is removed from the trust store with the connection.
 type OpcUaConnectionResource struct {
 	base.GenericIgnitionResource[client.OpcUaConnectionConfig, OpcUaConnectionResourceModel]
 }
@@ -32,15 +53,40 @@ type OpcUaConnectionResource struct {
 // OpcUaConnectionResourceModel describes the resource data model.
 type OpcUaConnectionResourceModel struct {
 	base.BaseResourceModel
+	base.CollectionResourceModel
 	Type           types.String `tfsdk:"type"`
 	DiscoveryURL   types.String `tfsdk:"discovery_url"`
 	EndpointURL    types.String `tfsdk:"endpoint_url"`
 	SecurityPolicy types.String `tfsdk:"security_policy"`
 	SecurityMode   types.String `tfsdk:"security_mode"`
+	// User identity
+	Username            types.String              `tfsdk:"username"`
+	Password            types.String              `tfsdk:"password"`
+	CertificateIdentity *OpcUaCertificateIdentity `tfsdk:"certificate_identity"`
+	ServerCertificate   types.String              `tfsdk:"server_certificate"`
+	// Session settings, which keep the gateway's defaults when left out
+	ConnectTimeout             types.Int64  `tfsdk:"connect_timeout"`
+	RequestTimeout             types.Int64  `tfsdk:"request_timeout"`
+	SessionTimeout             types.Int64  `tfsdk:"session_timeout"`
+	MaxPerOperation            types.Int64  `tfsdk:"max_per_operation"`
+	MaxNotificationsPerPublish types.Int64  `tfsdk:"max_notifications_per_publish"`
+	BrowseOrigin               types.String `tfsdk:"browse_origin"`
+	// Keep-alive settings, which keep the gateway's defaults when left out
+	KeepAliveInterval        types.Int64 `tfsdk:"keep_alive_interval"`
+	KeepAliveTimeout         types.Int64 `tfsdk:"keep_alive_timeout"`
+	KeepAliveFailuresAllowed types.Int64 `tfsdk:"keep_alive_failures_allowed"`
+}
+
+// OpcUaCertificateIdentity is an X.509 user identity certificate and its
+// private key
+type OpcUaCertificateIdentity struct {
+	Certificate types.String `tfsdk:"certificate"`
+	PrivateKey  types.String `tfsdk:"private_key"`
 }
 
 func (r *OpcUaConnectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_opc_ua_connection"
+	base.AllowRenames(resp)
 }
 
 func (r *OpcUaConnectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -50,15 +96,12 @@ func (r *OpcUaConnectionResource) Schema(ctx context.Context, req resource.Schem
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the OPC UA connection.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Description: "The description of the OPC UA connection.",
@@ -109,6 +152,59 @@ func (r *OpcUaConnectionResource) Schema(ctx context.Context, req resource.Schem
 					),
 				},
 			},
+			"username": schema.StringAttribute{
+				Description: "The username sessions are activated with. Without a username or certificate_identity, sessions are anonymous.",
+				Optional:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "The password of the username. It is encrypted by the gateway before it is saved.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("username")),
+				},
+			},
+			"certificate_identity": schema.SingleNestedAttribute{
+				Description: "An X.509 certificate sessions are activated with, instead of a username.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"certificate": schema.StringAttribute{
+						Description: "The PEM-encoded certificate.",
+						Required:    true,
+					},
+					"private_key": schema.StringAttribute{
+						Description: "The PEM-encoded private key of the certificate. It is encrypted by the gateway before it is saved.",
+						Required:    true,
+						Sensitive:   true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("username")),
+				},
+			},
+			"server_certificate": schema.StringAttribute{
+				Description: "The PEM-encoded certificate of the server, added to the trust store of the gateway's OPC UA " +
+					"client so that the connection is trusted without approving it in the web UI. It is removed from " +
+					"the trust store when it changes or the connection is destroyed. Not supported in filesystem mode.",
+				Optional: true,
+			},
+			"connect_timeout":             gatewayDefaultInt64("The milliseconds to wait for a connection to the server.", 1),
+			"request_timeout":             gatewayDefaultInt64("The milliseconds to wait for a response to a request.", 1),
+			"session_timeout":             gatewayDefaultInt64("The milliseconds the server keeps a session without activity.", 1),
+			"keep_alive_interval":         gatewayDefaultInt64("The milliseconds between keep-alive reads of the server's state.", 1),
+			"keep_alive_timeout":          gatewayDefaultInt64("The milliseconds to wait for a response to a keep-alive read.", 1),
+			"keep_alive_failures_allowed": gatewayDefaultInt64("The number of keep-alive reads that may fail before the connection is reestablished.", 0),
+			"max_per_operation":           gatewayDefaultInt64("The maximum number of nodes in each browse, read, write, or subscription request.", 1),
+			"browse_origin": schema.StringAttribute{
+				Description: "The folder that browsing the server starts from (e.g., OBJECTS_FOLDER).",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_notifications_per_publish": gatewayDefaultInt64("The maximum number of notifications in each publish response, or 0 for no limit.", 0),
+			"collection":                    base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Description: "The signature of the resource, used for updates and deletes.",
 				Computed:    true,
@@ -138,8 +234,9 @@ func (r *OpcUaConnectionResource) Configure(ctx context.Context, req resource.Co
 		ResourceType: "opc-connection",
 		CreateFunc:   c.CreateOpcUaConnection,
 		GetFunc:      c.GetOpcUaConnection,
-		UpdateFunc:   c.UpdateOpcUaConnection,
+		UpdateFunc:   r.update,
 		DeleteFunc:   c.DeleteOpcUaConnection,
+		Schemas:      base.SchemaValidatorFrom(req.ProviderData),
 	}
 }
 
@@ -149,6 +246,11 @@ func (r *OpcUaConnectionResource) MapPlanToClient(ctx context.Context, model *Op
 		profileType = model.Type.ValueString()
 	}
 
+	authentication, err := r.mapAuthenticationToClient(ctx, model)
+	if err != nil {
+		return client.OpcUaConnectionConfig{}, err
+	}
+
 	return client.OpcUaConnectionConfig{
 		Profile: client.OpcUaConnectionProfile{
 			Type: profileType,
@@ -160,10 +262,55 @@ func (r *OpcUaConnectionResource) MapPlanToClient(ctx context.Context, model *Op
 				SecurityPolicy: model.SecurityPolicy.ValueString(),
 				SecurityMode:   model.SecurityMode.ValueString(),
 			},
+			Authentication: authentication,
+			KeepAlive: client.OpcUaConnectionKeepAlive{
+				Interval:        base.Int64Pointer(model.KeepAliveInterval),
+				Timeout:         base.Int64Pointer(model.KeepAliveTimeout),
+				FailuresAllowed: base.Int64Pointer(model.KeepAliveFailuresAllowed),
+			},
+			Advanced: client.OpcUaConnectionAdvanced{
+				ConnectTimeout:             base.Int64Pointer(model.ConnectTimeout),
+				RequestTimeout:             base.Int64Pointer(model.RequestTimeout),
+				SessionTimeout:             base.Int64Pointer(model.SessionTimeout),
+				MaxPerOperation:            base.Int64Pointer(model.MaxPerOperation),
+				MaxNotificationsPerPublish: base.Int64Pointer(model.MaxNotificationsPerPublish),
+				BrowseOrigin:               base.StringPointer(model.BrowseOrigin),
+			},
 		},
 	}, nil
 }
 
+// mapAuthenticationToClient returns the user identity of the model, with its
+// secrets encrypted, or nil for anonymous sessions. Secrets are not encrypted
+// while planning.
+func (r *OpcUaConnectionResource) mapAuthenticationToClient(ctx context.Context, model *OpcUaConnectionResourceModel) (*client.OpcUaConnectionAuthentication, error) {
+	encrypt := func(plaintext types.String) (*client.IgnitionSecret, error) {
+		if plaintext.IsNull() || plaintext.IsUnknown() || base.IsPlanning(ctx) {
+			return nil, nil
+		}
+		return r.Client.EncryptSecret(ctx, plaintext.ValueString())
+	}
+
+	switch {
+	case !model.Username.IsNull():
+		password, err := encrypt(model.Password)
+		if err != nil {
+			return nil, err
+		}
+		return &client.OpcUaConnectionAuthentication{Username: model.Username.ValueString(), Password: password}, nil
+	case model.CertificateIdentity != nil:
+		privateKey, err := encrypt(model.CertificateIdentity.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		return &client.OpcUaConnectionAuthentication{
+			Certificate: model.CertificateIdentity.Certificate.ValueString(),
+			PrivateKey:  privateKey,
+		}, nil
+	}
+	return nil, nil
+}
+
 func (r *OpcUaConnectionResource) MapClientToState(ctx context.Context, name string, config *client.OpcUaConnectionConfig, model *OpcUaConnectionResourceModel) error {
 	model.Name = types.StringValue(name)
 	model.Type = types.StringValue(config.Profile.Type)
@@ -171,10 +318,164 @@ func (r *OpcUaConnectionResource) MapClientToState(ctx context.Context, name str
 	model.EndpointURL = types.StringValue(config.Settings.Endpoint.EndpointURL)
 	model.SecurityPolicy = types.StringValue(config.Settings.Endpoint.SecurityPolicy)
 	model.SecurityMode = types.StringValue(config.Settings.Endpoint.SecurityMode)
+
+	// The gateway never returns secrets, so the configured ones are kept
+	auth := config.Settings.Authentication
+	switch {
+	case auth != nil && auth.Username != "":
+		model.Username = types.StringValue(auth.Username)
+		model.CertificateIdentity = nil
+	case auth != nil && auth.Certificate != "":
+		privateKey := types.StringNull()
+		if model.CertificateIdentity != nil {
+			privateKey = model.CertificateIdentity.PrivateKey
+		}
+		model.Username = types.StringNull()
+		model.Password = types.StringNull()
+		model.CertificateIdentity = &OpcUaCertificateIdentity{
+			Certificate: types.StringValue(auth.Certificate),
+			PrivateKey:  privateKey,
+		}
+	default:
+		model.Username = types.StringNull()
+		model.Password = types.StringNull()
+		model.CertificateIdentity = nil
+	}
+
+	advanced := config.Settings.Advanced
+	model.ConnectTimeout = types.Int64PointerValue(advanced.ConnectTimeout)
+	model.RequestTimeout = types.Int64PointerValue(advanced.RequestTimeout)
+	model.SessionTimeout = types.Int64PointerValue(advanced.SessionTimeout)
+	model.MaxPerOperation = types.Int64PointerValue(advanced.MaxPerOperation)
+	model.MaxNotificationsPerPublish = types.Int64PointerValue(advanced.MaxNotificationsPerPublish)
+	model.BrowseOrigin = types.StringPointerValue(advanced.BrowseOrigin)
+
+	keepAlive := config.Settings.KeepAlive
+	model.KeepAliveInterval = types.Int64PointerValue(keepAlive.Interval)
+	model.KeepAliveTimeout = types.Int64PointerValue(keepAlive.Timeout)
+	model.KeepAliveFailuresAllowed = types.Int64PointerValue(keepAlive.FailuresAllowed)
 	return nil
 }
 
+// update saves the connection over its stored config, so that the settings the
+// resource does not manage (failover, security, and further advanced settings)
+// are kept. The authentication is replaced as a whole, so that switching to
+// another kind of user identity leaves no stale secret behind.
+func (r *OpcUaConnectionResource) update(ctx context.Context, res client.ResourceResponse[client.OpcUaConnectionConfig]) (*client.ResourceResponse[client.OpcUaConnectionConfig], error) {
+	var stored client.ResourceResponse[map[string]any]
+	if err := r.Client.GetResourceWithModule(ctx, r.Module, r.ResourceType, res.Name, &stored); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(res.Config)
+	if err != nil {
+		return nil, err
+	}
+	var planned map[string]any
+	if err := json.Unmarshal(data, &planned); err != nil {
+		return nil, err
+	}
+
+	config := mergeConfig(stored.Config, planned)
+	if settings, ok := config["settings"].(map[string]any); ok {
+		delete(settings, "authentication")
+		if res.Config.Settings.Authentication != nil {
+			settings["authentication"] = planned["settings"].(map[string]any)["authentication"]
+		}
+	}
+
+	var updated client.ResourceResponse[client.OpcUaConnectionConfig]
+	err = r.Client.UpdateResourceWithModule(ctx, r.Module, r.ResourceType, client.ResourceResponse[map[string]any]{
+		Name:        res.Name,
+		Collection:  res.Collection,
+		Enabled:     res.Enabled,
+		Description: res.Description,
+		Signature:   res.Signature,
+		Config:      config,
+	}, &updated)
+	return &updated, err
+}
+
+// mergeConfig returns the stored config with the planned one laid over it.
+// Objects are merged key by key; any other planned value replaces the stored one.
+func mergeConfig(stored, planned map[string]any) map[string]any {
+	merged := make(map[string]any, len(stored))
+	maps.Copy(merged, stored)
+	for key, value := range planned {
+		if object, ok := value.(map[string]any); ok {
+			if prior, ok := merged[key].(map[string]any); ok {
+				merged[key] = mergeConfig(prior, object)
+				continue
+			}
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// opcUaTrustedCertificate parses a PEM certificate into a trust store entry,
+// identified by its SHA-1 thumbprint as OPC UA identifies certificates
+func opcUaTrustedCertificate(certificate string) (client.OpcUaTrustedCertificate, error) {
+	block, _ := pem.Decode([]byte(certificate))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return client.OpcUaTrustedCertificate{}, errors.New("expected a PEM-encoded certificate")
+	}
+	if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+		return client.OpcUaTrustedCertificate{}, fmt.Errorf("invalid certificate: %w", err)
+	}
+	thumbprint := sha1.Sum(block.Bytes)
+	return client.OpcUaTrustedCertificate{
+		Thumbprint:  hex.EncodeToString(thumbprint[:]),
+		Certificate: certificate,
+	}, nil
+}
+
+// trustServerCertificate adds the server certificate to the trust store
+func (r *OpcUaConnectionResource) trustServerCertificate(ctx context.Context, certificate types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if certificate.IsNull() || certificate.IsUnknown() {
+		return diags
+	}
+
+	cert, err := opcUaTrustedCertificate(certificate.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("server_certificate"), "Invalid Server Certificate", err.Error())
+		return diags
+	}
+	if err := r.Client.TrustOpcUaCertificate(ctx, cert); err != nil {
+		diags.AddAttributeError(path.Root("server_certificate"), "Error trusting server certificate", err.Error())
+	}
+	return diags
+}
+
+// untrustServerCertificate removes a server certificate that is no longer
+// configured from the trust store. Failures are warnings, as the connection
+// itself has been saved or deleted.
+func (r *OpcUaConnectionResource) untrustServerCertificate(ctx context.Context, certificate, keep types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if certificate.IsNull() || certificate.IsUnknown() || certificate.Equal(keep) {
+		return diags
+	}
+
+	cert, err := opcUaTrustedCertificate(certificate.ValueString())
+	if err == nil {
+		err = r.Client.DeleteOpcUaTrustedCertificate(ctx, cert.Thumbprint)
+	}
+	if err != nil {
+		diags.AddWarning("Error removing server certificate",
+			fmt.Sprintf("The previous server certificate could not be removed from the OPC UA client trust store: %s", err))
+	}
+	return diags
+}
+
 func (r *OpcUaConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var serverCertificate types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("server_certificate"), &serverCertificate)...)
+	resp.Diagnostics.Append(r.trustServerCertificate(ctx, serverCertificate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data OpcUaConnectionResourceModel
 	r.GenericIgnitionResource.Create(ctx, req, resp, &data, &data.BaseResourceModel)
 }
@@ -185,20 +486,86 @@ func (r *OpcUaConnectionResource) Read(ctx context.Context, req resource.ReadReq
 }
 
 func (r *OpcUaConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var prior, serverCertificate types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("server_certificate"), &prior)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("server_certificate"), &serverCertificate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !serverCertificate.Equal(prior) {
+		resp.Diagnostics.Append(r.trustServerCertificate(ctx, serverCertificate)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	var data OpcUaConnectionResourceModel
 	r.GenericIgnitionResource.Update(ctx, req, resp, &data, &data.BaseResourceModel)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.untrustServerCertificate(ctx, prior, serverCertificate)...)
 }
 
 func (r *OpcUaConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data OpcUaConnectionResourceModel
 	r.GenericIgnitionResource.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.untrustServerCertificate(ctx, data.ServerCertificate, types.StringNull())...)
+}
+
+func (r *OpcUaConnectionResource) SecretAttributes() []base.Secret {
+	return []base.Secret{
+		{Path: path.Root("password"), Key: "settings.authentication.password"},
+		{Path: path.Root("certificate_identity").AtName("private_key"), Key: "settings.authentication.privateKey"},
+	}
+}
+
+func (r *OpcUaConnectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.CheckSchema(ctx, req, resp)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var serverCertificate types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("server_certificate"), &serverCertificate)...)
+	if serverCertificate.IsNull() || serverCertificate.IsUnknown() {
+		return
+	}
+	if _, err := opcUaTrustedCertificate(serverCertificate.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("server_certificate"), "Invalid Server Certificate", err.Error())
+	}
+}
+
+func (r *OpcUaConnectionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
 }
 
 func (r *OpcUaConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.GenericIgnitionResource.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &OpcUaConnectionResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
 	})...)
 }
+
+func (r *OpcUaConnectionResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists OPC UA Connections configured on the gateway.")
+}
+
+func (r *OpcUaConnectionResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.GenericIgnitionResource.List(ctx, req, stream, func(_ *client.ResourceResponse[client.OpcUaConnectionConfig]) (*OpcUaConnectionResourceModel, *base.BaseResourceModel) {
+		var data OpcUaConnectionResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
@@ -6,21 +6,30 @@ import (
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ProjectResource{}
 var _ resource.ResourceWithImportState = &ProjectResource{}
+var _ resource.ResourceWithIdentity = &ProjectResource{}
+var _ resource.ResourceWithModifyPlan = &ProjectResource{}
+var _ base.ResourceWithReferences = &ProjectResource{}
+var _ list.ListResourceWithConfigure = &ProjectResource{}
 
 func NewProjectResource() resource.Resource {
 	return &ProjectResource{}
 }
 
+func NewProjectListResource() list.ListResource {
+	return &ProjectResource{}
+}
+
 // ProjectResource defines the resource implementation.
 type ProjectResource struct {
 	base.GenericIgnitionResource[client.Project, ProjectResourceModel]
@@ -40,6 +49,7 @@ type ProjectResourceModel struct {
 
 func (r *ProjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_project"
+	base.AllowRenames(resp)
 }
 
 func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -49,15 +59,12 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the project.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Description: "The description of the project.",
@@ -162,6 +169,22 @@ func (r *ProjectResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.DeleteFunc = func(ctx context.Context, name, signature string) error {
 		return apiClient.DeleteProject(ctx, name)
 	}
+	r.RenameFunc = func(ctx context.Context, name, newName, signature string) error {
+		_, err := apiClient.RenameProject(ctx, name, newName)
+		return err
+	}
+	r.ListFunc = func(ctx context.Context) ([]string, error) {
+		projects, err := apiClient.ListProjects(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(projects))
+		for _, p := range projects {
+			names = append(names, p.Name)
+		}
+		return names, nil
+	}
+	r.References = base.ReferenceValidatorFrom(req.ProviderData)
 }
 
 func (r *ProjectResource) MapPlanToClient(ctx context.Context, model *ProjectResourceModel) (client.Project, error) {
@@ -232,11 +255,44 @@ func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest
 	r.GenericIgnitionResource.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
 
+func (r *ProjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.CheckReferences(ctx, req, resp, r.ReferenceAttributes()...)
+}
+
+func (r *ProjectResource) ReferenceAttributes() []base.Reference {
+	return []base.Reference{
+		{Path: path.Root("default_db"), Kind: "database connection", Module: "ignition", ResourceType: "database-connection"},
+		{Path: path.Root("tag_provider"), Kind: "tag provider", Module: "ignition", ResourceType: "tag-provider"},
+		{Path: path.Root("user_source"), Kind: "user source", Module: "ignition", ResourceType: "user-source"},
+		{Path: path.Root("identity_provider"), Kind: "identity provider", Module: "ignition", ResourceType: "identity-provider"},
+	}
+}
+
+func (r *ProjectResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
 func (r *ProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.GenericIgnitionResource.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &ProjectResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
 	})...)
 }
+
+func (r *ProjectResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists Projects configured on the gateway.")
+}
+
+func (r *ProjectResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.GenericIgnitionResource.List(ctx, req, stream, func(_ *client.ResourceResponse[client.Project]) (*ProjectResourceModel, *base.BaseResourceModel) {
+		var data ProjectResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
@@ -0,0 +1,330 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RawResource{}
+var _ resource.ResourceWithImportState = &RawResource{}
+var _ resource.ResourceWithIdentity = &RawResource{}
+var _ resource.ResourceWithModifyPlan = &RawResource{}
+
+func NewRawResource() resource.Resource {
+	return &RawResource{}
+}
+
+// RawResource manages a gateway resource of any module and type through the
+// generic resource endpoints, for resource types without a dedicated resource
+// (e.g., those of third-party modules).
+type RawResource struct {
+	client  client.IgnitionClient
+	schemas *base.SchemaValidator
+}
+
+// RawResourceModel describes the resource data model.
+type RawResourceModel struct {
+	base.BaseResourceModel
+	base.CollectionResourceModel
+	Module types.String         `tfsdk:"module"`
+	Type   types.String         `tfsdk:"type"`
+	Config jsontypes.Normalized `tfsdk:"config"`
+}
+
+func (r *RawResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource"
+	base.AllowRenames(resp)
+}
+
+func (r *RawResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a gateway resource of any module and type as raw JSON. Use it for resource types " +
+			"that have no dedicated resource, such as those of third-party modules.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					base.UseNameForID(),
+				},
+			},
+			"module": schema.StringAttribute{
+				Description: "The gateway module that owns the resource (e.g., ignition, com.inductiveautomation.opcua).",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "The resource type within the module (e.g., database-connection).",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the resource.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the resource.",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the resource is enabled.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"config": schema.StringAttribute{
+				Description: "The JSON config of the resource, as the gateway's REST API represents it. " +
+					"Settings populated with defaults by the gateway do not need to be specified.",
+				Required:   true,
+				CustomType: jsontypes.NormalizedType{IgnoreDefaults: true},
+			},
+			"collection": base.CollectionAttribute(),
+			"signature": schema.StringAttribute{
+				Description: "The signature of the resource, used for updates and deletes.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *RawResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClient, ok := req.ProviderData.(client.IgnitionClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.IgnitionClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = apiClient
+	r.schemas = base.SchemaValidatorFrom(req.ProviderData)
+}
+
+// mapPlanToClient converts the model into the generic resource representation
+func (r *RawResource) mapPlanToClient(data *RawResourceModel) client.ResourceResponse[json.RawMessage] {
+	res := client.ResourceResponse[json.RawMessage]{
+		Module:    data.Module.ValueString(),
+		Type:      data.Type.ValueString(),
+		Name:      data.Name.ValueString(),
+		Enabled:   base.BoolPtr(data.Enabled.ValueBool()),
+		Signature: data.Signature.ValueString(),
+		Config:    json.RawMessage(data.Config.ValueString()),
+	}
+	if !data.Description.IsNull() {
+		res.Description = data.Description.ValueString()
+	}
+	return res
+}
+
+// mapClientToState updates the model from the gateway's representation. The
+// module and type are kept as configured, since the gateway may report a more
+// specific type (e.g., a device's driver).
+func (r *RawResource) mapClientToState(res *client.ResourceResponse[json.RawMessage], data *RawResourceModel) {
+	data.Id = types.StringValue(res.Name)
+	data.Name = types.StringValue(res.Name)
+	data.Signature = types.StringValue(res.Signature)
+	if res.Enabled != nil {
+		data.Enabled = types.BoolValue(*res.Enabled)
+	} else {
+		data.Enabled = types.BoolValue(true)
+	}
+	if res.Description != "" {
+		data.Description = types.StringValue(res.Description)
+	} else if data.Description.IsNull() || data.Description.IsUnknown() {
+		data.Description = types.StringNull()
+	}
+
+	config := string(res.Config)
+	if len(res.Config) == 0 || config == "null" {
+		config = "{}"
+	}
+	data.Config = jsontypes.NewNormalizedValue(config)
+	base.SetCollection(data, res.Collection)
+}
+
+func (r *RawResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RawResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = base.WithCollection(ctx, &data)
+
+	var created client.ResourceResponse[json.RawMessage]
+	item := r.mapPlanToClient(&data)
+	item.Signature = ""
+	if err := r.client.CreateResourceWithModule(ctx, item.Module, item.Type, item, &created); err != nil {
+		resp.Diagnostics.AddError("Error creating resource", err.Error())
+		return
+	}
+
+	r.mapClientToState(&created, &data)
+	resp.Diagnostics.Append(base.SetResourceIdentity(ctx, resp.Identity, data.Module.ValueString(), data.Type.ValueString(), data.Name.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *RawResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RawResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = base.WithCollection(ctx, &data)
+
+	var res client.ResourceResponse[json.RawMessage]
+	if err := r.client.GetResourceWithModule(ctx, data.Module.ValueString(), data.Type.ValueString(), data.Name.ValueString(), &res); err != nil {
+		resp.Diagnostics.AddError("Error reading resource", err.Error())
+		return
+	}
+
+	r.mapClientToState(&res, &data)
+	resp.Diagnostics.Append(base.SetResourceIdentity(ctx, resp.Identity, data.Module.ValueString(), data.Type.ValueString(), data.Name.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *RawResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RawResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = base.WithCollection(ctx, &data)
+
+	module, resourceType := data.Module.ValueString(), data.Type.ValueString()
+	data.Signature = state.Signature
+
+	// Rename in place first; renaming changes the signature
+	if name := data.Name.ValueString(); name != state.Name.ValueString() {
+		if err := r.client.RenameResourceWithModule(ctx, module, resourceType, state.Name.ValueString(), name, state.Signature.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Error renaming resource", err.Error())
+			return
+		}
+
+		// Keep state in step with the gateway in case the update fails
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), name)...)
+		resp.Diagnostics.Append(base.SetResourceIdentity(ctx, resp.Identity, module, resourceType, name)...)
+
+		var renamed client.ResourceResponse[json.RawMessage]
+		if err := r.client.GetResourceWithModule(ctx, module, resourceType, name, &renamed); err != nil {
+			resp.Diagnostics.AddError("Error reading renamed resource", err.Error())
+			return
+		}
+		data.Signature = types.StringValue(renamed.Signature)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("signature"), renamed.Signature)...)
+	}
+
+	var updated client.ResourceResponse[json.RawMessage]
+	if err := r.client.UpdateResourceWithModule(ctx, module, resourceType, r.mapPlanToClient(&data), &updated); err != nil {
+		resp.Diagnostics.AddError("Error updating resource", err.Error())
+		return
+	}
+	if updated.Signature == "" {
+		if err := r.client.GetResourceWithModule(ctx, module, resourceType, data.Name.ValueString(), &updated); err != nil {
+			resp.Diagnostics.AddError("Error reading updated resource", err.Error())
+			return
+		}
+	}
+
+	r.mapClientToState(&updated, &data)
+	resp.Diagnostics.Append(base.SetResourceIdentity(ctx, resp.Identity, module, resourceType, data.Name.ValueString())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *RawResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RawResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = base.WithCollection(ctx, &data)
+
+	err := r.client.DeleteResourceWithModule(ctx, data.Module.ValueString(), data.Type.ValueString(), data.Name.ValueString(), data.Signature.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting resource", err.Error())
+	}
+}
+
+// ModifyPlan checks the config against the gateway's schema for the resource type
+func (r *RawResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.schemas == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data RawResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Module.IsUnknown() || data.Type.IsUnknown() || data.Config.IsUnknown() {
+		return
+	}
+
+	var config any
+	if err := json.Unmarshal([]byte(data.Config.ValueString()), &config); err != nil {
+		return
+	}
+	resp.Diagnostics.Append(r.schemas.Check(ctx, data.Module.ValueString(), data.Type.ValueString(), config, false, func([]string) path.Path {
+		return path.Root("config")
+	})...)
+}
+
+func (r *RawResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
+// ImportState imports a resource by an import ID of the form module/type/name,
+// or by its identity
+func (r *RawResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity base.ResourceIdentityModel
+	if req.ID != "" {
+		parts := strings.SplitN(req.ID, "/", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			resp.Diagnostics.AddError(
+				"Unexpected Import Identifier",
+				fmt.Sprintf("Expected an import ID of the form module/type/name, got: %q.", req.ID),
+			)
+			return
+		}
+		identity.Module = types.StringValue(parts[0])
+		identity.Type = types.StringValue(parts[1])
+		identity.Name = types.StringValue(parts[2])
+	} else if req.Identity != nil {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		resp.Diagnostics.AddError("Missing Import Identifier", "Either an import ID or a resource identity must be supplied.")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("module"), identity.Module)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), identity.Type)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), identity.Name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.Name)...)
+}
@@ -0,0 +1,141 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnitRawResource(t *testing.T) {
+	var stored *client.ResourceResponse[json.RawMessage]
+	revision := 0
+
+	// store keeps the written resource, filling in a default the configuration
+	// does not specify, and copies it into dest
+	store := func(module, resourceType string, item, dest any) error {
+		if module != "com.cirrus-link.mqtt.engine" || resourceType != "server-settings" {
+			return fmt.Errorf("unexpected resource type %s/%s", module, resourceType)
+		}
+		raw, _ := json.Marshal(item)
+		var res client.ResourceResponse[json.RawMessage]
+		if err := json.Unmarshal(raw, &res); err != nil {
+			return err
+		}
+		var config map[string]any
+		if err := json.Unmarshal(res.Config, &config); err != nil {
+			return err
+		}
+		config["keepAlive"] = 30
+		res.Config, _ = json.Marshal(config)
+		revision++
+		res.Signature = fmt.Sprintf("sig-%d", revision)
+		stored = &res
+		return json.Unmarshal(mustMarshal(res), dest)
+	}
+
+	mockClient := &client.MockClient{
+		CreateResourceWithModuleFunc: func(ctx context.Context, m, rt string, i, d any) error {
+			return store(m, rt, i, d)
+		},
+		UpdateResourceWithModuleFunc: func(ctx context.Context, m, rt string, i, d any) error {
+			return store(m, rt, i, d)
+		},
+		GetResourceWithModuleFunc: func(ctx context.Context, m, rt, n string, d any) error {
+			if stored == nil || stored.Name != n {
+				return fmt.Errorf("resource not found: %s/%s/%s", m, rt, n)
+			}
+			return json.Unmarshal(mustMarshal(stored), d)
+		},
+		RenameResourceWithModuleFunc: func(ctx context.Context, m, rt, n, nn, s string) error {
+			if s != stored.Signature {
+				return fmt.Errorf("signature mismatch")
+			}
+			stored.Name = nn
+			revision++
+			stored.Signature = fmt.Sprintf("sig-%d", revision)
+			return nil
+		},
+		DeleteResourceWithModuleFunc: func(ctx context.Context, m, rt, n, s string) error {
+			if s != stored.Signature {
+				return fmt.Errorf("signature mismatch")
+			}
+			stored = nil
+			return nil
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			ResourceFactory: NewRawResource,
+			Client:          mockClient,
+		}),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_resource" "test" {
+						module = "com.cirrus-link.mqtt.engine"
+						type   = "server-settings"
+						name   = "broker"
+						config = jsonencode({ url = "tcp://broker:1883" })
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_resource.test", "name", "broker"),
+					resource.TestCheckResourceAttr("ignition_resource.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("ignition_resource.test", "signature", "sig-1"),
+				),
+			},
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_resource" "test" {
+						module      = "com.cirrus-link.mqtt.engine"
+						type        = "server-settings"
+						name        = "primary-broker"
+						description = "Primary broker"
+						config      = jsonencode({ url = "tcp://broker:8883" })
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_resource.test", "name", "primary-broker"),
+					resource.TestCheckResourceAttr("ignition_resource.test", "description", "Primary broker"),
+				),
+			},
+			{
+				ResourceName:      "ignition_resource.test",
+				ImportState:       true,
+				ImportStateId:     "com.cirrus-link.mqtt.engine/server-settings/primary-broker",
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"config",
+				},
+			},
+		},
+	})
+}
+
+func mustMarshal(v any) []byte {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return raw
+}
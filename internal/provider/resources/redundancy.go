@@ -7,6 +7,7 @@ import (
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -19,11 +20,18 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RedundancyResource{}
+var _ resource.ResourceWithImportState = &RedundancyResource{}
+var _ resource.ResourceWithIdentity = &RedundancyResource{}
+var _ list.ListResourceWithConfigure = &RedundancyResource{}
 
 func NewRedundancyResource() resource.Resource {
 	return &RedundancyResource{}
 }
 
+func NewRedundancyListResource() list.ListResource {
+	return &RedundancyResource{}
+}
+
 // RedundancyResource defines the resource implementation.
 type RedundancyResource struct {
 	client  client.IgnitionClient
@@ -162,6 +170,7 @@ func (r *RedundancyResource) Configure(ctx context.Context, req resource.Configu
 	r.generic = base.GenericIgnitionResource[client.RedundancyConfig, RedundancyResourceModel]{
 		Client:       c,
 		Handler:      r,
+		Module:       "ignition",
 		ResourceType: "gateway-redundancy",
 		CreateFunc: func(ctx context.Context, res client.ResourceResponse[client.RedundancyConfig]) (*client.ResourceResponse[client.RedundancyConfig], error) {
 			err := c.UpdateRedundancyConfig(ctx, res.Config)
@@ -196,6 +205,9 @@ func (r *RedundancyResource) Configure(ctx context.Context, req resource.Configu
 		DeleteFunc: func(ctx context.Context, _, _ string) error {
 			return c.UpdateRedundancyConfig(ctx, client.RedundancyConfig{Role: "Independent"})
 		},
+		ListFunc: func(ctx context.Context) ([]string, error) {
+			return []string{"gateway-redundancy"}, nil
+		},
 	}
 }
 
@@ -286,3 +298,41 @@ func (r *RedundancyResource) Delete(ctx context.Context, req resource.DeleteRequ
 	var data RedundancyResourceModel
 	r.generic.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
+
+func (r *RedundancyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
+func (r *RedundancyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.generic.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
+	// Singleton resources can only be imported by their fixed key
+	if name != "gateway-redundancy" {
+		resp.Diagnostics.AddError(
+			"Invalid Import Identifier",
+			fmt.Sprintf("Expected the singleton key %q, got: %q.", "gateway-redundancy", name),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &RedundancyResourceModel{
+		BaseResourceModel: base.BaseResourceModel{
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
+		},
+	})...)
+}
+
+func (r *RedundancyResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists the Gateway Redundancy Settings configured on the gateway.")
+}
+
+func (r *RedundancyResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.generic.List(ctx, req, stream, func(_ *client.ResourceResponse[client.RedundancyConfig]) (*RedundancyResourceModel, *base.BaseResourceModel) {
+		var data RedundancyResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
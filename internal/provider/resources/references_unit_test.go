@@ -0,0 +1,141 @@
+package resources
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnitValidateReferences(t *testing.T) {
+	mockProject := &client.Project{}
+
+	mockClient := &client.MockClient{
+		ListResourcesWithModuleFunc: func(ctx context.Context, m, rt string) ([]client.ResourceListItem, error) {
+			switch rt {
+			case "tag-provider":
+				return []client.ResourceListItem{{Name: "default"}}, nil
+			case "database-driver":
+				return []client.ResourceListItem{{Name: "PostgreSQL"}}, nil
+			case "database-translator":
+				return []client.ResourceListItem{{Name: "POSTGRES"}}, nil
+			}
+			return nil, nil
+		},
+		CreateDatabaseConnectionFunc: func(ctx context.Context, db client.ResourceResponse[client.DatabaseConfig]) (*client.ResourceResponse[client.DatabaseConfig], error) {
+			db.Signature = "sig-123"
+			return &db, nil
+		},
+		GetDatabaseConnectionFunc: func(ctx context.Context, name string) (*client.ResourceResponse[client.DatabaseConfig], error) {
+			return &client.ResourceResponse[client.DatabaseConfig]{
+				Name:      name,
+				Signature: "sig-123",
+				Enabled:   base.BoolPtr(true),
+				Config:    client.DatabaseConfig{Driver: "PostgreSQL", Translator: "POSTGRES", ConnectURL: "jdbc:postgresql://db/mes"},
+			}, nil
+		},
+		CreateProjectFunc: func(ctx context.Context, p client.Project) (*client.Project, error) {
+			mockProject = &p
+			return mockProject, nil
+		},
+		GetProjectFunc: func(ctx context.Context, name string) (*client.Project, error) {
+			return mockProject, nil
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			Client:             mockClient,
+			ValidateReferences: true,
+			ResourceFactories: []func() fwresource.Resource{
+				NewDatabaseConnectionResource,
+				NewProjectResource,
+			},
+		}),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_database_connection" "mes" {
+						name        = "mes"
+						type        = "PostgreSQL"
+						translator  = "POSTGRES"
+						connect_url = "jdbc:postgresql://db/mes"
+					}
+					resource "ignition_project" "test" {
+						name         = "test-project"
+						tag_provider = "default"
+						default_db   = ignition_database_connection.mes.name
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_project.test", "default_db", "mes"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitReferenceValidatorCheck(t *testing.T) {
+	ctx := context.Background()
+	tagProviders := []client.ResourceListItem{{Name: "default"}}
+	v := base.NewReferenceValidator(&client.MockClient{
+		ListResourcesWithModuleFunc: func(ctx context.Context, m, rt string) ([]client.ResourceListItem, error) {
+			return tagProviders, nil
+		},
+	})
+
+	var schemaResp fwresource.SchemaResponse
+	(&ProjectResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	check := func(tagProvider string) diag.Diagnostics {
+		plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		plan.SetAttribute(ctx, path.Root("name"), "hmi")
+		plan.SetAttribute(ctx, path.Root("tag_provider"), tagProvider)
+		req := fwresource.ModifyPlanRequest{
+			Plan:  plan,
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		}
+		resp := fwresource.ModifyPlanResponse{Plan: plan}
+		v.Check(ctx, req, &resp, (&ProjectResource{}).ReferenceAttributes()...)
+		return resp.Diagnostics
+	}
+
+	// The target of a literal name may be planned later in the same run, so
+	// an unknown name is only a warning
+	diags := check("defualt")
+	if diags.HasError() || diags.WarningsCount() != 1 || !regexp.MustCompile(`No tag provider named "defualt"`).MatchString(diags[0].Detail()) {
+		t.Errorf("Expected a single warning about the unknown tag provider, got %v", diags)
+	}
+
+	// Listings are cached until the provider changes resources of their type
+	tagProviders = append(tagProviders, client.ResourceListItem{Name: "edge"})
+	if diags := check("edge"); diags.WarningsCount() != 1 {
+		t.Errorf("Expected the cached listing to be used, got %v", diags)
+	}
+	v.Forget("ignition", "tag-provider")
+	if diags := check("edge"); len(diags) != 0 {
+		t.Errorf("Expected the listing to be refreshed after Forget, got %v", diags)
+	}
+
+	v.Plan("ignition", "tag-provider", "planned")
+	if diags := check("planned"); len(diags) != 0 {
+		t.Errorf("Expected a planned tag provider to be accepted, got %v", diags)
+	}
+}
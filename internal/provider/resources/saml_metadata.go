@@ -0,0 +1,118 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// samlMetadataTimeout bounds the download of IdP metadata
+const samlMetadataTimeout = 30 * time.Second
+
+// loadSAMLMetadata reads IdP metadata XML from a local file, or else downloads
+// it from url
+func loadSAMLMetadata(ctx context.Context, url, file string) ([]byte, error) {
+	if file != "" {
+		return os.ReadFile(file)
+	}
+	if url == "" {
+		return nil, errors.New("either idp_metadata_url or idp_metadata_file must be set to import certificates")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, samlMetadataTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading IdP metadata from %s: status %s", url, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// samlKeyDescriptor is a KeyDescriptor of SAML metadata. Tags match elements
+// in any namespace.
+type samlKeyDescriptor struct {
+	Use          string   `xml:"use,attr"`
+	Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+}
+
+// samlMetadataCertificates returns the signing certificates of the IdP
+// described by the metadata, as PEM. Key descriptors without a use apply to
+// signing too. Metadata listing several entities yields the certificates of
+// all their IdP descriptors.
+func samlMetadataCertificates(metadata []byte) ([]string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(metadata))
+	var certificates []string
+	idpDepth := 0
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing IdP metadata: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch {
+			case t.Name.Local == "IDPSSODescriptor":
+				idpDepth++
+			case t.Name.Local == "KeyDescriptor" && idpDepth > 0:
+				var key samlKeyDescriptor
+				if err := decoder.DecodeElement(&key, &t); err != nil {
+					return nil, fmt.Errorf("parsing IdP metadata: %w", err)
+				}
+				if key.Use != "" && key.Use != "signing" {
+					continue
+				}
+				for _, c := range key.Certificates {
+					certificate, err := pemCertificate(c)
+					if err != nil {
+						return nil, err
+					}
+					if !slices.Contains(certificates, certificate) {
+						certificates = append(certificates, certificate)
+					}
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local == "IDPSSODescriptor" {
+				idpDepth--
+			}
+		}
+	}
+
+	if len(certificates) == 0 {
+		return nil, errors.New("the IdP metadata has no signing certificates")
+	}
+	return certificates, nil
+}
+
+// pemCertificate encodes the base64 DER certificate of an X509Certificate
+// element as PEM
+func pemCertificate(encoded string) (string, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+	if err != nil {
+		return "", fmt.Errorf("parsing IdP metadata: invalid certificate: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), nil
+}
@@ -0,0 +1,116 @@
+package resources
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCertificate returns a self-signed certificate as DER
+func testCertificate(t *testing.T, cn string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	return der
+}
+
+// testSAMLMetadata returns IdP metadata with a signing certificate, a certificate
+// without a use and an encryption certificate
+func testSAMLMetadata(signing, unspecified, encryption []byte) string {
+	descriptor := func(use string, der []byte) string {
+		if use != "" {
+			use = fmt.Sprintf(` use="%s"`, use)
+		}
+		return fmt.Sprintf(`
+			<md:KeyDescriptor%s>
+				<ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+					%s
+				</ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+			</md:KeyDescriptor>`, use, base64.StdEncoding.EncodeToString(der))
+	}
+	return `<?xml version="1.0"?>
+		<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com">
+			<md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">` +
+		descriptor("signing", signing) + descriptor("", unspecified) + descriptor("encryption", encryption) + `
+				<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"/>
+			</md:IDPSSODescriptor>
+		</md:EntityDescriptor>`
+}
+
+func TestUnitSAMLMetadataCertificates(t *testing.T) {
+	signing, unspecified, encryption := testCertificate(t, "signing"), testCertificate(t, "unspecified"), testCertificate(t, "encryption")
+	metadata := testSAMLMetadata(signing, unspecified, encryption)
+
+	certificates, err := samlMetadataCertificates([]byte(metadata))
+	if err != nil {
+		t.Fatalf("samlMetadataCertificates failed: %v", err)
+	}
+	if len(certificates) != 2 {
+		t.Fatalf("Expected the signing certificates only, got %d", len(certificates))
+	}
+	for i, want := range []string{"signing", "unspecified"} {
+		block, _ := pem.Decode([]byte(certificates[i]))
+		if block == nil || block.Type != "CERTIFICATE" {
+			t.Fatalf("Expected a PEM certificate, got %q", certificates[i])
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || cert.Subject.CommonName != want {
+			t.Errorf("Expected certificate %q, got %v (%v)", want, cert.Subject, err)
+		}
+	}
+
+	// Certificates are read from a file, or downloaded
+	file := filepath.Join(t.TempDir(), "metadata.xml")
+	if err := os.WriteFile(file, []byte(metadata), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, metadata)
+	}))
+	defer server.Close()
+
+	for _, source := range []struct{ url, file string }{{"", file}, {server.URL, ""}} {
+		raw, err := loadSAMLMetadata(context.Background(), source.url, source.file)
+		if err != nil {
+			t.Fatalf("loadSAMLMetadata(%q, %q) failed: %v", source.url, source.file, err)
+		}
+		if got, _ := samlMetadataCertificates(raw); len(got) != 2 || got[0] != certificates[0] {
+			t.Errorf("Unexpected certificates from %q%q: %v", source.url, source.file, got)
+		}
+	}
+
+	if _, err := loadSAMLMetadata(context.Background(), "", ""); err == nil {
+		t.Error("Expected an error without a metadata source")
+	}
+	if _, err := samlMetadataCertificates([]byte(`<EntityDescriptor><SPSSODescriptor/></EntityDescriptor>`)); err == nil ||
+		!strings.Contains(err.Error(), "no signing certificates") {
+		t.Errorf("Expected an error for metadata without certificates, got %v", err)
+	}
+}
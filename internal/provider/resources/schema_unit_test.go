@@ -0,0 +1,127 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnitValidateSchemas(t *testing.T) {
+	schemas := map[string]string{
+		"ignition/database-connection": `{
+			"type": "object",
+			"required": ["driver", "connectURL"],
+			"properties": {
+				"driver": {"type": "string"},
+				"translator": {"type": "string", "enum": ["POSTGRES", "MYSQL"]},
+				"connectURL": {"type": "string"},
+				"username": {"type": "string"},
+				"password": {"type": "object"}
+			}
+		}`,
+		"com.inductiveautomation.opcua/ModbusTcp": `{
+			"type": "object",
+			"properties": {
+				"hostname": {"type": "string"},
+				"port": {"type": "integer"}
+			}
+		}`,
+	}
+
+	var mockDevice *client.ResourceResponse[client.DeviceConfig]
+	mockClient := &client.MockClient{
+		GetResourceTypeSchemaFunc: func(ctx context.Context, m, rt string) (*client.ConfigSchema, error) {
+			raw, ok := schemas[m+"/"+rt]
+			if !ok {
+				return nil, nil
+			}
+			var schema client.ConfigSchema
+			return &schema, json.Unmarshal([]byte(raw), &schema)
+		},
+		EncryptSecretFunc: func(ctx context.Context, plaintext string) (*client.IgnitionSecret, error) {
+			t.Error("Expected no secret to be encrypted while planning")
+			return &client.IgnitionSecret{Type: "Embedded"}, nil
+		},
+		CreateDeviceFunc: func(ctx context.Context, d client.ResourceResponse[client.DeviceConfig]) (*client.ResourceResponse[client.DeviceConfig], error) {
+			d.Signature = "sig-1"
+			mockDevice = &d
+			return mockDevice, nil
+		},
+		GetDeviceFunc: func(ctx context.Context, name string) (*client.ResourceResponse[client.DeviceConfig], error) {
+			return mockDevice, nil
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			Client:          mockClient,
+			ValidateSchemas: true,
+			ResourceFactories: []func() fwresource.Resource{
+				NewDatabaseConnectionResource,
+				NewDeviceResource,
+			},
+		}),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_database_connection" "mes" {
+						name        = "mes"
+						type        = "PostgreSQL"
+						translator  = "POSTGRESQL"
+						connect_url = "jdbc:postgresql://db/mes"
+						password    = "secret"
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`translator: must be one of "POSTGRES", "MYSQL"`),
+			},
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_device" "plc" {
+						name       = "plc"
+						type       = "ModbusTcp"
+						parameters = jsonencode({ hostname = "plc", prot = 502 })
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`prot: unknown key, did you mean "port"\?`),
+			},
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_device" "plc" {
+						name       = "plc"
+						type       = "ModbusTcp"
+						parameters = jsonencode({ hostname = "plc", port = 502 })
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_device.plc", "signature", "sig-1"),
+				),
+			},
+		},
+	})
+}
@@ -6,23 +6,32 @@ import (
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SMTPProfileResource{}
 var _ resource.ResourceWithImportState = &SMTPProfileResource{}
+var _ resource.ResourceWithIdentity = &SMTPProfileResource{}
+var _ resource.ResourceWithModifyPlan = &SMTPProfileResource{}
+var _ base.ResourceWithSecrets = &SMTPProfileResource{}
+var _ list.ListResourceWithConfigure = &SMTPProfileResource{}
 
 func NewSMTPProfileResource() resource.Resource {
 	return &SMTPProfileResource{}
 }
 
+func NewSMTPProfileListResource() list.ListResource {
+	return &SMTPProfileResource{}
+}
+
 // SMTPProfileResource defines the resource implementation.
 type SMTPProfileResource struct {
 	client  client.IgnitionClient
@@ -32,6 +41,7 @@ type SMTPProfileResource struct {
 // SMTPProfileResourceModel describes the resource data model.
 type SMTPProfileResourceModel struct {
 	base.BaseResourceModel
+	base.CollectionResourceModel
 	Hostname        types.String `tfsdk:"hostname"`
 	Port            types.Int64  `tfsdk:"port"`
 	UseSslPort      types.Bool   `tfsdk:"use_ssl_port"`
@@ -42,6 +52,7 @@ type SMTPProfileResourceModel struct {
 
 func (r *SMTPProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_smtp_profile"
+	base.AllowRenames(resp)
 }
 
 func (r *SMTPProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -51,15 +62,12 @@ func (r *SMTPProfileResource) Schema(ctx context.Context, req resource.SchemaReq
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the SMTP profile.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Description: "The description of the SMTP profile.",
@@ -102,6 +110,7 @@ func (r *SMTPProfileResource) Schema(ctx context.Context, req resource.SchemaReq
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"collection": base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Description: "The signature of the resource, used for updates and deletes.",
 				Computed:    true,
@@ -134,6 +143,8 @@ func (r *SMTPProfileResource) Configure(ctx context.Context, req resource.Config
 		GetFunc:      c.GetSMTPProfile,
 		UpdateFunc:   c.UpdateSMTPProfile,
 		DeleteFunc:   c.DeleteSMTPProfile,
+		References:   base.ReferenceValidatorFrom(req.ProviderData),
+		Schemas:      base.SchemaValidatorFrom(req.ProviderData),
 	}
 }
 
@@ -203,11 +214,42 @@ func (r *SMTPProfileResource) Delete(ctx context.Context, req resource.DeleteReq
 	r.generic.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
 
+func (r *SMTPProfileResource) SecretAttributes() []base.Secret {
+	return []base.Secret{
+		{Path: path.Root("password"), Key: "settings.settings.password"},
+	}
+}
+
+func (r *SMTPProfileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.generic.CheckReferences(ctx, req, resp)
+	r.generic.CheckSchema(ctx, req, resp)
+}
+
+func (r *SMTPProfileResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
 func (r *SMTPProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.generic.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &SMTPProfileResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
 	})...)
 }
+
+func (r *SMTPProfileResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists SMTP Profiles configured on the gateway.")
+}
+
+func (r *SMTPProfileResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.generic.List(ctx, req, stream, func(_ *client.ResourceResponse[client.SMTPProfileConfig]) (*SMTPProfileResourceModel, *base.BaseResourceModel) {
+		var data SMTPProfileResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
@@ -7,13 +7,13 @@ import (
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -21,11 +21,18 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &StoreAndForwardResource{}
 var _ resource.ResourceWithImportState = &StoreAndForwardResource{}
+var _ resource.ResourceWithIdentity = &StoreAndForwardResource{}
+var _ resource.ResourceWithModifyPlan = &StoreAndForwardResource{}
+var _ list.ListResourceWithConfigure = &StoreAndForwardResource{}
 
 func NewStoreAndForwardResource() resource.Resource {
 	return &StoreAndForwardResource{}
 }
 
+func NewStoreAndForwardListResource() list.ListResource {
+	return &StoreAndForwardResource{}
+}
+
 // StoreAndForwardResource defines the resource implementation.
 type StoreAndForwardResource struct {
 	client  client.IgnitionClient
@@ -35,6 +42,7 @@ type StoreAndForwardResource struct {
 // StoreAndForwardResourceModel describes the resource data model.
 type StoreAndForwardResourceModel struct {
 	base.BaseResourceModel
+	base.CollectionResourceModel
 	TimeThresholdMs    types.Int64             `tfsdk:"time_threshold_ms"`
 	ForwardRateMs      types.Int64             `tfsdk:"forward_rate_ms"`
 	ForwardingPolicy   types.String            `tfsdk:"forwarding_policy"`
@@ -55,6 +63,7 @@ type MaintenancePolicyModel struct {
 
 func (r *StoreAndForwardResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_store_forward"
+	base.AllowRenames(resp)
 }
 
 func (r *StoreAndForwardResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -84,15 +93,12 @@ func (r *StoreAndForwardResource) Schema(ctx context.Context, req resource.Schem
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the engine.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"description": schema.StringAttribute{
 				Description: "The description of the engine.",
@@ -163,6 +169,7 @@ func (r *StoreAndForwardResource) Schema(ctx context.Context, req resource.Schem
 				Optional:    true,
 				Attributes:  maintenancePolicySchema.Attributes,
 			},
+			"collection": base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Description: "The signature of the resource.",
 				Computed:    true,
@@ -195,6 +202,7 @@ func (r *StoreAndForwardResource) Configure(ctx context.Context, req resource.Co
 		GetFunc:      c.GetStoreAndForward,
 		UpdateFunc:   c.UpdateStoreAndForward,
 		DeleteFunc:   c.DeleteStoreAndForward,
+		Schemas:      base.SchemaValidatorFrom(req.ProviderData),
 	}
 }
 
@@ -282,11 +290,35 @@ func (r *StoreAndForwardResource) Delete(ctx context.Context, req resource.Delet
 	r.generic.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
 
+func (r *StoreAndForwardResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.generic.CheckSchema(ctx, req, resp)
+}
+
+func (r *StoreAndForwardResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
 func (r *StoreAndForwardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.generic.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &StoreAndForwardResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
 	})...)
 }
+
+func (r *StoreAndForwardResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists Store and Forward engines configured on the gateway.")
+}
+
+func (r *StoreAndForwardResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.generic.List(ctx, req, stream, func(_ *client.ResourceResponse[client.StoreAndForwardConfig]) (*StoreAndForwardResourceModel, *base.BaseResourceModel) {
+		var data StoreAndForwardResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
@@ -2,26 +2,45 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TagProviderResource{}
 var _ resource.ResourceWithImportState = &TagProviderResource{}
+var _ resource.ResourceWithIdentity = &TagProviderResource{}
+var _ resource.ResourceWithModifyPlan = &TagProviderResource{}
+var _ resource.ResourceWithValidateConfig = &TagProviderResource{}
+var _ list.ListResourceWithConfigure = &TagProviderResource{}
 
 func NewTagProviderResource() resource.Resource {
 	return &TagProviderResource{}
 }
 
+func NewTagProviderListResource() list.ListResource {
+	return &TagProviderResource{}
+}
+
 // TagProviderResource defines the resource implementation.
 type TagProviderResource struct {
 	generic base.GenericIgnitionResource[client.TagProviderConfig, TagProviderResourceModel]
@@ -30,11 +49,62 @@ type TagProviderResource struct {
 // TagProviderResourceModel describes the resource data model.
 type TagProviderResourceModel struct {
 	base.BaseResourceModel
-	Type types.String `tfsdk:"type"`
+	base.CollectionResourceModel
+	Type     types.String         `tfsdk:"type"`
+	Standard types.Object         `tfsdk:"standard"`
+	Remote   *TagProviderRemote   `tfsdk:"remote"`
+	Settings jsontypes.Normalized `tfsdk:"settings"`
+}
+
+// The provider types with settings of their own attribute; the settings of
+// other types, such as INTERNAL or those added by MQTT modules, are set as JSON
+const (
+	tagProviderStandard = "STANDARD"
+	tagProviderRemote   = "REMOTE"
+)
+
+type TagProviderStandard struct {
+	HistoryEnabled         types.Bool              `tfsdk:"history_enabled"`
+	DefaultHistoryProvider types.String            `tfsdk:"default_history_provider"`
+	AllowBackfill          types.Bool              `tfsdk:"allow_backfill"`
+	TagReferenceStore      types.Bool              `tfsdk:"tag_reference_store"`
+	AlarmSync              types.Bool              `tfsdk:"alarm_sync"`
+	Permissions            []TagProviderPermission `tfsdk:"permissions"`
+}
+
+type TagProviderPermission struct {
+	Role        types.String `tfsdk:"role"`
+	Zone        types.String `tfsdk:"zone"`
+	AccessLevel types.String `tfsdk:"access_level"`
+}
+
+type TagProviderRemote struct {
+	Gateway         types.String `tfsdk:"gateway"`
+	Provider        types.String `tfsdk:"provider"`
+	HistoryMode     types.String `tfsdk:"history_mode"`
+	HistoryProvider types.String `tfsdk:"history_provider"`
+	AlarmMode       types.String `tfsdk:"alarm_mode"`
+	AlarmsEnabled   types.Bool   `tfsdk:"alarms_enabled"`
+}
+
+var tagProviderPermissionAttrTypes = map[string]attr.Type{
+	"role":         types.StringType,
+	"zone":         types.StringType,
+	"access_level": types.StringType,
+}
+
+var tagProviderStandardAttrTypes = map[string]attr.Type{
+	"history_enabled":          types.BoolType,
+	"default_history_provider": types.StringType,
+	"allow_backfill":           types.BoolType,
+	"tag_reference_store":      types.BoolType,
+	"alarm_sync":               types.BoolType,
+	"permissions":              types.ListType{ElemType: types.ObjectType{AttrTypes: tagProviderPermissionAttrTypes}},
 }
 
 func (r *TagProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_tag_provider"
+	base.AllowRenames(resp)
 }
 
 func (r *TagProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -44,19 +114,19 @@ func (r *TagProviderResource) Schema(ctx context.Context, req resource.SchemaReq
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the tag provider.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"type": schema.StringAttribute{
-				Description: "The type of the tag provider (e.g., standard).",
+				Description: "The type of the tag provider: STANDARD, REMOTE, INTERNAL, or a type added by a module, such as an MQTT module. It cannot be changed in place.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"description": schema.StringAttribute{
 				Description: "A description of the tag provider.",
@@ -68,6 +138,120 @@ func (r *TagProviderResource) Schema(ctx context.Context, req resource.SchemaReq
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 			},
+			"standard": schema.SingleNestedAttribute{
+				Description: "The settings of a STANDARD tag provider. When omitted, those on the gateway are kept.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"history_enabled": schema.BoolAttribute{
+						Description: "Whether history is enabled by default on the provider's tags.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+					},
+					"default_history_provider": schema.StringAttribute{
+						Description: "The history provider that tags store their history to by default.",
+						Optional:    true,
+					},
+					"allow_backfill": schema.BoolAttribute{
+						Description: "Whether tag history may be back-filled with values older than those already stored.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+					},
+					"tag_reference_store": schema.BoolAttribute{
+						Description: "Whether the provider tracks references between tags.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+					},
+					"alarm_sync": schema.BoolAttribute{
+						Description: "Whether alarm states, such as acknowledgements, are synchronized with remote providers of this provider's tags.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+					},
+					"permissions": schema.ListNestedAttribute{
+						Description: "The access granted to the provider's tags. Without any, all users have full access.",
+						Optional:    true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"role": schema.StringAttribute{
+									Description: "The role granted access. Omit to grant it to any role.",
+									Optional:    true,
+								},
+								"zone": schema.StringAttribute{
+									Description: "The security zone the access is granted from. Omit to grant it from any zone.",
+									Optional:    true,
+								},
+								"access_level": schema.StringAttribute{
+									Description: "The access granted (READ_ONLY, READ_WRITE, READ_WRITE_EDIT).",
+									Required:    true,
+									Validators: []validator.String{
+										stringvalidator.OneOf("READ_ONLY", "READ_WRITE", "READ_WRITE_EDIT"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"remote": schema.SingleNestedAttribute{
+				Description: "The settings of a REMOTE tag provider, which exposes the tags of a provider on another gateway of the Gateway Network. Required when type is REMOTE.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"gateway": schema.StringAttribute{
+						Description: "The name of the remote gateway.",
+						Required:    true,
+					},
+					"provider": schema.StringAttribute{
+						Description: "The name of the tag provider on the remote gateway.",
+						Required:    true,
+					},
+					"history_mode": schema.StringAttribute{
+						Description: "How tag history is queried (DATABASE, GATEWAY_NETWORK, NONE).",
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("GATEWAY_NETWORK"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("DATABASE", "GATEWAY_NETWORK", "NONE"),
+						},
+					},
+					"history_provider": schema.StringAttribute{
+						Description: "The history provider to query when history_mode is DATABASE.",
+						Optional:    true,
+					},
+					"alarm_mode": schema.StringAttribute{
+						Description: "How alarms are retrieved from the remote gateway (QUERIED, SUBSCRIBED).",
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("SUBSCRIBED"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("QUERIED", "SUBSCRIBED"),
+						},
+					},
+					"alarms_enabled": schema.BoolAttribute{
+						Description: "Whether the alarms of the remote tags are available.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+					},
+				},
+			},
+			"settings": schema.StringAttribute{
+				Description: "The JSON settings of tag providers of other types, such as INTERNAL or those added by MQTT modules. " +
+					"Settings populated with defaults by the gateway do not need to be specified. When omitted, those on the gateway are kept.",
+				Optional:   true,
+				Computed:   true,
+				CustomType: jsontypes.NormalizedType{IgnoreDefaults: true},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"collection": base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Description: "The signature of the resource.",
 				Computed:    true,
@@ -99,23 +283,161 @@ func (r *TagProviderResource) Configure(ctx context.Context, req resource.Config
 		GetFunc:      c.GetTagProvider,
 		UpdateFunc:   c.UpdateTagProvider,
 		DeleteFunc:   c.DeleteTagProvider,
+		References:   base.ReferenceValidatorFrom(req.ProviderData),
+		Schemas:      base.SchemaValidatorFrom(req.ProviderData),
+	}
+}
+
+// ValidateConfig checks that only the settings of the configured type are set
+func (r *TagProviderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var providerType types.String
+	var standard, remote types.Object
+	var settings jsontypes.Normalized
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &providerType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("standard"), &standard)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("remote"), &remote)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("settings"), &settings)...)
+	if resp.Diagnostics.HasError() || providerType.IsUnknown() || providerType.IsNull() {
+		return
+	}
+
+	t := providerType.ValueString()
+	if t != tagProviderStandard && !standard.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("standard"), "Invalid Tag Provider Settings",
+			fmt.Sprintf("standard can only be set when type is %s, got %s.", tagProviderStandard, t))
+	}
+	if t != tagProviderRemote && !remote.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("remote"), "Invalid Tag Provider Settings",
+			fmt.Sprintf("remote can only be set when type is %s, got %s.", tagProviderRemote, t))
+	}
+	if t == tagProviderRemote && remote.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("remote"), "Missing Tag Provider Settings",
+			fmt.Sprintf("remote is required when type is %s.", tagProviderRemote))
+	}
+	if (t == tagProviderStandard || t == tagProviderRemote) && !settings.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("settings"), "Invalid Tag Provider Settings",
+			fmt.Sprintf("settings cannot be set when type is %s; use the %s attribute.", t, strings.ToLower(t)))
 	}
 }
 
 func (r *TagProviderResource) MapPlanToClient(ctx context.Context, model *TagProviderResourceModel) (client.TagProviderConfig, error) {
-	return client.TagProviderConfig{
+	config := client.TagProviderConfig{
 		Profile: client.TagProviderProfile{
 			Type: model.Type.ValueString(),
 		},
 		Description: model.Description.ValueString(),
 		Settings:    make(map[string]any),
-	}, nil
+	}
+
+	var settings any
+	switch model.Type.ValueString() {
+	case tagProviderStandard:
+		// Unknown until the gateway has filled in its defaults
+		if model.Standard.IsNull() || model.Standard.IsUnknown() {
+			return config, nil
+		}
+		var standard TagProviderStandard
+		if diags := model.Standard.As(ctx, &standard, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return config, fmt.Errorf("failed to read the standard settings: %v", diags)
+		}
+		s := client.TagProviderStandardSettings{
+			HistoryEnabled:          standard.HistoryEnabled.ValueBool(),
+			DefaultHistoryProvider:  standard.DefaultHistoryProvider.ValueString(),
+			AllowBackfill:           standard.AllowBackfill.ValueBool(),
+			EnableTagReferenceStore: standard.TagReferenceStore.ValueBool(),
+			AlarmSync:               standard.AlarmSync.ValueBool(),
+		}
+		for _, p := range standard.Permissions {
+			s.Permissions = append(s.Permissions, client.TagProviderPermission{
+				Role:        p.Role.ValueString(),
+				Zone:        p.Zone.ValueString(),
+				AccessLevel: p.AccessLevel.ValueString(),
+			})
+		}
+		settings = s
+	case tagProviderRemote:
+		if model.Remote == nil {
+			return config, fmt.Errorf("remote is required when type is %s", tagProviderRemote)
+		}
+		settings = client.TagProviderRemoteSettings{
+			GatewayName:     model.Remote.Gateway.ValueString(),
+			ProviderName:    model.Remote.Provider.ValueString(),
+			HistoryMode:     model.Remote.HistoryMode.ValueString(),
+			HistoryProvider: model.Remote.HistoryProvider.ValueString(),
+			AlarmMode:       model.Remote.AlarmMode.ValueString(),
+			AlarmsEnabled:   model.Remote.AlarmsEnabled.ValueBool(),
+		}
+	default:
+		if model.Settings.IsNull() || model.Settings.IsUnknown() {
+			return config, nil
+		}
+		if err := json.Unmarshal([]byte(model.Settings.ValueString()), &config.Settings); err != nil {
+			return config, fmt.Errorf("failed to parse settings: %w", err)
+		}
+		return config, nil
+	}
+
+	return config, base.EncodeSettings(config.Settings, settings)
 }
 
 func (r *TagProviderResource) MapClientToState(ctx context.Context, name string, config *client.TagProviderConfig, model *TagProviderResourceModel) error {
 	model.Name = types.StringValue(name)
 	model.Type = types.StringValue(config.Profile.Type)
 	model.Description = base.StringToNullableString(config.Description)
+	model.Standard = types.ObjectNull(tagProviderStandardAttrTypes)
+	model.Remote = nil
+	model.Settings = jsontypes.NewNormalizedNull()
+
+	switch config.Profile.Type {
+	case tagProviderStandard:
+		// Settings the gateway leaves out have their defaults
+		s := client.TagProviderStandardSettings{EnableTagReferenceStore: true}
+		if err := base.DecodeSettings(config.Settings, &s); err != nil {
+			return fmt.Errorf("failed to parse the standard settings: %w", err)
+		}
+		standard := TagProviderStandard{
+			HistoryEnabled:         types.BoolValue(s.HistoryEnabled),
+			DefaultHistoryProvider: base.StringToNullableString(s.DefaultHistoryProvider),
+			AllowBackfill:          types.BoolValue(s.AllowBackfill),
+			TagReferenceStore:      types.BoolValue(s.EnableTagReferenceStore),
+			AlarmSync:              types.BoolValue(s.AlarmSync),
+		}
+		for _, p := range s.Permissions {
+			standard.Permissions = append(standard.Permissions, TagProviderPermission{
+				Role:        base.StringToNullableString(p.Role),
+				Zone:        base.StringToNullableString(p.Zone),
+				AccessLevel: types.StringValue(p.AccessLevel),
+			})
+		}
+		obj, diags := types.ObjectValueFrom(ctx, tagProviderStandardAttrTypes, standard)
+		if diags.HasError() {
+			return fmt.Errorf("failed to set the standard settings: %v", diags)
+		}
+		model.Standard = obj
+	case tagProviderRemote:
+		s := client.TagProviderRemoteSettings{HistoryMode: "GATEWAY_NETWORK", AlarmMode: "SUBSCRIBED", AlarmsEnabled: true}
+		if err := base.DecodeSettings(config.Settings, &s); err != nil {
+			return fmt.Errorf("failed to parse the remote settings: %w", err)
+		}
+		model.Remote = &TagProviderRemote{
+			Gateway:         types.StringValue(s.GatewayName),
+			Provider:        types.StringValue(s.ProviderName),
+			HistoryMode:     types.StringValue(s.HistoryMode),
+			HistoryProvider: base.StringToNullableString(s.HistoryProvider),
+			AlarmMode:       types.StringValue(s.AlarmMode),
+			AlarmsEnabled:   types.BoolValue(s.AlarmsEnabled),
+		}
+	default:
+		settings := config.Settings
+		if settings == nil {
+			settings = make(map[string]any)
+		}
+		b, err := json.Marshal(settings)
+		if err != nil {
+			return err
+		}
+		model.Settings = jsontypes.NewNormalizedValue(string(b))
+	}
 	return nil
 }
 
@@ -139,11 +461,37 @@ func (r *TagProviderResource) Delete(ctx context.Context, req resource.DeleteReq
 	r.generic.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
 
+func (r *TagProviderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.generic.CheckReferences(ctx, req, resp)
+	r.generic.CheckSchema(ctx, req, resp)
+}
+
+func (r *TagProviderResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
 func (r *TagProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.generic.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &TagProviderResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
+		Standard: types.ObjectNull(tagProviderStandardAttrTypes),
 	})...)
 }
+
+func (r *TagProviderResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists Tag Providers configured on the gateway.")
+}
+
+func (r *TagProviderResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.generic.List(ctx, req, stream, func(_ *client.ResourceResponse[client.TagProviderConfig]) (*TagProviderResourceModel, *base.BaseResourceModel) {
+		var data TagProviderResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
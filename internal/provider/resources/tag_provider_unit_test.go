@@ -3,41 +3,55 @@ package resources
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestUnitTagProviderResource(t *testing.T) {
 	currentDescription := "Test Description"
 	currentSignature := "sig-123"
+	currentName := "test-tags"
+	var currentSettings map[string]any
 
 	mockClient := &client.MockClient{
 		CreateTagProviderFunc: func(ctx context.Context, tp client.ResourceResponse[client.TagProviderConfig]) (*client.ResourceResponse[client.TagProviderConfig], error) {
+			currentSettings = tp.Config.Settings
 			tp.Signature = "sig-123"
 			return &tp, nil
 		},
 		GetTagProviderFunc: func(ctx context.Context, name string) (*client.ResourceResponse[client.TagProviderConfig], error) {
-			if name != "test-tags" {
+			if name != currentName {
 				return nil, fmt.Errorf("not found")
 			}
 			return &client.ResourceResponse[client.TagProviderConfig]{
-				Name:      "test-tags",
+				Name:      currentName,
 				Signature: currentSignature,
 				Config: client.TagProviderConfig{
 					Profile: client.TagProviderProfile{
 						Type: "STANDARD",
 					},
 					Description: currentDescription,
+					Settings:    currentSettings,
 				},
 			}, nil
 		},
 		UpdateTagProviderFunc: func(ctx context.Context, tp client.ResourceResponse[client.TagProviderConfig]) (*client.ResourceResponse[client.TagProviderConfig], error) {
 			currentDescription = tp.Config.Description
+			currentSettings = tp.Config.Settings
 			currentSignature = "sig-456"
 			tp.Signature = currentSignature
 			return &tp, nil
@@ -45,6 +59,14 @@ func TestUnitTagProviderResource(t *testing.T) {
 		DeleteTagProviderFunc: func(ctx context.Context, name, signature string) error {
 			return nil
 		},
+		RenameResourceWithModuleFunc: func(ctx context.Context, m, rt, name, newName, signature string) error {
+			if name != currentName || signature != currentSignature {
+				return fmt.Errorf("unexpected rename of %s with signature %s", name, signature)
+			}
+			currentName = newName
+			currentSignature = "sig-789"
+			return nil
+		},
 	}
 
 	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
@@ -74,6 +96,10 @@ func TestUnitTagProviderResource(t *testing.T) {
 					resource.TestCheckResourceAttr("ignition_tag_provider.test", "type", "STANDARD"),
 					resource.TestCheckResourceAttr("ignition_tag_provider.test", "description", "Test Description"),
 					resource.TestCheckResourceAttr("ignition_tag_provider.test", "signature", "sig-123"),
+					// The gateway's defaults are read back
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "standard.tag_reference_store", "true"),
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "standard.allow_backfill", "false"),
+					resource.TestCheckNoResourceAttr("ignition_tag_provider.test", "settings"),
 				),
 			},
 			// Update
@@ -92,8 +118,238 @@ func TestUnitTagProviderResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("ignition_tag_provider.test", "description", "Updated Description"),
 					resource.TestCheckResourceAttr("ignition_tag_provider.test", "signature", "sig-456"),
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "standard.tag_reference_store", "true"),
+					func(s *terraform.State) error {
+						// Omitted settings are sent back as they were, not wiped
+						if currentSettings["enableTagReferenceStore"] != true {
+							return fmt.Errorf("expected the gateway's settings to be kept, got %v", currentSettings)
+						}
+						return nil
+					},
+				),
+			},
+			// Standard settings
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_tag_provider" "test" {
+						name        = "test-tags"
+						type        = "STANDARD"
+						description = "Updated Description"
+						standard = {
+							history_enabled          = true
+							default_history_provider = "Historian"
+							allow_backfill           = true
+							alarm_sync               = true
+							permissions = [
+								{ role = "Operator", access_level = "READ_WRITE" },
+								{ access_level = "READ_ONLY" },
+							]
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "standard.history_enabled", "true"),
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "standard.default_history_provider", "Historian"),
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "standard.allow_backfill", "true"),
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "standard.tag_reference_store", "true"),
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "standard.alarm_sync", "true"),
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "standard.permissions.#", "2"),
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "standard.permissions.0.role", "Operator"),
+					resource.TestCheckNoResourceAttr("ignition_tag_provider.test", "standard.permissions.1.role"),
+					func(s *terraform.State) error {
+						if currentSettings["allowBackfill"] != true || currentSettings["defaultHistoryProvider"] != "Historian" {
+							return fmt.Errorf("unexpected settings sent: %v", currentSettings)
+						}
+						return nil
+					},
+				),
+			},
+			// Settings of another type are rejected
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_tag_provider" "test" {
+						name        = "test-tags"
+						type        = "STANDARD"
+						description = "Updated Description"
+						remote = {
+							gateway  = "site-a"
+							provider = "default"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile("remote can only be set when type is REMOTE"),
+			},
+			// Rename in place
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_tag_provider" "test" {
+						name        = "renamed-tags"
+						type        = "STANDARD"
+						description = "Updated Description"
+					}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ignition_tag_provider.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "name", "renamed-tags"),
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "id", "renamed-tags"),
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "signature", "sig-456"),
+					resource.TestCheckResourceAttr("ignition_tag_provider.test", "standard.allow_backfill", "true"),
 				),
 			},
 		},
 	})
 }
+
+func TestUnitTagProviderSettings(t *testing.T) {
+	ctx := context.Background()
+	r := &TagProviderResource{}
+
+	tests := []struct {
+		name     string
+		config   client.TagProviderConfig
+		check    func(*TagProviderResourceModel) error
+		settings map[string]any
+	}{
+		{
+			name: "standard",
+			config: client.TagProviderConfig{
+				Profile: client.TagProviderProfile{Type: "STANDARD"},
+				Settings: map[string]any{
+					"allowBackfill": true,
+					"permissions":   []any{map[string]any{"role": "Operator", "accessLevel": "READ_WRITE"}},
+				},
+			},
+			check: func(m *TagProviderResourceModel) error {
+				var standard TagProviderStandard
+				if diags := m.Standard.As(ctx, &standard, basetypes.ObjectAsOptions{}); diags.HasError() {
+					return fmt.Errorf("unexpected standard settings: %v", diags)
+				}
+				if !standard.AllowBackfill.ValueBool() || !standard.TagReferenceStore.ValueBool() || len(standard.Permissions) != 1 || !standard.Permissions[0].Zone.IsNull() {
+					return fmt.Errorf("unexpected standard settings: %+v", standard)
+				}
+				return nil
+			},
+			settings: map[string]any{
+				"historyEnabled":          false,
+				"allowBackfill":           true,
+				"enableTagReferenceStore": true,
+				"alarmSync":               false,
+				"permissions":             []any{map[string]any{"role": "Operator", "accessLevel": "READ_WRITE"}},
+			},
+		},
+		{
+			name: "remote",
+			config: client.TagProviderConfig{
+				Profile:  client.TagProviderProfile{Type: "REMOTE"},
+				Settings: map[string]any{"gatewayName": "site-a", "providerName": "default"},
+			},
+			check: func(m *TagProviderResourceModel) error {
+				if m.Remote == nil || m.Remote.Gateway.ValueString() != "site-a" || m.Remote.HistoryMode.ValueString() != "GATEWAY_NETWORK" || !m.Remote.AlarmsEnabled.ValueBool() {
+					return fmt.Errorf("unexpected remote settings: %+v", m.Remote)
+				}
+				if !m.Standard.IsNull() || !m.Settings.IsNull() {
+					return fmt.Errorf("expected only the remote settings to be set")
+				}
+				return nil
+			},
+			settings: map[string]any{
+				"gatewayName":   "site-a",
+				"providerName":  "default",
+				"historyMode":   "GATEWAY_NETWORK",
+				"alarmMode":     "SUBSCRIBED",
+				"alarmsEnabled": true,
+			},
+		},
+		{
+			name: "mqtt",
+			config: client.TagProviderConfig{
+				Profile:  client.TagProviderProfile{Type: "MQTT_ENGINE"},
+				Settings: map[string]any{"primaryHost": "scada", "qos": float64(1)},
+			},
+			check: func(m *TagProviderResourceModel) error {
+				if m.Settings.ValueString() != `{"primaryHost":"scada","qos":1}` {
+					return fmt.Errorf("unexpected settings: %s", m.Settings.ValueString())
+				}
+				if !m.Standard.IsNull() || m.Remote != nil {
+					return fmt.Errorf("expected only the JSON settings to be set")
+				}
+				return nil
+			},
+			settings: map[string]any{"primaryHost": "scada", "qos": float64(1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var model TagProviderResourceModel
+			if err := r.MapClientToState(ctx, "tags", &tt.config, &model); err != nil {
+				t.Fatalf("MapClientToState failed: %v", err)
+			}
+			if err := tt.check(&model); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := r.MapPlanToClient(ctx, &model)
+			if err != nil {
+				t.Fatalf("MapPlanToClient failed: %v", err)
+			}
+			if !reflect.DeepEqual(config.Settings, tt.settings) {
+				t.Errorf("Expected the settings %v to round-trip, got %v", tt.settings, config.Settings)
+			}
+		})
+	}
+}
+
+func TestUnitTagProviderRenameThenFailedUpdate(t *testing.T) {
+	ctx := context.Background()
+	r := &TagProviderResource{}
+	r.Configure(ctx, fwresource.ConfigureRequest{ProviderData: &client.MockClient{
+		RenameResourceWithModuleFunc: func(ctx context.Context, m, rt, name, newName, signature string) error {
+			return nil
+		},
+		GetTagProviderFunc: func(ctx context.Context, name string) (*client.ResourceResponse[client.TagProviderConfig], error) {
+			return &client.ResourceResponse[client.TagProviderConfig]{Name: name, Signature: "sig-renamed"}, nil
+		},
+		UpdateTagProviderFunc: func(ctx context.Context, tp client.ResourceResponse[client.TagProviderConfig]) (*client.ResourceResponse[client.TagProviderConfig], error) {
+			return nil, fmt.Errorf("gateway unavailable")
+		},
+	}}, &fwresource.ConfigureResponse{})
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	for attr, value := range map[string]any{"id": "tags", "name": "tags", "type": "STANDARD", "signature": "sig-old", "enabled": true} {
+		state.SetAttribute(ctx, path.Root(attr), value)
+	}
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}
+	plan.SetAttribute(ctx, path.Root("name"), "renamed")
+	plan.SetAttribute(ctx, path.Root("id"), "renamed")
+
+	resp := fwresource.UpdateResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw.Copy()}}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Expected the failed update to be reported")
+	}
+
+	// The rename went through, so state must follow the gateway
+	var data TagProviderResourceModel
+	resp.State.Get(ctx, &data)
+	if data.Name.ValueString() != "renamed" || data.Id.ValueString() != "renamed" || data.Signature.ValueString() != "sig-renamed" {
+		t.Errorf("Expected the renamed name and signature in state, got %s, %s, %s", data.Name, data.Id, data.Signature)
+	}
+}
@@ -3,25 +3,42 @@ package resources
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &UserSourceResource{}
 var _ resource.ResourceWithImportState = &UserSourceResource{}
+var _ resource.ResourceWithIdentity = &UserSourceResource{}
+var _ resource.ResourceWithModifyPlan = &UserSourceResource{}
+var _ resource.ResourceWithValidateConfig = &UserSourceResource{}
+var _ base.ResourceWithReferences = &UserSourceResource{}
+var _ base.ResourceWithSecrets = &UserSourceResource{}
+var _ list.ListResourceWithConfigure = &UserSourceResource{}
 
 func NewUserSourceResource() resource.Resource {
 	return &UserSourceResource{}
 }
 
+func NewUserSourceListResource() list.ListResource {
+	return &UserSourceResource{}
+}
+
 // UserSourceResource defines the resource implementation.
 type UserSourceResource struct {
 	base.GenericIgnitionResource[client.UserSourceConfig, UserSourceResourceModel]
@@ -30,14 +47,62 @@ type UserSourceResource struct {
 // UserSourceResourceModel describes the resource data model.
 type UserSourceResourceModel struct {
 	base.BaseResourceModel
+	base.CollectionResourceModel
 	Type               types.String `tfsdk:"type"`
 	FailoverProfile    types.String `tfsdk:"failover_profile"`
 	FailoverMode       types.String `tfsdk:"failover_mode"`
 	ScheduleRestricted types.Bool   `tfsdk:"schedule_restricted"`
+	// Type-specific settings
+	ActiveDirectory *UserSourceActiveDirectory `tfsdk:"active_directory"`
+	Database        *UserSourceDatabase        `tfsdk:"database"`
+}
+
+type UserSourceActiveDirectory struct {
+	Domain         types.String `tfsdk:"domain"`
+	PrimaryHost    types.String `tfsdk:"primary_host"`
+	PrimaryPort    types.Int64  `tfsdk:"primary_port"`
+	SecondaryHost  types.String `tfsdk:"secondary_host"`
+	SecondaryPort  types.Int64  `tfsdk:"secondary_port"`
+	UseSSL         types.Bool   `tfsdk:"use_ssl"`
+	BindUsername   types.String `tfsdk:"bind_username"`
+	BindPassword   types.String `tfsdk:"bind_password"`
+	UserSearchBase types.String `tfsdk:"user_search_base"`
+	RoleSearchBase types.String `tfsdk:"role_search_base"`
+	RoleFilter     types.String `tfsdk:"role_filter"`
+	GroupFilter    types.String `tfsdk:"group_filter"`
+}
+
+type UserSourceDatabase struct {
+	Datasource          types.String `tfsdk:"datasource"`
+	SchemaMode          types.String `tfsdk:"schema_mode"`
+	TablePrefix         types.String `tfsdk:"table_prefix"`
+	AuthenticationQuery types.String `tfsdk:"authentication_query"`
+	UserListQuery       types.String `tfsdk:"user_list_query"`
+	RoleListQuery       types.String `tfsdk:"role_list_query"`
+	UserRolesQuery      types.String `tfsdk:"user_roles_query"`
+}
+
+// userSourceSettings lists the settings attributes each user source type takes.
+// INTERNAL user sources have none, and the hybrid types combine Active
+// Directory authentication with internal or database roles.
+var userSourceSettings = map[string][]string{
+	"INTERNAL":     nil,
+	"ADEASY":       {"active_directory"},
+	"ADHYBRID":     {"active_directory"},
+	"AD_DB_HYBRID": {"active_directory", "database"},
+	"DATASOURCE":   {"database"},
+}
+
+// userSourceManualQueries are the database settings used with the MANUAL schema mode
+var userSourceManualQueries = []string{"authentication_query", "user_list_query", "role_list_query", "user_roles_query"}
+
+func userSourceTakes(userSourceType, attribute string) bool {
+	return slices.Contains(userSourceSettings[userSourceType], attribute)
 }
 
 func (r *UserSourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_user_source"
+	base.AllowRenames(resp)
 }
 
 func (r *UserSourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -47,15 +112,12 @@ func (r *UserSourceResource) Schema(ctx context.Context, req resource.SchemaRequ
 			"id": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					base.UseNameForID(),
 				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the user source.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"type": schema.StringAttribute{
 				Description: "The type of the user source (e.g., INTERNAL, ADEASY, ADHYBRID, AD_DB_HYBRID, DATASOURCE).",
@@ -87,6 +149,107 @@ func (r *UserSourceResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"active_directory": schema.SingleNestedAttribute{
+				Description: "The Active Directory settings. Required when type is ADEASY, ADHYBRID or AD_DB_HYBRID.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"domain": schema.StringAttribute{
+						Description: "The Active Directory domain (e.g., corp.example.com).",
+						Required:    true,
+					},
+					"primary_host": schema.StringAttribute{
+						Description: "The host of the primary domain controller.",
+						Required:    true,
+					},
+					"primary_port": schema.Int64Attribute{
+						Description: "The LDAP port of the primary domain controller.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(389),
+					},
+					"secondary_host": schema.StringAttribute{
+						Description: "The host of the secondary domain controller, used when the primary is unreachable.",
+						Optional:    true,
+					},
+					"secondary_port": schema.Int64Attribute{
+						Description: "The LDAP port of the secondary domain controller.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(389),
+					},
+					"use_ssl": schema.BoolAttribute{
+						Description: "Whether to connect to the domain controllers over LDAPS.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+					},
+					"bind_username": schema.StringAttribute{
+						Description: "The user the gateway binds as to query users and roles.",
+						Optional:    true,
+					},
+					"bind_password": schema.StringAttribute{
+						Description: "The password of the bind user. It is encrypted by the gateway before being stored.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"user_search_base": schema.StringAttribute{
+						Description: "The base DN that users are searched under.",
+						Optional:    true,
+					},
+					"role_search_base": schema.StringAttribute{
+						Description: "The base DN that roles are searched under.",
+						Optional:    true,
+					},
+					"role_filter": schema.StringAttribute{
+						Description: "The LDAP filter that selects the groups treated as roles.",
+						Optional:    true,
+					},
+					"group_filter": schema.StringAttribute{
+						Description: "The LDAP filter that selects the groups a user's roles are read from.",
+						Optional:    true,
+					},
+				},
+			},
+			"database": schema.SingleNestedAttribute{
+				Description: "The database settings. Required when type is DATASOURCE or AD_DB_HYBRID.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"datasource": schema.StringAttribute{
+						Description: "The database connection that users and roles are kept in.",
+						Required:    true,
+					},
+					"schema_mode": schema.StringAttribute{
+						Description: "AUTOMATIC to let the gateway create and manage the tables, or MANUAL to query existing ones.",
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("AUTOMATIC"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("AUTOMATIC", "MANUAL"),
+						},
+					},
+					"table_prefix": schema.StringAttribute{
+						Description: "The prefix of the tables the gateway creates, with the AUTOMATIC schema mode.",
+						Optional:    true,
+					},
+					"authentication_query": schema.StringAttribute{
+						Description: "The query that authenticates a user, with the MANUAL schema mode.",
+						Optional:    true,
+					},
+					"user_list_query": schema.StringAttribute{
+						Description: "The query that lists users, with the MANUAL schema mode.",
+						Optional:    true,
+					},
+					"role_list_query": schema.StringAttribute{
+						Description: "The query that lists roles, with the MANUAL schema mode.",
+						Optional:    true,
+					},
+					"user_roles_query": schema.StringAttribute{
+						Description: "The query that lists the roles of a user, with the MANUAL schema mode.",
+						Optional:    true,
+					},
+				},
+			},
+			"collection": base.CollectionAttribute(),
 			"signature": schema.StringAttribute{
 				Description: "The signature of the resource, used for updates and deletes.",
 				Computed:    true,
@@ -117,6 +280,8 @@ func (r *UserSourceResource) Configure(ctx context.Context, req resource.Configu
 	r.GetFunc = client.GetUserSource
 	r.UpdateFunc = client.UpdateUserSource
 	r.DeleteFunc = client.DeleteUserSource
+	r.References = base.ReferenceValidatorFrom(req.ProviderData)
+	r.Schemas = base.SchemaValidatorFrom(req.ProviderData)
 }
 
 func (r *UserSourceResource) MapPlanToClient(ctx context.Context, model *UserSourceResourceModel) (client.UserSourceConfig, error) {
@@ -134,9 +299,53 @@ func (r *UserSourceResource) MapPlanToClient(ctx context.Context, model *UserSou
 		profile.ScheduleRestricted = model.ScheduleRestricted.ValueBool()
 	}
 
-	return client.UserSourceConfig{
-		Profile: profile,
-	}, nil
+	config := client.UserSourceConfig{
+		Profile:  profile,
+		Settings: make(map[string]any),
+	}
+
+	if ad := model.ActiveDirectory; ad != nil {
+		settings := client.UserSourceADSettings{
+			Domain:          ad.Domain.ValueString(),
+			PrimaryHost:     ad.PrimaryHost.ValueString(),
+			PrimaryPort:     int(ad.PrimaryPort.ValueInt64()),
+			SecondaryHost:   ad.SecondaryHost.ValueString(),
+			SecondaryPort:   int(ad.SecondaryPort.ValueInt64()),
+			SSLEnabled:      ad.UseSSL.ValueBool(),
+			GatewayUsername: ad.BindUsername.ValueString(),
+			UserSearchBase:  ad.UserSearchBase.ValueString(),
+			RoleSearchBase:  ad.RoleSearchBase.ValueString(),
+			RoleFilter:      ad.RoleFilter.ValueString(),
+			GroupFilter:     ad.GroupFilter.ValueString(),
+		}
+		if !ad.BindPassword.IsNull() {
+			encrypted, err := r.Client.EncryptSecret(ctx, ad.BindPassword.ValueString())
+			if err != nil {
+				return client.UserSourceConfig{}, err
+			}
+			settings.GatewayPassword = encrypted
+		}
+		if err := base.EncodeSettings(config.Settings, settings); err != nil {
+			return client.UserSourceConfig{}, err
+		}
+	}
+
+	if db := model.Database; db != nil {
+		settings := client.UserSourceDatabaseSettings{
+			Datasource:          db.Datasource.ValueString(),
+			SchemaMode:          db.SchemaMode.ValueString(),
+			TablePrefix:         db.TablePrefix.ValueString(),
+			AuthenticationQuery: db.AuthenticationQuery.ValueString(),
+			UserListQuery:       db.UserListQuery.ValueString(),
+			RoleListQuery:       db.RoleListQuery.ValueString(),
+			UserRolesQuery:      db.UserRolesQuery.ValueString(),
+		}
+		if err := base.EncodeSettings(config.Settings, settings); err != nil {
+			return client.UserSourceConfig{}, err
+		}
+	}
+
+	return config, nil
 }
 
 func (r *UserSourceResource) MapClientToState(ctx context.Context, name string, config *client.UserSourceConfig, model *UserSourceResourceModel) error {
@@ -157,9 +366,111 @@ func (r *UserSourceResource) MapClientToState(ctx context.Context, name string,
 	}
 
 	model.ScheduleRestricted = types.BoolValue(config.Profile.ScheduleRestricted)
+
+	if userSourceTakes(config.Profile.Type, "active_directory") {
+		// Settings the gateway leaves out have their defaults
+		settings := client.UserSourceADSettings{PrimaryPort: 389, SecondaryPort: 389}
+		if err := base.DecodeSettings(config.Settings, &settings); err != nil {
+			return fmt.Errorf("failed to parse the Active Directory settings: %w", err)
+		}
+		// The gateway does not return the bind password, so the one in state is kept
+		bindPassword := types.StringNull()
+		if model.ActiveDirectory != nil {
+			bindPassword = model.ActiveDirectory.BindPassword
+		}
+		model.ActiveDirectory = &UserSourceActiveDirectory{
+			Domain:         types.StringValue(settings.Domain),
+			PrimaryHost:    types.StringValue(settings.PrimaryHost),
+			PrimaryPort:    types.Int64Value(int64(settings.PrimaryPort)),
+			SecondaryHost:  base.StringToNullableString(settings.SecondaryHost),
+			SecondaryPort:  types.Int64Value(int64(settings.SecondaryPort)),
+			UseSSL:         types.BoolValue(settings.SSLEnabled),
+			BindUsername:   base.StringToNullableString(settings.GatewayUsername),
+			BindPassword:   bindPassword,
+			UserSearchBase: base.StringToNullableString(settings.UserSearchBase),
+			RoleSearchBase: base.StringToNullableString(settings.RoleSearchBase),
+			RoleFilter:     base.StringToNullableString(settings.RoleFilter),
+			GroupFilter:    base.StringToNullableString(settings.GroupFilter),
+		}
+	} else {
+		model.ActiveDirectory = nil
+	}
+
+	if userSourceTakes(config.Profile.Type, "database") {
+		settings := client.UserSourceDatabaseSettings{SchemaMode: "AUTOMATIC"}
+		if err := base.DecodeSettings(config.Settings, &settings); err != nil {
+			return fmt.Errorf("failed to parse the database settings: %w", err)
+		}
+		model.Database = &UserSourceDatabase{
+			Datasource:          types.StringValue(settings.Datasource),
+			SchemaMode:          types.StringValue(settings.SchemaMode),
+			TablePrefix:         base.StringToNullableString(settings.TablePrefix),
+			AuthenticationQuery: base.StringToNullableString(settings.AuthenticationQuery),
+			UserListQuery:       base.StringToNullableString(settings.UserListQuery),
+			RoleListQuery:       base.StringToNullableString(settings.RoleListQuery),
+			UserRolesQuery:      base.StringToNullableString(settings.UserRolesQuery),
+		}
+	} else {
+		model.Database = nil
+	}
+
 	return nil
 }
 
+// ValidateConfig checks that the settings attributes set are those of the
+// configured type, and that the database settings suit the schema mode
+func (r *UserSourceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var userSourceType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &userSourceType)...)
+	if resp.Diagnostics.HasError() || userSourceType.IsUnknown() || userSourceType.IsNull() {
+		return
+	}
+	t := userSourceType.ValueString()
+
+	for _, attribute := range []string{"active_directory", "database"} {
+		var settings types.Object
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &settings)...)
+		if settings.IsUnknown() {
+			continue
+		}
+
+		var takenBy []string
+		for userSourceType := range userSourceSettings {
+			if userSourceTakes(userSourceType, attribute) {
+				takenBy = append(takenBy, userSourceType)
+			}
+		}
+		slices.Sort(takenBy)
+
+		if userSourceTakes(t, attribute) && settings.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Missing User Source Settings",
+				fmt.Sprintf("%s is required when type is %s.", attribute, t))
+		} else if !userSourceTakes(t, attribute) && !settings.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid User Source Settings",
+				fmt.Sprintf("%s can only be set when type is one of %s, got %s.", attribute, strings.Join(takenBy, ", "), t))
+		}
+	}
+
+	var schemaMode types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("database").AtName("schema_mode"), &schemaMode)...)
+	if schemaMode.IsUnknown() {
+		return
+	}
+	manual := schemaMode.ValueString() == "MANUAL"
+	for _, attribute := range userSourceManualQueries {
+		var query types.String
+		p := path.Root("database").AtName(attribute)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &query)...)
+		if manual && query.IsNull() {
+			resp.Diagnostics.AddAttributeError(p, "Missing Database Query",
+				fmt.Sprintf("%s is required when schema_mode is MANUAL.", attribute))
+		} else if !manual && !query.IsNull() && !query.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(p, "Invalid Database Query",
+				fmt.Sprintf("%s is only used when schema_mode is MANUAL.", attribute))
+		}
+	}
+}
+
 func (r *UserSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data UserSourceResourceModel
 	r.GenericIgnitionResource.Create(ctx, req, resp, &data, &data.BaseResourceModel)
@@ -180,11 +491,48 @@ func (r *UserSourceResource) Delete(ctx context.Context, req resource.DeleteRequ
 	r.GenericIgnitionResource.Delete(ctx, req, resp, &data, &data.BaseResourceModel)
 }
 
+func (r *UserSourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.CheckReferences(ctx, req, resp, r.ReferenceAttributes()...)
+	r.CheckSchema(ctx, req, resp)
+}
+
+func (r *UserSourceResource) ReferenceAttributes() []base.Reference {
+	return []base.Reference{
+		{Path: path.Root("database").AtName("datasource"), Kind: "database connection", Module: "ignition", ResourceType: "database-connection"},
+	}
+}
+
+func (r *UserSourceResource) SecretAttributes() []base.Secret {
+	return []base.Secret{
+		{Path: path.Root("active_directory").AtName("bind_password"), Key: "settings.gatewayPassword"},
+	}
+}
+
+func (r *UserSourceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = base.ResourceIdentitySchema()
+}
+
 func (r *UserSourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, ok := r.GenericIgnitionResource.ImportName(ctx, req, resp)
+	if !ok {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &UserSourceResourceModel{
 		BaseResourceModel: base.BaseResourceModel{
-			Id:   types.StringValue(req.ID),
-			Name: types.StringValue(req.ID),
+			Id:   types.StringValue(name),
+			Name: types.StringValue(name),
 		},
 	})...)
 }
+
+func (r *UserSourceResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = base.ListResourceConfigSchema("Lists User Sources configured on the gateway.")
+}
+
+func (r *UserSourceResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	r.GenericIgnitionResource.List(ctx, req, stream, func(_ *client.ResourceResponse[client.UserSourceConfig]) (*UserSourceResourceModel, *base.BaseResourceModel) {
+		var data UserSourceResourceModel
+		return &data, &data.BaseResourceModel
+	})
+}
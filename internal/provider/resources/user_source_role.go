@@ -0,0 +1,242 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserSourceRoleResource{}
+var _ resource.ResourceWithImportState = &UserSourceRoleResource{}
+var _ resource.ResourceWithIdentity = &UserSourceRoleResource{}
+var _ resource.ResourceWithModifyPlan = &UserSourceRoleResource{}
+var _ base.ResourceWithReferences = &UserSourceRoleResource{}
+
+func NewUserSourceRoleResource() resource.Resource {
+	return &UserSourceRoleResource{}
+}
+
+// UserSourceRoleResource manages a role of an internal user source through the
+// gateway's user management endpoints. Roles have nothing to update, so every
+// change replaces them.
+type UserSourceRoleResource struct {
+	client     client.IgnitionClient
+	references *base.ReferenceValidator
+}
+
+// UserSourceRoleResourceModel describes the resource data model.
+type UserSourceRoleResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	UserSource types.String `tfsdk:"user_source"`
+	Name       types.String `tfsdk:"name"`
+}
+
+// UserSourceRoleIdentityModel describes the identity of a role: its user source
+// and name.
+type UserSourceRoleIdentityModel struct {
+	UserSource types.String `tfsdk:"user_source"`
+	Name       types.String `tfsdk:"name"`
+}
+
+func (r *UserSourceRoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_source_role"
+}
+
+func (r *UserSourceRoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a role of an internal user source in Ignition.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The user source and role name, separated by a slash.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_source": schema.StringAttribute{
+				Description: "The name of the internal user source the role belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the role.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *UserSourceRoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClient, ok := req.ProviderData.(client.IgnitionClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.IgnitionClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = apiClient
+	r.references = base.ReferenceValidatorFrom(req.ProviderData)
+}
+
+func (r *UserSourceRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserSourceRoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.client.CreateUserSourceRole(ctx, data.UserSource.ValueString(), client.UserSourceRole{Name: data.Name.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating user source role", err.Error())
+		return
+	}
+
+	data.Name = types.StringValue(role.Name)
+	data.Id = types.StringValue(data.UserSource.ValueString() + "/" + role.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(setUserSourceRoleIdentity(ctx, resp.Identity, &data)...)
+}
+
+func (r *UserSourceRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserSourceRoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.client.GetUserSourceRole(ctx, data.UserSource.ValueString(), data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading user source role", err.Error())
+		return
+	}
+
+	data.Name = types.StringValue(role.Name)
+	data.Id = types.StringValue(data.UserSource.ValueString() + "/" + role.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(setUserSourceRoleIdentity(ctx, resp.Identity, &data)...)
+}
+
+// Update is never called, as every attribute requires replacement
+func (r *UserSourceRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data UserSourceRoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(setUserSourceRoleIdentity(ctx, resp.Identity, &data)...)
+}
+
+func (r *UserSourceRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserSourceRoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteUserSourceRole(ctx, data.UserSource.ValueString(), data.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error deleting user source role", err.Error())
+	}
+}
+
+func (r *UserSourceRoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.references.Check(ctx, req, resp, r.ReferenceAttributes()...)
+}
+
+func (r *UserSourceRoleResource) ReferenceAttributes() []base.Reference {
+	return []base.Reference{
+		{Path: path.Root("user_source"), Kind: "user source", Module: "ignition", ResourceType: "user-source"},
+	}
+}
+
+func (r *UserSourceRoleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = userSourceIdentitySchema("name", "The name of the role.")
+}
+
+// setUserSourceRoleIdentity records the identity of a role. It is a no-op when
+// the caller does not support identity.
+func setUserSourceRoleIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, data *UserSourceRoleResourceModel) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, UserSourceRoleIdentityModel{UserSource: data.UserSource, Name: data.Name})
+}
+
+// ImportState imports a role by an import ID of the form user_source/name, or
+// by its identity
+func (r *UserSourceRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var userSource, name string
+	if req.ID == "" && req.Identity != nil {
+		var identity UserSourceRoleIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		userSource, name = identity.UserSource.ValueString(), identity.Name.ValueString()
+	} else {
+		var ok bool
+		if userSource, name, ok = parseUserSourceImportID(req.ID); !ok {
+			resp.Diagnostics.AddError(
+				"Unexpected Import Identifier",
+				fmt.Sprintf("Expected an import ID of the form user_source/name, got: %q.", req.ID),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_source"), userSource)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), userSource+"/"+name)...)
+}
+
+// userSourceIdentitySchema returns the identity schema of a user or role: its
+// user source and the attribute that names it within that source
+func userSourceIdentitySchema(nameAttribute, description string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"user_source": identityschema.StringAttribute{
+				Description:       "The name of the internal user source.",
+				RequiredForImport: true,
+			},
+			nameAttribute: identityschema.StringAttribute{
+				Description:       description,
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// parseUserSourceImportID splits an import ID of the form user_source/name.
+// Only the first slash separates, so user and role names may contain slashes.
+func parseUserSourceImportID(id string) (string, string, bool) {
+	userSource, name, ok := strings.Cut(id, "/")
+	if !ok || userSource == "" || name == "" {
+		return "", "", false
+	}
+	return userSource, name, true
+}
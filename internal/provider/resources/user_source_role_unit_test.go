@@ -0,0 +1,146 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestUnitUserSourceRoleResource(t *testing.T) {
+	roles := map[string]bool{}
+
+	mockClient := &client.MockClient{
+		CreateUserSourceRoleFunc: func(ctx context.Context, userSource string, role client.UserSourceRole) (*client.UserSourceRole, error) {
+			roles[userSource+"/"+role.Name] = true
+			return &role, nil
+		},
+		GetUserSourceRoleFunc: func(ctx context.Context, userSource, name string) (*client.UserSourceRole, error) {
+			if !roles[userSource+"/"+name] {
+				return nil, fmt.Errorf("role not found: %s", name)
+			}
+			return &client.UserSourceRole{Name: name}, nil
+		},
+		DeleteUserSourceRoleFunc: func(ctx context.Context, userSource, name string) error {
+			delete(roles, userSource+"/"+name)
+			return nil
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			ResourceFactory: NewUserSourceRoleResource,
+			Client:          mockClient,
+		}),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_user_source_role" "test" {
+						user_source = "default"
+						name        = "Operator"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_user_source_role.test", "id", "default/Operator"),
+					resource.TestCheckResourceAttr("ignition_user_source_role.test", "name", "Operator"),
+				),
+			},
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_user_source_role" "test" {
+						user_source = "default"
+						name        = "Supervisor"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_user_source_role.test", "id", "default/Supervisor"),
+					func(*terraform.State) error {
+						if roles["default/Operator"] {
+							return fmt.Errorf("expected the renamed role to be replaced")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "ignition_user_source_role.test",
+				ImportState:       true,
+				ImportStateId:     "default/Supervisor",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitParseUserSourceImportID(t *testing.T) {
+	tests := []struct {
+		id               string
+		userSource, name string
+		ok               bool
+	}{
+		{"default/Operator", "default", "Operator", true},
+		{"default/field/tech", "default", "field/tech", true},
+		{"default", "", "", false},
+		{"/Operator", "", "", false},
+		{"default/", "", "", false},
+	}
+	for _, tt := range tests {
+		userSource, name, ok := parseUserSourceImportID(tt.id)
+		if userSource != tt.userSource || name != tt.name || ok != tt.ok {
+			t.Errorf("parseUserSourceImportID(%q) = %q, %q, %v; want %q, %q, %v", tt.id, userSource, name, ok, tt.userSource, tt.name, tt.ok)
+		}
+	}
+}
+
+func TestUnitUserSourceRoleIdentity(t *testing.T) {
+	mockClient := &client.MockClient{
+		CreateUserSourceRoleFunc: func(ctx context.Context, userSource string, role client.UserSourceRole) (*client.UserSourceRole, error) {
+			return &role, nil
+		},
+	}
+	p := &base.TestProvider{ResourceFactory: NewUserSourceRoleResource, Client: mockClient}
+
+	_, identity := applyResource(t, p, "ignition_user_source_role", map[string]tftypes.Value{
+		"user_source": tftypes.NewValue(tftypes.String, "default"),
+		"name":        tftypes.NewValue(tftypes.String, "Operator"),
+	})
+	expected := tftypes.NewValue(identity.Type(), map[string]tftypes.Value{
+		"user_source": tftypes.NewValue(tftypes.String, "default"),
+		"name":        tftypes.NewValue(tftypes.String, "Operator"),
+	})
+	if !identity.Equal(expected) {
+		t.Errorf("Unexpected identity: %s", identity)
+	}
+
+	// Roles can be imported by their identity
+	imported := importResource(t, p, "ignition_user_source_role", map[string]tftypes.Value{
+		"user_source": tftypes.NewValue(tftypes.String, "default"),
+		"name":        tftypes.NewValue(tftypes.String, "Operator"),
+	})
+	var attributes map[string]tftypes.Value
+	if err := imported.As(&attributes); err != nil {
+		t.Fatalf("failed to decode imported state: %v", err)
+	}
+	if !attributes["id"].Equal(tftypes.NewValue(tftypes.String, "default/Operator")) || !attributes["name"].Equal(tftypes.NewValue(tftypes.String, "Operator")) {
+		t.Errorf("Unexpected imported state: %s", imported)
+	}
+}
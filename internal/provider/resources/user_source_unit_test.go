@@ -2,13 +2,18 @@ package resources
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"regexp"
 	"testing"
 
 	"github.com/apollogeddon/ignition-tfpl/internal/client"
 	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestUnitUserSourceResource(t *testing.T) {
@@ -70,3 +75,140 @@ func TestUnitUserSourceResource(t *testing.T) {
 		},
 	})
 }
+
+func TestUnitUserSourceResource_Hybrid(t *testing.T) {
+	var current client.UserSourceConfig
+	mockClient := &client.MockClient{
+		EncryptSecretFunc: func(ctx context.Context, p string) (*client.IgnitionSecret, error) {
+			return &client.IgnitionSecret{Type: "Embedded", Data: "encrypted:" + p}, nil
+		},
+		CreateUserSourceFunc: func(ctx context.Context, us client.ResourceResponse[client.UserSourceConfig]) (*client.ResourceResponse[client.UserSourceConfig], error) {
+			current = us.Config
+			us.Signature = "sig-123"
+			return &us, nil
+		},
+		GetUserSourceFunc: func(ctx context.Context, name string) (*client.ResourceResponse[client.UserSourceConfig], error) {
+			// The gateway does not return secrets
+			settings := maps.Clone(current.Settings)
+			delete(settings, "gatewayPassword")
+			return &client.ResourceResponse[client.UserSourceConfig]{
+				Name:      name,
+				Enabled:   base.BoolPtr(true),
+				Signature: "sig-123",
+				Config:    client.UserSourceConfig{Profile: current.Profile, Settings: settings},
+			}, nil
+		},
+		DeleteUserSourceFunc: func(ctx context.Context, name, signature string) error {
+			return nil
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			ResourceFactory: NewUserSourceResource,
+			Client:          mockClient,
+		}),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_user_source" "test" {
+						name = "corp"
+						type = "DATASOURCE"
+					}
+				`,
+				ExpectError: regexp.MustCompile("database is required when type is DATASOURCE"),
+			},
+			{
+				Config: `
+					provider "ignition" {
+						host  = "http://mock-host"
+						token = "mock-token"
+					}
+					resource "ignition_user_source" "test" {
+						name = "corp"
+						type = "AD_DB_HYBRID"
+						active_directory = {
+							domain        = "corp.example.com"
+							primary_host  = "dc1.corp.example.com"
+							use_ssl       = true
+							primary_port  = 636
+							bind_username = "svc-ignition"
+							bind_password = "hunter2"
+							role_filter   = "(objectClass=group)"
+						}
+						database = {
+							datasource = "users"
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_user_source.test", "active_directory.primary_port", "636"),
+					resource.TestCheckResourceAttr("ignition_user_source.test", "active_directory.secondary_port", "389"),
+					resource.TestCheckResourceAttr("ignition_user_source.test", "active_directory.bind_password", "hunter2"),
+					resource.TestCheckResourceAttr("ignition_user_source.test", "database.schema_mode", "AUTOMATIC"),
+					func(s *terraform.State) error {
+						if password, ok := current.Settings["gatewayPassword"].(map[string]any); !ok || password["data"] != "encrypted:hunter2" {
+							return fmt.Errorf("expected the bind password to be encrypted, got %v", current.Settings["gatewayPassword"])
+						}
+						if current.Settings["datasource"] != "users" || current.Settings["domain"] != "corp.example.com" {
+							return fmt.Errorf("expected both settings to be sent, got %v", current.Settings)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitUserSourceSettings(t *testing.T) {
+	ctx := context.Background()
+	r := &UserSourceResource{}
+	r.Client = &client.MockClient{
+		EncryptSecretFunc: func(ctx context.Context, p string) (*client.IgnitionSecret, error) {
+			return &client.IgnitionSecret{Type: "Embedded", Data: "encrypted:" + p}, nil
+		},
+	}
+
+	model := UserSourceResourceModel{
+		Type: types.StringValue("ADHYBRID"),
+		ActiveDirectory: &UserSourceActiveDirectory{
+			Domain:       types.StringValue("corp.example.com"),
+			PrimaryHost:  types.StringValue("dc1"),
+			PrimaryPort:  types.Int64Value(389),
+			BindPassword: types.StringValue("hunter2"),
+		},
+	}
+	config, err := r.MapPlanToClient(ctx, &model)
+	if err != nil {
+		t.Fatalf("MapPlanToClient failed: %v", err)
+	}
+	if secret, ok := config.Settings["gatewayPassword"].(map[string]any); !ok || secret["data"] != "encrypted:hunter2" {
+		t.Errorf("Expected the encrypted bind password, got %v", config.Settings["gatewayPassword"])
+	}
+	if _, ok := config.Settings["datasource"]; ok {
+		t.Errorf("Expected no database settings, got %v", config.Settings)
+	}
+
+	delete(config.Settings, "gatewayPassword")
+	if err := r.MapClientToState(ctx, "corp", &config, &model); err != nil {
+		t.Fatalf("MapClientToState failed: %v", err)
+	}
+	if model.ActiveDirectory.BindPassword.ValueString() != "hunter2" {
+		t.Errorf("Expected the bind password in state to be kept, got %v", model.ActiveDirectory.BindPassword)
+	}
+	if model.ActiveDirectory.SecondaryPort.ValueInt64() != 389 || !model.ActiveDirectory.SecondaryHost.IsNull() {
+		t.Errorf("Unexpected secondary domain controller: %+v", model.ActiveDirectory)
+	}
+	if model.Database != nil {
+		t.Errorf("Expected no database settings, got %+v", model.Database)
+	}
+}
@@ -0,0 +1,394 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserSourceUserResource{}
+var _ resource.ResourceWithImportState = &UserSourceUserResource{}
+var _ resource.ResourceWithIdentity = &UserSourceUserResource{}
+var _ resource.ResourceWithModifyPlan = &UserSourceUserResource{}
+var _ base.ResourceWithReferences = &UserSourceUserResource{}
+
+func NewUserSourceUserResource() resource.Resource {
+	return &UserSourceUserResource{}
+}
+
+// UserSourceUserResource manages a user of an internal user source through the
+// gateway's user management endpoints.
+type UserSourceUserResource struct {
+	client     client.IgnitionClient
+	references *base.ReferenceValidator
+}
+
+// UserSourceUserResourceModel describes the resource data model.
+type UserSourceUserResourceModel struct {
+	Id              types.String                 `tfsdk:"id"`
+	UserSource      types.String                 `tfsdk:"user_source"`
+	Username        types.String                 `tfsdk:"username"`
+	FirstName       types.String                 `tfsdk:"first_name"`
+	LastName        types.String                 `tfsdk:"last_name"`
+	Roles           types.Set                    `tfsdk:"roles"`
+	ContactInfo     []UserSourceUserContactModel `tfsdk:"contact_info"`
+	Schedule        types.String                 `tfsdk:"schedule"`
+	Language        types.String                 `tfsdk:"language"`
+	Badge           types.String                 `tfsdk:"badge"`
+	Password        types.String                 `tfsdk:"password"`
+	PasswordVersion types.String                 `tfsdk:"password_version"`
+}
+
+// UserSourceUserIdentityModel describes the identity of a user: its user source
+// and username.
+type UserSourceUserIdentityModel struct {
+	UserSource types.String `tfsdk:"user_source"`
+	Username   types.String `tfsdk:"username"`
+}
+
+// UserSourceUserContactModel describes a contact info entry of a user.
+type UserSourceUserContactModel struct {
+	Type  types.String `tfsdk:"type"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (r *UserSourceUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_source_user"
+}
+
+func (r *UserSourceUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a user of an internal user source in Ignition.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The user source and username, separated by a slash.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_source": schema.StringAttribute{
+				Description: "The name of the internal user source the user belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "The username the user logs in with.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"first_name": schema.StringAttribute{
+				Description: "The first name of the user.",
+				Optional:    true,
+			},
+			"last_name": schema.StringAttribute{
+				Description: "The last name of the user.",
+				Optional:    true,
+			},
+			"roles": schema.SetAttribute{
+				Description: "The roles of the user, which must exist in the user source (e.g., created with `ignition_user_source_role`).",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"contact_info": schema.ListNestedAttribute{
+				Description: "The ways of contacting the user, used by alarm notification.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "The type of contact info. One of: email, phone, sms.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("email", "phone", "sms"),
+							},
+						},
+						"value": schema.StringAttribute{
+							Description: "The email address or phone number.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"schedule": schema.StringAttribute{
+				Description: "The schedule during which the user is considered on call. Defaults to `Always`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("Always"),
+			},
+			"language": schema.StringAttribute{
+				Description: "The preferred language of the user (e.g., en_US).",
+				Optional:    true,
+			},
+			"badge": schema.StringAttribute{
+				Description: "The badge the user can log in with, where badge authentication is enabled.",
+				Optional:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "The password of the user. It is write-only: it is never stored in state, and it is " +
+					"only sent on create or when `password_version` changes. Requires Terraform 1.11 or later.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"password_version": schema.StringAttribute{
+				Description: "Any value; changing it sends `password` to the gateway again.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *UserSourceUserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	apiClient, ok := req.ProviderData.(client.IgnitionClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.IgnitionClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = apiClient
+	r.references = base.ReferenceValidatorFrom(req.ProviderData)
+}
+
+// mapPlanToClient converts the model into the gateway's representation, without
+// the password
+func (r *UserSourceUserResource) mapPlanToClient(ctx context.Context, data *UserSourceUserResourceModel) (client.UserSourceUser, diag.Diagnostics) {
+	u := client.UserSourceUser{
+		Username:    data.Username.ValueString(),
+		FirstName:   data.FirstName.ValueString(),
+		LastName:    data.LastName.ValueString(),
+		Schedule:    data.Schedule.ValueString(),
+		Language:    data.Language.ValueString(),
+		Badge:       data.Badge.ValueString(),
+		Roles:       []string{},
+		ContactInfo: []client.UserSourceContactInfo{},
+	}
+
+	var diags diag.Diagnostics
+	if !data.Roles.IsNull() {
+		diags.Append(data.Roles.ElementsAs(ctx, &u.Roles, false)...)
+	}
+	for _, c := range data.ContactInfo {
+		u.ContactInfo = append(u.ContactInfo, client.UserSourceContactInfo{
+			Type:  c.Type.ValueString(),
+			Value: c.Value.ValueString(),
+		})
+	}
+	return u, diags
+}
+
+// mapClientToState updates the model from the gateway's representation. The
+// password and its version are left as they are.
+func (r *UserSourceUserResource) mapClientToState(ctx context.Context, u *client.UserSourceUser, data *UserSourceUserResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Id = types.StringValue(data.UserSource.ValueString() + "/" + u.Username)
+	data.Username = types.StringValue(u.Username)
+	data.FirstName = base.StringToNullableString(u.FirstName)
+	data.LastName = base.StringToNullableString(u.LastName)
+	data.Language = base.StringToNullableString(u.Language)
+	data.Badge = base.StringToNullableString(u.Badge)
+	if u.Schedule != "" {
+		data.Schedule = types.StringValue(u.Schedule)
+	} else {
+		data.Schedule = types.StringValue("Always")
+	}
+
+	if len(u.Roles) > 0 {
+		var d diag.Diagnostics
+		data.Roles, d = types.SetValueFrom(ctx, types.StringType, u.Roles)
+		diags.Append(d...)
+	} else if data.Roles.IsNull() || data.Roles.IsUnknown() {
+		data.Roles = types.SetNull(types.StringType)
+	} else {
+		// An empty set stays as configured
+		data.Roles = types.SetValueMust(types.StringType, []attr.Value{})
+	}
+
+	if data.ContactInfo != nil {
+		// An empty list stays as configured
+		data.ContactInfo = []UserSourceUserContactModel{}
+	}
+	for _, c := range u.ContactInfo {
+		data.ContactInfo = append(data.ContactInfo, UserSourceUserContactModel{
+			Type:  types.StringValue(c.Type),
+			Value: types.StringValue(c.Value),
+		})
+	}
+	return diags
+}
+
+func (r *UserSourceUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserSourceUserResourceModel
+	var password types.String
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	u, diags := r.mapPlanToClient(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	u.Password = password.ValueString()
+
+	created, err := r.client.CreateUserSourceUser(ctx, data.UserSource.ValueString(), u)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating user source user", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.mapClientToState(ctx, created, &data)...)
+	data.Password = types.StringNull()
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(setUserSourceUserIdentity(ctx, resp.Identity, &data)...)
+}
+
+func (r *UserSourceUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserSourceUserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	u, err := r.client.GetUserSourceUser(ctx, data.UserSource.ValueString(), data.Username.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading user source user", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.mapClientToState(ctx, u, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(setUserSourceUserIdentity(ctx, resp.Identity, &data)...)
+}
+
+func (r *UserSourceUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state UserSourceUserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	u, diags := r.mapPlanToClient(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The password is only sent again when its version changes, as write-only
+	// values cannot be compared with what was last sent
+	if !data.PasswordVersion.Equal(state.PasswordVersion) {
+		var password types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		u.Password = password.ValueString()
+	}
+
+	updated, err := r.client.UpdateUserSourceUser(ctx, data.UserSource.ValueString(), u)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating user source user", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.mapClientToState(ctx, updated, &data)...)
+	data.Password = types.StringNull()
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(setUserSourceUserIdentity(ctx, resp.Identity, &data)...)
+}
+
+func (r *UserSourceUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserSourceUserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteUserSourceUser(ctx, data.UserSource.ValueString(), data.Username.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error deleting user source user", err.Error())
+	}
+}
+
+func (r *UserSourceUserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.references.Check(ctx, req, resp, r.ReferenceAttributes()...)
+}
+
+func (r *UserSourceUserResource) ReferenceAttributes() []base.Reference {
+	return []base.Reference{
+		{Path: path.Root("user_source"), Kind: "user source", Module: "ignition", ResourceType: "user-source"},
+	}
+}
+
+func (r *UserSourceUserResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = userSourceIdentitySchema("username", "The username of the user.")
+}
+
+// setUserSourceUserIdentity records the identity of a user. It is a no-op when
+// the caller does not support identity.
+func setUserSourceUserIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, data *UserSourceUserResourceModel) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, UserSourceUserIdentityModel{UserSource: data.UserSource, Username: data.Username})
+}
+
+// ImportState imports a user by an import ID of the form user_source/username,
+// or by its identity. The password is not imported; set password_version to
+// send it.
+func (r *UserSourceUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var userSource, username string
+	if req.ID == "" && req.Identity != nil {
+		var identity UserSourceUserIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		userSource, username = identity.UserSource.ValueString(), identity.Username.ValueString()
+	} else {
+		var ok bool
+		if userSource, username, ok = parseUserSourceImportID(req.ID); !ok {
+			resp.Diagnostics.AddError(
+				"Unexpected Import Identifier",
+				fmt.Sprintf("Expected an import ID of the form user_source/username, got: %q.", req.ID),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_source"), userSource)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), username)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), userSource+"/"+username)...)
+}
@@ -0,0 +1,217 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/provider/base"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestUnitUserSourceUserResource(t *testing.T) {
+	var stored *client.UserSourceUser
+	var passwords []string
+
+	// store keeps the user as the gateway would, without its password
+	store := func(u client.UserSourceUser) *client.UserSourceUser {
+		if u.Password != "" {
+			passwords = append(passwords, u.Password)
+		}
+		u.Password = ""
+		slices.Sort(u.Roles)
+		stored = &u
+		return &u
+	}
+
+	mockClient := &client.MockClient{
+		CreateUserSourceUserFunc: func(ctx context.Context, userSource string, u client.UserSourceUser) (*client.UserSourceUser, error) {
+			return store(u), nil
+		},
+		UpdateUserSourceUserFunc: func(ctx context.Context, userSource string, u client.UserSourceUser) (*client.UserSourceUser, error) {
+			return store(u), nil
+		},
+		GetUserSourceUserFunc: func(ctx context.Context, userSource, username string) (*client.UserSourceUser, error) {
+			if stored == nil || stored.Username != username {
+				return nil, fmt.Errorf("user not found: %s", username)
+			}
+			return stored, nil
+		},
+		DeleteUserSourceUserFunc: func(ctx context.Context, userSource, username string) error {
+			stored = nil
+			return nil
+		},
+	}
+
+	providerFactories := map[string]func() (tfprotov6.ProviderServer, error){
+		"ignition": providerserver.NewProtocol6WithError(&base.TestProvider{
+			ResourceFactory: NewUserSourceUserResource,
+			Client:          mockClient,
+		}),
+	}
+
+	config := func(lastName, passwordVersion string) string {
+		return fmt.Sprintf(`
+			provider "ignition" {
+				host  = "http://mock-host"
+				token = "mock-token"
+			}
+			resource "ignition_user_source_user" "test" {
+				user_source      = "default"
+				username         = "jdoe"
+				first_name       = "Jane"
+				last_name        = %q
+				roles            = ["Supervisor", "Operator"]
+				password         = "secret-%s"
+				password_version = %q
+				contact_info = [
+					{ type = "email", value = "jdoe@example.com" },
+				]
+			}
+		`, lastName, passwordVersion, passwordVersion)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Doe", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_user_source_user.test", "id", "default/jdoe"),
+					resource.TestCheckResourceAttr("ignition_user_source_user.test", "schedule", "Always"),
+					resource.TestCheckResourceAttr("ignition_user_source_user.test", "roles.#", "2"),
+					resource.TestCheckResourceAttr("ignition_user_source_user.test", "contact_info.0.type", "email"),
+					resource.TestCheckNoResourceAttr("ignition_user_source_user.test", "password"),
+				),
+			},
+			{
+				// The password is only sent again when its version changes
+				Config: config("Smith", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ignition_user_source_user.test", "last_name", "Smith"),
+					func(*terraform.State) error {
+						if !slices.Equal(passwords, []string{"secret-1"}) {
+							return fmt.Errorf("expected the password to be sent once, got %v", passwords)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: config("Smith", "2"),
+				Check: func(*terraform.State) error {
+					if !slices.Equal(passwords, []string{"secret-1", "secret-2"}) {
+						return fmt.Errorf("expected the new password to be sent, got %v", passwords)
+					}
+					return nil
+				},
+			},
+			{
+				ResourceName:            "ignition_user_source_user.test",
+				ImportState:             true,
+				ImportStateId:           "default/jdoe",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password_version"},
+			},
+		},
+	})
+}
+
+func TestUnitUserSourceUserMapping(t *testing.T) {
+	ctx := context.Background()
+	r := &UserSourceUserResource{}
+
+	roles, _ := types.SetValueFrom(ctx, types.StringType, []string{"Operator"})
+	model := UserSourceUserResourceModel{
+		UserSource: types.StringValue("default"),
+		Username:   types.StringValue("jdoe"),
+		FirstName:  types.StringValue("Jane"),
+		LastName:   types.StringNull(),
+		Roles:      roles,
+		Schedule:   types.StringValue("Always"),
+		ContactInfo: []UserSourceUserContactModel{
+			{Type: types.StringValue("sms"), Value: types.StringValue("+15550100")},
+		},
+		Password:        types.StringNull(),
+		PasswordVersion: types.StringValue("1"),
+	}
+	u, diags := r.mapPlanToClient(ctx, &model)
+	if diags.HasError() {
+		t.Fatalf("mapPlanToClient failed: %v", diags)
+	}
+	if u.Password != "" || !slices.Equal(u.Roles, []string{"Operator"}) || len(u.ContactInfo) != 1 || u.ContactInfo[0].Type != "sms" {
+		t.Errorf("Unexpected user: %+v", u)
+	}
+
+	// Users without roles or contact info send empty lists, so that they are cleared
+	model.Roles = types.SetNull(types.StringType)
+	model.ContactInfo = nil
+	if u, _ = r.mapPlanToClient(ctx, &model); u.Roles == nil || u.ContactInfo == nil {
+		t.Errorf("Expected empty roles and contact info, got %+v", u)
+	}
+
+	if diags := r.mapClientToState(ctx, &client.UserSourceUser{Username: "jdoe", FirstName: "Jane"}, &model); diags.HasError() {
+		t.Fatalf("mapClientToState failed: %v", diags)
+	}
+	if model.Id.ValueString() != "default/jdoe" || model.Schedule.ValueString() != "Always" || !model.Roles.IsNull() || model.ContactInfo != nil {
+		t.Errorf("Unexpected state: %+v", model)
+	}
+	if model.PasswordVersion.ValueString() != "1" {
+		t.Errorf("Expected the password version to be kept, got %v", model.PasswordVersion)
+	}
+}
+
+func TestUnitUserSourceUserApply(t *testing.T) {
+	mockClient := &client.MockClient{
+		CreateUserSourceUserFunc: func(ctx context.Context, userSource string, u client.UserSourceUser) (*client.UserSourceUser, error) {
+			// The gateway leaves out roles and contact info a user has none of
+			u.Password, u.Roles, u.ContactInfo = "", nil, nil
+			return &u, nil
+		},
+	}
+	p := &base.TestProvider{ResourceFactory: NewUserSourceUserResource, Client: mockClient}
+
+	contactType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"type": tftypes.String, "value": tftypes.String}}
+	state, identity := applyResource(t, p, "ignition_user_source_user", map[string]tftypes.Value{
+		"user_source":  tftypes.NewValue(tftypes.String, "default"),
+		"username":     tftypes.NewValue(tftypes.String, "jdoe"),
+		"roles":        tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{}),
+		"contact_info": tftypes.NewValue(tftypes.List{ElementType: contactType}, []tftypes.Value{}),
+	})
+
+	// Empty lists stay as configured, rather than becoming null
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatalf("failed to decode state: %v", err)
+	}
+	if attributes["roles"].IsNull() || attributes["contact_info"].IsNull() {
+		t.Errorf("Expected empty roles and contact info, got %s and %s", attributes["roles"], attributes["contact_info"])
+	}
+
+	expected := tftypes.NewValue(identity.Type(), map[string]tftypes.Value{
+		"user_source": tftypes.NewValue(tftypes.String, "default"),
+		"username":    tftypes.NewValue(tftypes.String, "jdoe"),
+	})
+	if !identity.Equal(expected) {
+		t.Errorf("Unexpected identity: %s", identity)
+	}
+
+	// Users can be imported by their identity
+	imported := importResource(t, p, "ignition_user_source_user", map[string]tftypes.Value{
+		"user_source": tftypes.NewValue(tftypes.String, "default"),
+		"username":    tftypes.NewValue(tftypes.String, "jdoe"),
+	})
+	if err := imported.As(&attributes); err != nil {
+		t.Fatalf("failed to decode imported state: %v", err)
+	}
+	if !attributes["id"].Equal(tftypes.NewValue(tftypes.String, "default/jdoe")) {
+		t.Errorf("Unexpected imported ID: %s", attributes["id"])
+	}
+}
@@ -0,0 +1,250 @@
+// Package recorder records the HTTP requests that the client makes to a live
+// gateway, and their responses, so that tests can replay them later without a
+// gateway. Secrets and tokens are scrubbed from recordings.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// ModeEnv is the environment variable that selects whether tests record or
+// replay gateway requests
+const ModeEnv = "IGNITION_FIXTURES"
+
+// Mode is what a Recorder does with requests
+type Mode string
+
+const (
+	// Record sends requests to the gateway and records them with their responses
+	Record Mode = "record"
+	// Replay answers requests with recorded responses, without a gateway
+	Replay Mode = "replay"
+)
+
+// ModeFromEnv returns the mode set by ModeEnv, or an empty mode when it is unset
+func ModeFromEnv() (Mode, error) {
+	switch m := Mode(os.Getenv(ModeEnv)); m {
+	case "", Record, Replay:
+		return m, nil
+	default:
+		return "", fmt.Errorf("%s must be %q or %q, got %q", ModeEnv, Record, Replay, m)
+	}
+}
+
+// Interaction is a recorded request and the response the gateway gave to it
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. Headers are not recorded, so that the API
+// token is never written.
+type Request struct {
+	Method string `json:"method"`
+	// URI is the path and query of the request URL, leaving out the host so
+	// that recordings replay against any gateway URL
+	URI  string `json:"uri"`
+	Body string `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+type fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder records interactions to, or replays them from, a fixture file
+type Recorder struct {
+	mode Mode
+	file string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New returns a Recorder of the given fixture file. In Replay mode, the file is
+// read right away, and an error that wraps fs.ErrNotExist is returned when it
+// has not been recorded.
+func New(file string, mode Mode) (*Recorder, error) {
+	r := &Recorder{mode: mode, file: file}
+	switch mode {
+	case Record:
+		return r, nil
+	case Replay:
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var f fixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", file, err)
+		}
+		r.interactions = f.Interactions
+		r.used = make([]bool, len(f.Interactions))
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unknown mode %q", mode)
+	}
+}
+
+// Mode returns the mode of r
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Transport returns a RoundTripper that records the requests it sends through
+// next, or that replays recorded responses. A nil next sends requests through
+// http.DefaultTransport.
+func (r *Recorder) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{recorder: r, next: next}
+}
+
+// Save writes the recorded interactions to the fixture file. It is a no-op
+// when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != Record {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(fixture{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.file), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.file, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) record(i Interaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, i)
+}
+
+// replay returns the response to req: that of the first unused recorded
+// interaction with the same method, URI and body, or else that of the last
+// one, so that extra reads of an unchanged resource are answered too
+func (r *Recorder) replay(req Request) (*Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+	for i, recorded := range r.interactions {
+		if recorded.Request != req {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return &r.interactions[i].Response, nil
+		}
+		last = i
+	}
+	if last >= 0 {
+		return &r.interactions[last].Response, nil
+	}
+	return nil, fmt.Errorf("%s has no recorded response to %s %s; record it again with %s=%s", r.file, req.Method, req.URI, ModeEnv, Record)
+}
+
+type transport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := Request{
+		Method: req.Method,
+		URI:    req.URL.RequestURI(),
+		Body:   scrubRequest(req.URL.Path, body),
+	}
+
+	if t.recorder.mode == Replay {
+		res, err := t.recorder.replay(recorded)
+		if err != nil {
+			return nil, err
+		}
+		header := make(http.Header)
+		if res.ContentType != "" {
+			header.Set("Content-Type", res.ContentType)
+		}
+		header.Set("Content-Length", strconv.Itoa(len(res.Body)))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", res.Status, http.StatusText(res.Status)),
+			StatusCode:    res.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader([]byte(res.Body))),
+			ContentLength: int64(len(res.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		// Failed connections are left out, as they have no response to replay
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	t.recorder.record(Interaction{
+		Request: recorded,
+		Response: Response{
+			Status:      res.StatusCode,
+			ContentType: res.Header.Get("Content-Type"),
+			Body:        scrub(resBody),
+		},
+	})
+	return res, nil
+}
+
+// readBody reads the body of req, leaving it in place to be sent
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// IsNotRecorded reports whether err was returned by New for a fixture file
+// that has not been recorded
+func IsNotRecorded(err error) bool {
+	return errors.Is(err, os.ErrNotExist)
+}
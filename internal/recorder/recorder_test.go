@@ -0,0 +1,162 @@
+package recorder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apollogeddon/ignition-tfpl/internal/client"
+	"github.com/apollogeddon/ignition-tfpl/internal/fakegateway"
+)
+
+func newTestClient(t *testing.T, host, token string, rec *Recorder) *client.Client {
+	t.Helper()
+
+	c, err := client.NewClient(host, token, false)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.HTTPClient.RetryMax = 0
+	c.HTTPClient.RetryWaitMin = 10 * time.Millisecond
+	c.HTTPClient.HTTPClient.Transport = rec.Transport(c.HTTPClient.HTTPClient.Transport)
+	return c
+}
+
+// exercise creates a database connection with an encrypted password and reads
+// it back, returning what was read
+func exercise(t *testing.T, c *client.Client) *client.ResourceResponse[client.DatabaseConfig] {
+	t.Helper()
+	ctx := client.WithCollection(context.Background(), "core")
+
+	secret, err := c.EncryptSecret(ctx, "hunter2")
+	if err != nil {
+		t.Fatalf("EncryptSecret failed: %v", err)
+	}
+	_, err = c.CreateDatabaseConnection(ctx, client.ResourceResponse[client.DatabaseConfig]{
+		Name: "mes",
+		Config: client.DatabaseConfig{
+			Driver:     "PostgreSQL",
+			ConnectURL: "jdbc:postgresql://db/mes",
+			Password:   secret,
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateDatabaseConnection failed: %v", err)
+	}
+	db, err := c.GetDatabaseConnection(ctx, "mes")
+	if err != nil {
+		t.Fatalf("GetDatabaseConnection failed: %v", err)
+	}
+	return db
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "fixtures", "test.json")
+
+	g := fakegateway.New()
+	rec, err := New(file, Record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded := exercise(t, newTestClient(t, g.URL, g.Token, rec))
+	g.Close()
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{g.Token, "hunter2", "aHVudGVyMg"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expected %q to be scrubbed from the recording:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), `"uri": "/data/api/v1/resources/find/ignition/database-connection/mes?collection=core"`) {
+		t.Errorf("Expected the read to be recorded with its query:\n%s", data)
+	}
+
+	// The gateway is gone, and the replaying client points elsewhere
+	rec, err = New(file, Replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := exercise(t, newTestClient(t, "http://replay.invalid", "other:token", rec))
+	if replayed.Signature == "" || replayed.Signature != recorded.Signature {
+		t.Errorf("Expected the recorded signature %q, got %q", recorded.Signature, replayed.Signature)
+	}
+	if replayed.Config.ConnectURL != "jdbc:postgresql://db/mes" {
+		t.Errorf("Expected the recorded config, got %+v", replayed.Config)
+	}
+
+	// Reads beyond those recorded get the last recorded response
+	if _, err := newTestClient(t, "http://replay.invalid", "other:token", rec).GetDatabaseConnection(client.WithCollection(context.Background(), "core"), "mes"); err != nil {
+		t.Errorf("Expected a repeated read to be replayed, got %v", err)
+	}
+
+	_, err = newTestClient(t, "http://replay.invalid", "other:token", rec).GetDatabaseConnection(context.Background(), "erp")
+	if err == nil || !strings.Contains(err.Error(), "no recorded response to GET /data/api/v1/resources/find/ignition/database-connection/erp") {
+		t.Errorf("Expected an unrecorded request to fail, got %v", err)
+	}
+}
+
+func TestRecorder_NotRecorded(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), Replay)
+	if !IsNotRecorded(err) {
+		t.Errorf("Expected a missing fixture to be reported as not recorded, got %v", err)
+	}
+}
+
+func TestModeFromEnv(t *testing.T) {
+	t.Setenv(ModeEnv, "replay")
+	if m, err := ModeFromEnv(); err != nil || m != Replay {
+		t.Errorf("Expected replay, got %q, %v", m, err)
+	}
+
+	t.Setenv(ModeEnv, "rewind")
+	if _, err := ModeFromEnv(); err == nil {
+		t.Error("Expected an unknown mode to be rejected")
+	}
+}
+
+func TestScrub(t *testing.T) {
+	tests := []struct {
+		name, body, want string
+	}{
+		{
+			name: "normalized",
+			body: `{"b": 1, "a": "<x>"}`,
+			want: `{"a":"<x>","b":1}`,
+		},
+		{
+			name: "secret keys",
+			body: `[{"config": {"password": "hunter2", "clientSecret": "s3cr3t", "tokenEndpoint": "https://idp/token"}}]`,
+			want: `[{"config":{"clientSecret":"REDACTED","password":"REDACTED","tokenEndpoint":"https://idp/token"}}]`,
+		},
+		{
+			name: "embedded secret",
+			body: `{"password": {"type": "Embedded", "data": {"protected": "p", "iv": "i", "ciphertext": "c", "tag": "t"}}}`,
+			want: `{"password":{"data":{"ciphertext":"REDACTED","iv":"REDACTED","protected":"REDACTED","tag":"REDACTED"},"type":"Embedded"}}`,
+		},
+		{
+			name: "not JSON",
+			body: "plain text",
+			want: "plain text",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scrub([]byte(tt.body)); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	if got := scrubRequest("/data/api/v1/encryption/encrypt", []byte("hunter2")); got != redacted {
+		t.Errorf("Expected the plaintext of an encryption request to be redacted, got %q", got)
+	}
+}
@@ -0,0 +1,85 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// redacted replaces the secrets scrubbed from recordings
+const redacted = "REDACTED"
+
+// secretKeys are the lower case keys whose string values are secrets
+var secretKeys = map[string]bool{
+	"password":     true,
+	"bindpassword": true,
+	"secret":       true,
+	"clientsecret": true,
+	"token":        true,
+	"apitoken":     true,
+	"privatekey":   true,
+}
+
+// jweMembers are the members of a JSON Web Encryption object, in which the
+// gateway returns encrypted secrets
+var jweMembers = []string{"protected", "encrypted_key", "iv", "ciphertext", "tag", "aad"}
+
+// scrubRequest returns the body of a request to path as it is recorded. The
+// plaintext sent to the encryption endpoint is redacted as a whole.
+func scrubRequest(path string, body []byte) string {
+	if len(body) > 0 && strings.HasSuffix(path, "/encryption/encrypt") {
+		return redacted
+	}
+	return scrub(body)
+}
+
+// scrub returns body with its secrets redacted. JSON bodies are normalized, so
+// that recorded and replayed requests compare equal regardless of key order.
+func scrub(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return string(body)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(scrubValue(v)); err != nil {
+		return string(body)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func scrubValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		if _, ok := v["ciphertext"]; ok {
+			for _, member := range jweMembers {
+				if _, ok := v[member]; ok {
+					v[member] = redacted
+				}
+			}
+		}
+		for k, value := range v {
+			if _, ok := value.(string); ok && secretKeys[strings.ToLower(k)] {
+				v[k] = redacted
+				continue
+			}
+			v[k] = scrubValue(value)
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = scrubValue(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/apollogeddon/ignition-tfpl/internal/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -15,6 +16,10 @@ import (
 var version = "1.0.0" // x-release-please-version
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(runExport(context.Background(), os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")